@@ -18,15 +18,28 @@
 package main
 
 import (
+	"errors"
+	"io/fs"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/durudex/durudex-auth-service/internal/audit"
 	"github.com/durudex/durudex-auth-service/internal/client"
 	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/event"
+	"github.com/durudex/durudex-auth-service/internal/idempotency"
+	"github.com/durudex/durudex-auth-service/internal/metrics"
+	"github.com/durudex/durudex-auth-service/internal/outbox"
+	"github.com/durudex/durudex-auth-service/internal/recovery"
 	"github.com/durudex/durudex-auth-service/internal/repository"
+	"github.com/durudex/durudex-auth-service/internal/risk"
+	"github.com/durudex/durudex-auth-service/internal/saga"
 	"github.com/durudex/durudex-auth-service/internal/service"
 	"github.com/durudex/durudex-auth-service/internal/transport/grpc"
+	"github.com/durudex/durudex-auth-service/internal/transport/http"
+	"github.com/durudex/durudex-auth-service/internal/webhook"
+	"github.com/durudex/durudex-auth-service/pkg/geoip"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -50,12 +63,108 @@ func main() {
 		log.Fatal().Err(err).Msg("error creating a new config")
 	}
 
+	// Run schema migrations subcommand.
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(cfg.Database, os.Args[2:]); err != nil {
+			log.Fatal().Err(err).Msg("error running schema migrations")
+		}
+
+		return
+	}
+
+	// Checking database schema version.
+	if err := checkSchema(cfg.Database); err != nil {
+		log.Fatal().Err(err).Msg("error checking database schema")
+	}
+
 	// Creating a new repository.
-	repos := repository.NewRepository(cfg.Database)
+	repos, err := repository.NewRepository(cfg.Database)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error creating a new repository")
+	}
+
 	// Creating a new client.
 	client := client.NewClient(cfg.Service)
+
+	// Creating a new auth audit log.
+	auditLog := audit.NewLog(repos.Audit, cfg.Audit)
+
+	// Run auth audit log writer.
+	recovery.Go("auth audit log writer", auditLog.Run)
+
+	// Creating a new auth audit cleaner.
+	auditCleaner := audit.NewCleaner(repos.Audit, cfg.Audit)
+
+	// Run auth audit cleaner.
+	recovery.Go("auth audit cleaner", auditCleaner.Run)
+
+	// Creating new auth domain events.
+	events, err := event.NewEvents(cfg.Events, repos.EventOutbox)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error creating auth domain events")
+	}
+
+	// Run auth domain event outbox relay.
+	if events.Relay != nil {
+		recovery.Go("event outbox relay", events.Relay.Run)
+	}
+
+	// Auth domain events are published to the message bus and webhooks.
+	publisher := event.Publishers{events, webhook.NewPublisher(repos.WebhookDelivery)}
+
+	// Creating a new ip address locator, locations are empty without the GeoIP database.
+	var (
+		locator   geoip.Locator = geoip.Nop{}
+		geoReader *geoip.Reader
+	)
+
+	// Opening the GeoIP database, missing database file disables ip address locations.
+	if cfg.GeoIP.Database != "" {
+		geoReader, err = geoip.Open(cfg.GeoIP.Database)
+
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			log.Warn().Str("database", cfg.GeoIP.Database).Msg("GeoIP database is not found, ip address locations are disabled")
+		case err != nil:
+			log.Fatal().Err(err).Msg("error opening GeoIP database")
+		default:
+			locator = geoReader
+		}
+	}
+
+	// Creating a new sign in risk assessor.
+	assessor, err := risk.New(repos.Risk, repos.Audit, locator, cfg.Risk)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error creating sign in risk assessor")
+	}
+
 	// Creating a new service.
-	service := service.NewService(repos, client, cfg)
+	service := service.NewService(repos, client, auditLog, publisher, locator, assessor, cfg)
+
+	// Creating a new webhook dispatcher.
+	webhookDispatcher := webhook.NewDispatcher(repos.Webhook, repos.WebhookDelivery, cfg.Webhook)
+
+	// Run webhook dispatcher.
+	recovery.Go("webhook dispatcher", webhookDispatcher.Run)
+
+	// Creating a new email outbox dispatcher.
+	dispatcher := outbox.NewDispatcher(repos.Outbox, client.Email, cfg.Outbox)
+
+	// Run email outbox dispatcher.
+	recovery.Go("email outbox dispatcher", dispatcher.Run)
+
+	// Creating a new sign up saga recovery worker.
+	recoverer := saga.NewRecoverer(repos.Saga, service.SignUp, cfg.Saga)
+
+	// Run sign up saga recovery worker.
+	recovery.Go("sign up saga recoverer", recoverer.Run)
+
+	// Creating a new expired idempotency keys cleaner.
+	cleaner := idempotency.NewCleaner(repos.Idempotency, cfg.Idempotency)
+
+	// Run expired idempotency keys cleaner.
+	recovery.Go("idempotency keys cleaner", cleaner.Run)
+
 	// Creating a new gRPC handler.
 	handler := grpc.NewHandler(service)
 
@@ -63,21 +172,86 @@ func main() {
 	srv := grpc.NewServer(cfg.GRPC, handler)
 
 	// Run server.
-	go srv.Run()
+	recovery.Go("grpc server", srv.Run)
+
+	// Creating a new in-process gRPC client connection.
+	conn, err := srv.DialLocal()
+	if err != nil {
+		log.Fatal().Err(err).Msg("error creating in-process gRPC connection")
+	}
+
+	// Create a new HTTP gateway server.
+	httpSrv := http.NewServer(cfg.HTTP, http.NewHandler(conn, cfg.HTTP, cfg.Auth))
+
+	// Run HTTP gateway server.
+	if cfg.HTTP.Enable {
+		recovery.Go("http server", httpSrv.Run)
+	}
+
+	// Creating a new metrics server.
+	metricsSrv := metrics.NewServer(cfg.Metrics)
+
+	// Run metrics server.
+	if cfg.Metrics.Enable {
+		recovery.Go("metrics server", metricsSrv.Run)
+	}
 
 	// Quit in application.
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
 	<-quit
 
-	// Closing postgres pool connections.
-	repos.Postgres.Close()
+	// Stopping HTTP gateway server.
+	if cfg.HTTP.Enable {
+		httpSrv.Stop()
+	}
+
+	// Closing in-process gRPC client connection.
+	if err := conn.Close(); err != nil {
+		log.Error().Err(err).Msg("failed to close in-process gRPC connection")
+	}
+
+	// Stopping gRPC and gRPC-Web servers, in-flight calls finish before workers and storage stop.
+	srv.Stop()
+
+	// Stopping metrics server.
+	if cfg.Metrics.Enable {
+		metricsSrv.Stop()
+	}
+
+	// Stopping email outbox dispatcher.
+	dispatcher.Stop()
+
+	// Stopping webhook dispatcher.
+	webhookDispatcher.Stop()
+
+	// Stopping sign up saga recovery worker.
+	recoverer.Stop()
+
+	// Stopping expired idempotency keys cleaner.
+	cleaner.Stop()
+
+	// Stopping auth audit cleaner.
+	auditCleaner.Stop()
+
+	// Stopping event outbox relay and closing message bus connection.
+	events.Close()
+
+	// Stopping auth audit log writer. Buffered events are written before closing storage.
+	auditLog.Stop()
+
+	// Closing storage driver connections.
+	repos.Close()
 
 	// Closing a client connections.
 	client.Close()
 
-	// Stopping server.
-	srv.Stop()
+	// Closing the GeoIP database.
+	if geoReader != nil {
+		if err := geoReader.Close(); err != nil {
+			log.Error().Err(err).Msg("failed to close GeoIP database")
+		}
+	}
 
 	log.Info().Msg("Durudex Auth Service stopping!")
 }
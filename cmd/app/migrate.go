@@ -0,0 +1,89 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/migration"
+	"github.com/durudex/durudex-auth-service/internal/repository"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Schema migrations subcommand usage.
+const migrateUsage string = "usage: app migrate up|down|status"
+
+// Running schema migrations subcommand.
+func runMigrate(cfg config.DatabaseConfig, args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	// Creating a new schema migrator.
+	migrator, err := migration.NewMigrator(cfg.Postgres.URL, cfg.Migrate)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	switch args[0] {
+	case "up":
+		return migrator.Up()
+	case "down":
+		return migrator.Down()
+	case "status":
+		status, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("version: %d\nlatest: %d\ndirty: %t\n", status.Version, status.Latest, status.Dirty)
+
+		return nil
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+// Checking postgres schema version on start. Pending migrations are applied first
+// when auto-migrate is enabled.
+func checkSchema(cfg config.DatabaseConfig) error {
+	if cfg.Driver != repository.DriverPostgres && cfg.Driver != "" {
+		return nil
+	}
+
+	// Creating a new schema migrator.
+	migrator, err := migration.NewMigrator(cfg.Postgres.URL, cfg.Migrate)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	if cfg.Migrate.Auto {
+		log.Info().Msg("Applying schema migrations...")
+
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+	}
+
+	return migrator.Check()
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/recovery"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Period for which the storage is prepared ahead.
+const prepareAhead = time.Hour * 24 * 62
+
+// Auth audit events retention cleaner. Prepares storage for upcoming events and
+// deletes events older than the retention.
+type Cleaner struct {
+	repos postgres.Audit
+	cfg   config.AuditConfig
+	done  chan struct{}
+	stop  chan struct{}
+}
+
+// Creating a new auth audit events retention cleaner.
+func NewCleaner(repos postgres.Audit, cfg config.AuditConfig) *Cleaner {
+	return &Cleaner{
+		repos: repos,
+		cfg:   cfg,
+		done:  make(chan struct{}),
+		stop:  make(chan struct{}),
+	}
+}
+
+// Running auth audit events retention cleaner.
+func (c *Cleaner) Run() {
+	log.Info().Msg("Running auth audit cleaner...")
+
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	clean := func() {
+		recovery.Do("auth audit cleaner", func() {
+			if err := c.Clean(context.Background(), time.Now()); err != nil {
+				log.Error().Err(err).Msg("failed to clean auth audit events")
+			}
+		})
+	}
+
+	// Storage is prepared on start, before the first events are written.
+	clean()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			clean()
+		}
+	}
+}
+
+// Stopping auth audit events retention cleaner. Waits for the current pass.
+func (c *Cleaner) Stop() {
+	log.Info().Msg("Stopping auth audit cleaner...")
+
+	close(c.stop)
+	<-c.done
+}
+
+// Preparing storage and deleting expired auth audit events. Zero retention keeps
+// events forever.
+func (c *Cleaner) Clean(ctx context.Context, now time.Time) error {
+	if err := c.repos.Prepare(ctx, now.Add(prepareAhead)); err != nil {
+		return err
+	}
+
+	if c.cfg.Retention <= 0 {
+		return nil
+	}
+
+	return c.repos.DeleteBefore(ctx, now.Add(-c.cfg.Retention))
+}
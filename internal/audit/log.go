@@ -0,0 +1,171 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/metrics"
+	"github.com/durudex/durudex-auth-service/internal/recovery"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/ksuid"
+)
+
+// Auth audit events recorder interface.
+type Recorder interface {
+	// Recording auth audit events.
+	Record(ctx context.Context, events ...domain.AuditEvent)
+}
+
+// Auth audit log. Events are buffered and written in batches, events are written
+// immediately when the buffer is full or disabled.
+type Log struct {
+	repos  postgres.Audit
+	cfg    config.AuditConfig
+	events chan domain.AuditEvent
+	done   chan struct{}
+	stop   chan struct{}
+}
+
+// Creating a new auth audit log.
+func NewLog(repos postgres.Audit, cfg config.AuditConfig) *Log {
+	return &Log{
+		repos:  repos,
+		cfg:    cfg,
+		events: make(chan domain.AuditEvent, cfg.BufferSize),
+		done:   make(chan struct{}),
+		stop:   make(chan struct{}),
+	}
+}
+
+// Recording auth audit events. Event id and creation time are set when empty.
+func (l *Log) Record(ctx context.Context, events ...domain.AuditEvent) {
+	for i := range events {
+		if events[i].CreatedAt.IsZero() {
+			events[i].CreatedAt = time.Now()
+		}
+
+		if events[i].Id == ksuid.Nil {
+			events[i].Id = newEventId(events[i].CreatedAt)
+		}
+
+		metrics.AuditEventsTotal.WithLabelValues(string(events[i].Kind), string(events[i].Outcome)).Inc()
+	}
+
+	for i, event := range events {
+		select {
+		case l.events <- event:
+			continue
+		default:
+		}
+
+		// Writing the rest events when the buffer is full.
+		l.write(ctx, events[i:])
+
+		return
+	}
+}
+
+// Running auth audit log writer.
+func (l *Log) Run() {
+	log.Info().Msg("Running auth audit log writer...")
+
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			// Writing buffered events before stopping.
+			l.Flush(context.Background())
+
+			return
+		case <-ticker.C:
+			recovery.Do("auth audit log writer", func() {
+				l.Flush(context.Background())
+			})
+		}
+	}
+}
+
+// Stopping auth audit log writer. Waits for buffered events to be written.
+func (l *Log) Stop() {
+	log.Info().Msg("Stopping auth audit log writer...")
+
+	close(l.stop)
+	<-l.done
+}
+
+// Writing buffered auth audit events in batches.
+func (l *Log) Flush(ctx context.Context) {
+	batch := make([]domain.AuditEvent, 0, l.batchSize())
+
+	for {
+		select {
+		case event := <-l.events:
+			batch = append(batch, event)
+
+			if len(batch) < cap(batch) {
+				continue
+			}
+		default:
+		}
+
+		if len(batch) == 0 {
+			return
+		}
+
+		l.write(ctx, batch)
+
+		batch = batch[:0]
+	}
+}
+
+// Writing auth audit events to the repository.
+func (l *Log) write(ctx context.Context, events []domain.AuditEvent) {
+	if err := l.repos.Create(ctx, events...); err != nil {
+		log.Error().Err(err).Int("events", len(events)).Msg("failed to write auth audit events")
+		metrics.AuditWriteErrorsTotal.Add(float64(len(events)))
+	}
+}
+
+// Getting batch size of the buffered events.
+func (l *Log) batchSize() int {
+	if l.cfg.BatchSize <= 0 {
+		return 1
+	}
+
+	return l.cfg.BatchSize
+}
+
+// Generating a new event id with the creation time.
+func newEventId(createdAt time.Time) ksuid.KSUID {
+	id, err := ksuid.NewRandomWithTime(createdAt)
+	if err != nil {
+		return ksuid.New()
+	}
+
+	return id
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package audit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/audit"
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+)
+
+// In-memory auth audit repository recording written batches.
+type auditRepository struct{ batches [][]domain.AuditEvent }
+
+// Creating auth audit events.
+func (r *auditRepository) Create(_ context.Context, events ...domain.AuditEvent) error {
+	r.batches = append(r.batches, append([]domain.AuditEvent(nil), events...))
+	return nil
+}
+
+// Getting auth audit events, unused.
+func (r *auditRepository) GetList(context.Context, domain.AuditFilter, domain.SortOptions) ([]domain.AuditEvent, domain.PageInfo, error) {
+	return nil, domain.PageInfo{}, nil
+}
+
+// Preparing storage, unused.
+func (r *auditRepository) Prepare(context.Context, time.Time) error { return nil }
+
+// Deleting auth audit events, unused.
+func (r *auditRepository) DeleteBefore(context.Context, time.Time) error { return nil }
+
+// Testing buffered auth audit events writing.
+func TestLog_Record(t *testing.T) {
+	repos := &auditRepository{}
+	l := audit.NewLog(repos, config.AuditConfig{BatchSize: 2, BufferSize: 3})
+
+	ctx := context.Background()
+
+	// Buffering events.
+	l.Record(ctx,
+		domain.AuditEvent{Kind: domain.AuditSignIn, Outcome: domain.AuditSuccess},
+		domain.AuditEvent{Kind: domain.AuditSignIn, Outcome: domain.AuditFailure},
+	)
+
+	if len(repos.batches) != 0 {
+		t.Fatalf("error buffered events are written: got %v", repos.batches)
+	}
+
+	// Rest events are written immediately when the buffer is full.
+	l.Record(ctx,
+		domain.AuditEvent{Kind: domain.AuditTokenRefresh, Outcome: domain.AuditSuccess},
+		domain.AuditEvent{Kind: domain.AuditSessionRevoke, Outcome: domain.AuditSuccess},
+	)
+
+	if len(repos.batches) != 1 || len(repos.batches[0]) != 1 || repos.batches[0][0].Kind != domain.AuditSessionRevoke {
+		t.Fatalf("error events written on the full buffer: got %v", repos.batches)
+	}
+
+	// Writing buffered events in batches.
+	l.Flush(ctx)
+
+	if len(repos.batches) != 3 || len(repos.batches[1]) != 2 || len(repos.batches[2]) != 1 {
+		t.Fatalf("error flushed batches: got %v", repos.batches)
+	}
+
+	for _, batch := range repos.batches {
+		for _, event := range batch {
+			if event.Id.IsNil() || event.CreatedAt.IsZero() {
+				t.Errorf("error event id and creation time are not set: got %v", event)
+			}
+		}
+	}
+}
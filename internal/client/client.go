@@ -18,6 +18,8 @@
 package client
 
 import (
+	"context"
+
 	"github.com/durudex/durudex-auth-service/internal/config"
 	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
 
@@ -25,29 +27,43 @@ import (
 	"google.golang.org/grpc"
 )
 
-// Client structure.
-type Client struct {
-	User  *UserClient
-	Code  *CodeClient
-	Email *EmailClient
+// User service client interface.
+type User interface {
+	// Creating a new user.
+	CreateUser(ctx context.Context, in *v1.CreateUserRequest, opts ...grpc.CallOption) (*v1.CreateUserResponse, error)
+	// Getting a user by id.
+	GetUserById(ctx context.Context, in *v1.GetUserByIdRequest, opts ...grpc.CallOption) (*v1.GetUserByIdResponse, error)
+	// Getting a user by credentials.
+	GetUserByCreds(ctx context.Context, in *v1.GetUserByCredsRequest, opts ...grpc.CallOption) (*v1.GetUserByCredsResponse, error)
 }
 
-// User client structure.
-type UserClient struct {
-	v1.UserServiceClient
-	conn *grpc.ClientConn
+// User code service client interface.
+type Code interface {
+	// Verifying user email code.
+	VerifyUserEmailCode(ctx context.Context, in *v1.VerifyUserEmailCodeRequest, opts ...grpc.CallOption) (*v1.VerifyUserEmailCodeResponse, error)
 }
 
-// Code client structure.
-type CodeClient struct {
-	v1.UserCodeServiceClient
-	conn *grpc.ClientConn
+// Email service client interface.
+type Email interface {
+	// Sending user register email.
+	SendEmailUserRegister(ctx context.Context, in *v1.SendEmailUserRegisterRequest, opts ...grpc.CallOption) (*v1.SendEmailUserRegisterResponse, error)
+	// Sending user logged in email.
+	SendEmailUserLoggedIn(ctx context.Context, in *v1.SendEmailUserLoggedInRequest, opts ...grpc.CallOption) (*v1.SendEmailUserLoggedInResponse, error)
 }
 
-// Email client structure.
-type EmailClient struct {
-	v1.EmailUserServiceClient
-	conn *grpc.ClientConn
+// Client structure.
+type Client struct {
+	User  User
+	Code  Code
+	Email Email
+	// Downstream service connections, empty for in-memory clients.
+	conns []serviceConn
+}
+
+// Named downstream service connection.
+type serviceConn struct {
+	name string
+	conn *Conn
 }
 
 // Creating a new client.
@@ -59,17 +75,13 @@ func NewClient(cfg config.ServiceConfig) *Client {
 	emailServiceConn := ConnectToGRPCService(cfg.Email)
 
 	return &Client{
-		User: &UserClient{
-			v1.NewUserServiceClient(userServiceConn),
-			userServiceConn,
-		},
-		Code: &CodeClient{
-			v1.NewUserCodeServiceClient(codeServiceConn),
-			codeServiceConn,
-		},
-		Email: &EmailClient{
-			v1.NewEmailUserServiceClient(emailServiceConn),
-			emailServiceConn,
+		User:  v1.NewUserServiceClient(userServiceConn),
+		Code:  v1.NewUserCodeServiceClient(codeServiceConn),
+		Email: v1.NewEmailUserServiceClient(emailServiceConn),
+		conns: []serviceConn{
+			{name: "user", conn: userServiceConn},
+			{name: "code", conn: codeServiceConn},
+			{name: "email", conn: emailServiceConn},
 		},
 	}
 }
@@ -78,16 +90,9 @@ func NewClient(cfg config.ServiceConfig) *Client {
 func (c *Client) Close() {
 	log.Info().Msg("Closing a client connections")
 
-	// Closing user service connection.
-	if err := c.User.conn.Close(); err != nil {
-		log.Fatal().Err(err).Msg("failed to close user service connection")
-	}
-	// Closing code service connection.
-	if err := c.Code.conn.Close(); err != nil {
-		log.Fatal().Err(err).Msg("failed to close code service connection")
-	}
-	// Closing email service connection.
-	if err := c.Email.conn.Close(); err != nil {
-		log.Fatal().Err(err).Msg("failed to close email service connection")
+	for _, sc := range c.conns {
+		if err := sc.conn.Close(); err != nil {
+			log.Fatal().Err(err).Msgf("failed to close %s service connection", sc.name)
+		}
 	}
 }
@@ -0,0 +1,173 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Creating a new client with in-memory downstream services.
+func NewFakeClient() *Client {
+	return &Client{User: NewFakeUser(), Code: NewFakeCode(), Email: NewFakeEmail()}
+}
+
+// In-memory user record.
+type fakeUser struct {
+	id       ksuid.KSUID
+	username string
+	email    string
+	password string
+}
+
+// In-memory user service.
+type FakeUser struct {
+	mu    sync.Mutex
+	users []fakeUser
+}
+
+// Creating a new in-memory user service.
+func NewFakeUser() *FakeUser {
+	return &FakeUser{}
+}
+
+// Creating a new user. Username and email must be unique.
+func (f *FakeUser) CreateUser(_ context.Context, in *v1.CreateUserRequest, _ ...grpc.CallOption) (*v1.CreateUserResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, user := range f.users {
+		if user.username == in.Username || user.email == in.Email {
+			return nil, status.Error(codes.AlreadyExists, "User already exists")
+		}
+	}
+
+	user := fakeUser{id: ksuid.New(), username: in.Username, email: in.Email, password: in.Password}
+	f.users = append(f.users, user)
+
+	return &v1.CreateUserResponse{Id: user.id.Bytes()}, nil
+}
+
+// Getting a user by id.
+func (f *FakeUser) GetUserById(_ context.Context, in *v1.GetUserByIdRequest, _ ...grpc.CallOption) (*v1.GetUserByIdResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, user := range f.users {
+		if user.id == ksuid.FromBytesOrNil(in.Id) {
+			return &v1.GetUserByIdResponse{Username: user.username, Verified: true}, nil
+		}
+	}
+
+	return nil, status.Error(codes.NotFound, "User not found")
+}
+
+// Getting a user by credentials.
+func (f *FakeUser) GetUserByCreds(_ context.Context, in *v1.GetUserByCredsRequest, _ ...grpc.CallOption) (*v1.GetUserByCredsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, user := range f.users {
+		if user.username == in.Username && user.password == in.Password {
+			return &v1.GetUserByCredsResponse{Id: user.id.Bytes(), Email: user.email, Verified: true}, nil
+		}
+	}
+
+	return nil, status.Error(codes.NotFound, "User not found")
+}
+
+// In-memory user code service.
+type FakeCode struct {
+	mu    sync.Mutex
+	codes map[string]uint64
+}
+
+// Creating a new in-memory user code service.
+func NewFakeCode() *FakeCode {
+	return &FakeCode{codes: make(map[string]uint64)}
+}
+
+// Setting a verification code of the email.
+func (f *FakeCode) SetCode(email string, code uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.codes[email] = code
+}
+
+// Verifying user email code. Verified code can not be used again.
+func (f *FakeCode) VerifyUserEmailCode(_ context.Context, in *v1.VerifyUserEmailCodeRequest, _ ...grpc.CallOption) (*v1.VerifyUserEmailCodeResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	code, ok := f.codes[in.Email]
+	if !ok || code != in.Code {
+		return &v1.VerifyUserEmailCodeResponse{Status: false}, nil
+	}
+
+	delete(f.codes, in.Email)
+
+	return &v1.VerifyUserEmailCodeResponse{Status: true}, nil
+}
+
+// In-memory email service capturing sent emails.
+type FakeEmail struct {
+	mu   sync.Mutex
+	sent []domain.Email
+}
+
+// Creating a new in-memory email service.
+func NewFakeEmail() *FakeEmail {
+	return &FakeEmail{}
+}
+
+// Capturing user register email.
+func (f *FakeEmail) SendEmailUserRegister(_ context.Context, in *v1.SendEmailUserRegisterRequest, _ ...grpc.CallOption) (*v1.SendEmailUserRegisterResponse, error) {
+	f.capture(domain.Email{Kind: domain.EmailUserRegister, Email: in.Email, Username: in.Username})
+	return &v1.SendEmailUserRegisterResponse{}, nil
+}
+
+// Capturing user logged in email.
+func (f *FakeEmail) SendEmailUserLoggedIn(_ context.Context, in *v1.SendEmailUserLoggedInRequest, _ ...grpc.CallOption) (*v1.SendEmailUserLoggedInResponse, error) {
+	f.capture(domain.Email{Kind: domain.EmailUserLoggedIn, Email: in.Email, Ip: in.Ip})
+	return &v1.SendEmailUserLoggedInResponse{}, nil
+}
+
+// Capturing a sent email.
+func (f *FakeEmail) capture(email domain.Email) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.sent = append(f.sent, email)
+}
+
+// Getting sent emails.
+func (f *FakeEmail) Sent() []domain.Email {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]domain.Email(nil), f.sent...)
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/durudex/durudex-auth-service/internal/client"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Testing in-memory user service.
+func TestFakeUser(t *testing.T) {
+	user := client.NewFakeUser()
+
+	created, err := user.CreateUser(context.Background(), &v1.CreateUserRequest{
+		Username: "example",
+		Email:    "example@durudex.com",
+		Password: "Password123",
+	})
+	if err != nil {
+		t.Fatalf("error creating user: %s", err.Error())
+	}
+
+	// Testing args.
+	type args struct{ username, password string }
+
+	// Tests structures.
+	tests := []struct {
+		name string
+		args args
+		want codes.Code
+	}{
+		{name: "OK", args: args{username: "example", password: "Password123"}, want: codes.OK},
+		{name: "Invalid password", args: args{username: "example", password: "Password321"}, want: codes.NotFound},
+		{name: "Unknown user", args: args{username: "unknown", password: "Password123"}, want: codes.NotFound},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := user.GetUserByCreds(context.Background(), &v1.GetUserByCredsRequest{
+				Username: tt.args.username,
+				Password: tt.args.password,
+			})
+
+			// Check for similarity of status code.
+			if status.Code(err) != tt.want {
+				t.Fatalf("error status code: got %s, want %s", status.Code(err), tt.want)
+			}
+
+			if err == nil && string(got.Id) != string(created.Id) {
+				t.Errorf("error user id: got %x, want %x", got.Id, created.Id)
+			}
+		})
+	}
+
+	// Getting a user by id.
+	byId, err := user.GetUserById(context.Background(), &v1.GetUserByIdRequest{Id: created.Id})
+	if err != nil || byId.Username != "example" {
+		t.Errorf("error getting user by id: got %v, %v", byId, err)
+	}
+
+	// Creating a user with the same email.
+	_, err = user.CreateUser(context.Background(), &v1.CreateUserRequest{Username: "other", Email: "example@durudex.com"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("error status code: got %s, want %s", status.Code(err), codes.AlreadyExists)
+	}
+}
+
+// Testing in-memory user code service.
+func TestFakeCode(t *testing.T) {
+	code := client.NewFakeCode()
+	code.SetCode("example@durudex.com", 123456)
+
+	verify := func(value uint64) bool {
+		res, err := code.VerifyUserEmailCode(context.Background(), &v1.VerifyUserEmailCodeRequest{
+			Email: "example@durudex.com",
+			Code:  value,
+		})
+		if err != nil {
+			t.Fatalf("error verifying code: %s", err.Error())
+		}
+
+		return res.Status
+	}
+
+	if verify(654321) {
+		t.Error("error verifying invalid code: got true, want false")
+	}
+
+	if !verify(123456) {
+		t.Error("error verifying valid code: got false, want true")
+	}
+
+	// Verified code can not be used again.
+	if verify(123456) {
+		t.Error("error verifying used code: got true, want false")
+	}
+}
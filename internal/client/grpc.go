@@ -19,6 +19,7 @@ package client
 
 import (
 	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/metrics"
 	"github.com/durudex/durudex-auth-service/pkg/tls"
 
 	"github.com/rs/zerolog/log"
@@ -27,29 +28,71 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+// gRPC client connection with reloadable TLS credentials.
+type Conn struct {
+	*grpc.ClientConn
+	// TLS credentials reloader, nil when TLS is disabled.
+	reloader *tls.Reloader
+}
+
+// Closing a client connection and stopping TLS credentials reloader.
+func (c *Conn) Close() error {
+	if c.reloader != nil {
+		if err := c.reloader.Close(); err != nil {
+			return err
+		}
+	}
+
+	return c.ClientConn.Close()
+}
+
 // Creating a new gRPC client connection to specified service.
-func ConnectToGRPCService(cfg config.Service) *grpc.ClientConn {
+func ConnectToGRPCService(cfg config.Service) *Conn {
 	log.Info().Msgf("Connecting to %s service", cfg.Addr)
 
-	var opts []grpc.DialOption
+	var (
+		opts     []grpc.DialOption
+		reloader *tls.Reloader
+	)
 
 	if cfg.TLS.Enable {
-		creds, err := tls.LoadTLSConfig(cfg.TLS.CACert, cfg.TLS.Cert, cfg.TLS.Key)
+		var err error
+
+		reloader, err = tls.NewReloader(cfg.TLS.CACert, cfg.TLS.Cert, cfg.TLS.Key, metrics.ObserveCertificate(cfg.Addr))
 		if err != nil {
 			log.Fatal().Err(err).Msg("failed to load TLS credentials")
 		}
 
+		// Creating a new client TLS config.
+		creds, err := reloader.ClientConfig(tls.Policy{
+			MinVersion:   cfg.TLS.MinVersion,
+			MaxVersion:   cfg.TLS.MaxVersion,
+			CipherSuites: cfg.TLS.CipherSuites,
+			ServerName:   cfg.TLS.ServerName,
+		})
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid TLS policy")
+		}
+
 		// Append client credential options.
 		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(creds)))
 	} else {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
+	// Getting retry, timeout, keepalive and circuit breaker options.
+	resilienceOpts, err := getResilienceOptions(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid service client config")
+	}
+
+	opts = append(opts, resilienceOpts...)
+
 	// Creating a new gRPC client connection.
 	conn, err := grpc.Dial(cfg.Addr, opts...)
 	if err != nil {
 		log.Error().Err(err).Msgf("failed to connect to service: %s", cfg.Addr)
 	}
 
-	return conn
+	return &Conn{ClientConn: conn, reloader: reloader}
 }
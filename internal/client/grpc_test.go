@@ -0,0 +1,160 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client_test
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/client"
+	"github.com/durudex/durudex-auth-service/internal/config"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Unreliable user service server.
+type userServer struct {
+	v1.UnimplementedUserServiceServer
+	// Number of failed calls before success.
+	failures int32
+	calls    int32
+}
+
+// Getting a user by id, fails with Unavailable before succeeding.
+func (s *userServer) GetUserById(context.Context, *v1.GetUserByIdRequest) (*v1.GetUserByIdResponse, error) {
+	if atomic.AddInt32(&s.calls, 1) <= s.failures {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+
+	return &v1.GetUserByIdResponse{Username: "example"}, nil
+}
+
+// Creating a new user, always fails with Unavailable.
+func (s *userServer) CreateUser(context.Context, *v1.CreateUserRequest) (*v1.CreateUserResponse, error) {
+	atomic.AddInt32(&s.calls, 1)
+	return nil, status.Error(codes.Unavailable, "unavailable")
+}
+
+// Updating user avatar, slower than client timeout.
+func (s *userServer) UpdateUserAvatar(ctx context.Context, _ *v1.UpdateUserAvatarRequest) (*v1.UpdateUserAvatarResponse, error) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+	}
+
+	return &v1.UpdateUserAvatarResponse{}, nil
+}
+
+// Running a new user service server.
+func runUserServer(t *testing.T, srv *userServer) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error creating listener: %s", err.Error())
+	}
+
+	s := grpc.NewServer()
+	v1.RegisterUserServiceServer(s, srv)
+
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	return lis.Addr().String()
+}
+
+// Getting a resilient service config.
+func serviceConfig(addr string) config.Service {
+	return config.Service{
+		Addr:    addr,
+		Timeout: time.Millisecond * 100,
+		Retry: config.RetryConfig{
+			MaxAttempts:       3,
+			InitialBackoff:    time.Millisecond * 10,
+			MaxBackoff:        time.Millisecond * 50,
+			BackoffMultiplier: 2,
+			Codes:             []string{"UNAVAILABLE"},
+			Methods:           []string{"durudex.v1.UserService/GetUserById"},
+		},
+		Keepalive: config.KeepaliveConfig{Time: time.Minute, Timeout: time.Second * 10},
+		Breaker: config.BreakerConfig{
+			Enable:           true,
+			MaxRequests:      1,
+			Interval:         time.Minute,
+			Timeout:          time.Minute,
+			FailureThreshold: 2,
+		},
+	}
+}
+
+// Testing retries of idempotent methods.
+func TestConnectToGRPCService_Retry(t *testing.T) {
+	srv := &userServer{failures: 2}
+
+	conn := client.ConnectToGRPCService(serviceConfig(runUserServer(t, srv)))
+	defer conn.Close()
+
+	// Idempotent method is retried until success.
+	if _, err := v1.NewUserServiceClient(conn).GetUserById(context.Background(), &v1.GetUserByIdRequest{}); err != nil {
+		t.Fatalf("error getting user by id: %s", err.Error())
+	}
+
+	if calls := atomic.LoadInt32(&srv.calls); calls != 3 {
+		t.Errorf("error number of calls: got %d, want %d", calls, 3)
+	}
+}
+
+// Testing circuit breaker of non-idempotent methods.
+func TestConnectToGRPCService_Breaker(t *testing.T) {
+	srv := &userServer{}
+
+	conn := client.ConnectToGRPCService(serviceConfig(runUserServer(t, srv)))
+	defer conn.Close()
+
+	user := v1.NewUserServiceClient(conn)
+
+	// Non-idempotent method is not retried and opens the breaker.
+	for i := 0; i < 3; i++ {
+		if _, err := user.CreateUser(context.Background(), &v1.CreateUserRequest{}); status.Code(err) != codes.Unavailable {
+			t.Fatalf("error status code: got %s, want %s", status.Code(err), codes.Unavailable)
+		}
+	}
+
+	if calls := atomic.LoadInt32(&srv.calls); calls != 2 {
+		t.Errorf("error number of calls: got %d, want %d", calls, 2)
+	}
+}
+
+// Testing per-service call timeout.
+func TestConnectToGRPCService_Timeout(t *testing.T) {
+	conn := client.ConnectToGRPCService(serviceConfig(runUserServer(t, &userServer{})))
+	defer conn.Close()
+
+	_, err := v1.NewUserServiceClient(conn).UpdateUserAvatar(context.Background(), &v1.UpdateUserAvatarRequest{})
+
+	// Check for similarity of status code.
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("error status code: got %s, want %s", status.Code(err), codes.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sony/gobreaker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC service config structure.
+type serviceConfig struct {
+	MethodConfig []methodConfig `json:"methodConfig,omitempty"`
+}
+
+// gRPC service config method structure.
+type methodConfig struct {
+	Name        []methodName `json:"name"`
+	RetryPolicy retryPolicy  `json:"retryPolicy"`
+}
+
+// gRPC service config method name structure.
+type methodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method"`
+}
+
+// gRPC service config retry policy structure.
+type retryPolicy struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+// Getting gRPC client resilience dial options.
+func getResilienceOptions(cfg config.Service) ([]grpc.DialOption, error) {
+	var opts []grpc.DialOption
+
+	// Getting retry service config.
+	if len(cfg.Retry.Methods) != 0 {
+		sc, err := getServiceConfig(cfg.Retry)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, grpc.WithDefaultServiceConfig(sc))
+	}
+
+	// Getting keepalive params.
+	if cfg.Keepalive.Time != 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                cfg.Keepalive.Time,
+			Timeout:             cfg.Keepalive.Timeout,
+			PermitWithoutStream: cfg.Keepalive.PermitWithoutStream,
+		}))
+	}
+
+	var interceptors []grpc.UnaryClientInterceptor
+
+	// Getting call timeout interceptor.
+	if cfg.Timeout != 0 {
+		interceptors = append(interceptors, timeoutUnary(cfg.Timeout))
+	}
+
+	// Getting circuit breaker interceptor.
+	if cfg.Breaker.Enable {
+		interceptors = append(interceptors, breakerUnary(newBreaker(cfg.Addr, cfg.Breaker)))
+	}
+
+	if len(interceptors) != 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(interceptors...))
+	}
+
+	return opts, nil
+}
+
+// Getting gRPC retry service config JSON. Methods are set as "package.Service/Method".
+func getServiceConfig(cfg config.RetryConfig) (string, error) {
+	names := make([]methodName, len(cfg.Methods))
+
+	for i, method := range cfg.Methods {
+		parts := strings.Split(strings.TrimPrefix(method, "/"), "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return "", fmt.Errorf("invalid retry method name: %s", method)
+		}
+
+		names[i] = methodName{Service: parts[0], Method: parts[1]}
+	}
+
+	// Retrying only unavailable calls by default.
+	retryCodes := cfg.Codes
+	if len(retryCodes) == 0 {
+		retryCodes = []string{"UNAVAILABLE"}
+	}
+
+	sc, err := json.Marshal(serviceConfig{MethodConfig: []methodConfig{{
+		Name: names,
+		RetryPolicy: retryPolicy{
+			MaxAttempts:          cfg.MaxAttempts,
+			InitialBackoff:       durationString(cfg.InitialBackoff),
+			MaxBackoff:           durationString(cfg.MaxBackoff),
+			BackoffMultiplier:    cfg.BackoffMultiplier,
+			RetryableStatusCodes: retryCodes,
+		},
+	}}})
+	if err != nil {
+		return "", err
+	}
+
+	return string(sc), nil
+}
+
+// Getting a service config duration string in seconds.
+func durationString(d time.Duration) string {
+	return fmt.Sprintf("%gs", d.Seconds())
+}
+
+// Unary gRPC client call timeout interceptor. Earlier caller deadline is kept.
+func timeoutUnary(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// Creating a new service circuit breaker.
+func newBreaker(name string, cfg config.BreakerConfig) *gobreaker.CircuitBreaker {
+	return gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        name,
+		MaxRequests: cfg.MaxRequests,
+		Interval:    cfg.Interval,
+		Timeout:     cfg.Timeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= cfg.FailureThreshold
+		},
+		OnStateChange: func(name string, from, to gobreaker.State) {
+			log.Warn().Str("service", name).Str("from", from.String()).Str("to", to.String()).
+				Msg("service circuit breaker state changed")
+		},
+		IsSuccessful: func(err error) bool {
+			switch status.Code(err) {
+			case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+				return false
+			default:
+				return true
+			}
+		},
+	})
+}
+
+// Unary gRPC client circuit breaker interceptor. Calls fail fast with Unavailable status while
+// the breaker is open.
+func breakerUnary(cb *gobreaker.CircuitBreaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		_, err := cb.Execute(func() (interface{}, error) {
+			return nil, invoker(ctx, method, req, reply, cc, opts...)
+		})
+
+		switch err {
+		case gobreaker.ErrOpenState, gobreaker.ErrTooManyRequests:
+			return status.Errorf(codes.Unavailable, "%s service %s", cb.Name(), err.Error())
+		default:
+			return err
+		}
+	}
+}
@@ -32,30 +32,108 @@ const defaultConfigPath string = "configs/main"
 type (
 	// Config variables.
 	Config struct {
-		GRPC     GRPCConfig     `mapstructure:"grpc"`
-		Database DatabaseConfig `mapstructure:"database"`
-		Auth     AuthConfig     `mapstructure:"auth"`
-		Service  ServiceConfig  `mapstructure:"service"`
+		GRPC        GRPCConfig        `mapstructure:"grpc"`
+		HTTP        HTTPConfig        `mapstructure:"http"`
+		Database    DatabaseConfig    `mapstructure:"database"`
+		Auth        AuthConfig        `mapstructure:"auth"`
+		Service     ServiceConfig     `mapstructure:"service"`
+		Metrics     MetricsConfig     `mapstructure:"metrics"`
+		Outbox      OutboxConfig      `mapstructure:"outbox"`
+		Saga        SagaConfig        `mapstructure:"saga"`
+		Idempotency IdempotencyConfig `mapstructure:"idempotency"`
+		Audit       AuditConfig       `mapstructure:"audit"`
+		Events      EventsConfig      `mapstructure:"events"`
+		Webhook     WebhookConfig     `mapstructure:"webhook"`
+		GeoIP       GeoIPConfig       `mapstructure:"geoip"`
+		Risk        RiskConfig        `mapstructure:"risk"`
 	}
 
 	// gRPC server config variables.
 	GRPCConfig struct {
-		Host string    `mapstructure:"host"`
-		Port string    `mapstructure:"port"`
-		TLS  TLSConfig `mapstructure:"tls"`
+		Host       string           `mapstructure:"host"`
+		Port       string           `mapstructure:"port"`
+		TLS        TLSConfig        `mapstructure:"tls"`
+		Log        LogConfig        `mapstructure:"log"`
+		Validation ValidationConfig `mapstructure:"validation"`
+		Web        WebConfig        `mapstructure:"web"`
+		Authz      AuthzConfig      `mapstructure:"authz"`
+	}
+
+	// gRPC-Web and Connect server config variables.
+	WebConfig struct {
+		Enable  bool     `mapstructure:"enable"`
+		Host    string   `mapstructure:"host"`
+		Port    string   `mapstructure:"port"`
+		Origins []string `mapstructure:"origins"`
+	}
+
+	// gRPC peer authorization config variables.
+	AuthzConfig struct {
+		Enable bool              `mapstructure:"enable"`
+		Local  string            `mapstructure:"local"`
+		Rules  []AuthzRuleConfig `mapstructure:"rules"`
+	}
+
+	// gRPC method authorization rule config variables.
+	AuthzRuleConfig struct {
+		Method string   `mapstructure:"method"`
+		Allow  []string `mapstructure:"allow"`
+	}
+
+	// gRPC access log config variables.
+	LogConfig struct {
+		Payload  bool             `mapstructure:"payload"`
+		Sampling []SamplingConfig `mapstructure:"sampling"`
+	}
+
+	// Request validation config variables.
+	ValidationConfig struct {
+		MaxPageSize int32 `mapstructure:"max-page-size"`
+	}
+
+	// Access log method sampling config variables.
+	SamplingConfig struct {
+		Method string `mapstructure:"method"`
+		Every  uint32 `mapstructure:"every"`
+	}
+
+	// HTTP gateway server config variables.
+	HTTPConfig struct {
+		Enable bool         `mapstructure:"enable"`
+		Host   string       `mapstructure:"host"`
+		Port   string       `mapstructure:"port"`
+		Cookie CookieConfig `mapstructure:"cookie"`
+	}
+
+	// Refresh token cookie config variables.
+	CookieConfig struct {
+		Enable   bool   `mapstructure:"enable"`
+		Name     string `mapstructure:"name"`
+		Domain   string `mapstructure:"domain"`
+		Path     string `mapstructure:"path"`
+		Secure   bool   `mapstructure:"secure"`
+		SameSite string `mapstructure:"same-site"`
 	}
 
 	// TLS config variables.
 	TLSConfig struct {
-		Enable bool   `mapstructure:"enable"`
-		CACert string `mapstructure:"ca-cert"`
-		Cert   string `mapstructure:"cert"`
-		Key    string `mapstructure:"key"`
+		Enable       bool     `mapstructure:"enable"`
+		CACert       string   `mapstructure:"ca-cert"`
+		Cert         string   `mapstructure:"cert"`
+		Key          string   `mapstructure:"key"`
+		MinVersion   string   `mapstructure:"min-version"`
+		MaxVersion   string   `mapstructure:"max-version"`
+		CipherSuites []string `mapstructure:"cipher-suites"`
+		ClientAuth   string   `mapstructure:"client-auth"`
+		ServerName   string   `mapstructure:"server-name"`
 	}
 
 	// Database config variables.
 	DatabaseConfig struct {
+		Driver   string         `mapstructure:"driver"`
 		Postgres PostgresConfig `mapstructure:"postgres"`
+		Redis    RedisConfig    `mapstructure:"redis"`
+		Migrate  MigrateConfig  `mapstructure:"migrate"`
 	}
 
 	// Postgres config variables.
@@ -65,10 +143,23 @@ type (
 		URL      string
 	}
 
+	// Redis config variables.
+	RedisConfig struct {
+		PoolSize int `mapstructure:"pool-size"`
+		URL      string
+	}
+
+	// Schema migrations config variables.
+	MigrateConfig struct {
+		Auto        bool          `mapstructure:"auto"`
+		LockTimeout time.Duration `mapstructure:"lock-timeout"`
+	}
+
 	// Auth config variables.
 	AuthConfig struct {
-		Session SessionConfig `mapstructure:"session"`
-		JWT     JWTConfig     `mapstructure:"jwt"`
+		Session    SessionConfig    `mapstructure:"session"`
+		JWT        JWTConfig        `mapstructure:"jwt"`
+		LoginAlert LoginAlertConfig `mapstructure:"login-alert"`
 	}
 
 	// Session config variables.
@@ -76,16 +167,195 @@ type (
 		TTL time.Duration `mapstructure:"ttl"`
 	}
 
-	// JWT config variables.
+	// Logged in email config variables. Emails are sent only for sign ins from an ip network or
+	// device not seen in the number of recent sessions created within the lookback, the number
+	// must not exceed the maximum page size. Zero prefix bits compare only ip addresses.
+	LoginAlertConfig struct {
+		Lookback   time.Duration `mapstructure:"lookback"`
+		Sessions   int32         `mapstructure:"sessions"`
+		IPv4Prefix int           `mapstructure:"ipv4-prefix"`
+		IPv6Prefix int           `mapstructure:"ipv6-prefix"`
+	}
+
+	// JWT config variables. Step up ttl is the lifetime of elevated access tokens.
 	JWTConfig struct {
 		TTL        time.Duration `mapstructure:"ttl"`
+		StepUpTTL  time.Duration `mapstructure:"step-up-ttl"`
 		SigningKey string
 	}
 
 	// Service base config.
 	Service struct {
-		Addr string    `mapstructure:"addr"`
-		TLS  TLSConfig `mapstructure:"tls"`
+		Addr      string          `mapstructure:"addr"`
+		TLS       TLSConfig       `mapstructure:"tls"`
+		Timeout   time.Duration   `mapstructure:"timeout"`
+		Retry     RetryConfig     `mapstructure:"retry"`
+		Keepalive KeepaliveConfig `mapstructure:"keepalive"`
+		Breaker   BreakerConfig   `mapstructure:"breaker"`
+	}
+
+	// Service client retry policy config variables. Only listed idempotent methods are retried.
+	RetryConfig struct {
+		MaxAttempts       int           `mapstructure:"max-attempts"`
+		InitialBackoff    time.Duration `mapstructure:"initial-backoff"`
+		MaxBackoff        time.Duration `mapstructure:"max-backoff"`
+		BackoffMultiplier float64       `mapstructure:"backoff-multiplier"`
+		Codes             []string      `mapstructure:"codes"`
+		Methods           []string      `mapstructure:"methods"`
+	}
+
+	// Service client keepalive config variables.
+	KeepaliveConfig struct {
+		Time                time.Duration `mapstructure:"time"`
+		Timeout             time.Duration `mapstructure:"timeout"`
+		PermitWithoutStream bool          `mapstructure:"permit-without-stream"`
+	}
+
+	// Service client circuit breaker config variables.
+	BreakerConfig struct {
+		Enable           bool          `mapstructure:"enable"`
+		MaxRequests      uint32        `mapstructure:"max-requests"`
+		Interval         time.Duration `mapstructure:"interval"`
+		Timeout          time.Duration `mapstructure:"timeout"`
+		FailureThreshold uint32        `mapstructure:"failure-threshold"`
+	}
+
+	// Email outbox dispatcher config variables.
+	OutboxConfig struct {
+		Interval       time.Duration `mapstructure:"interval"`
+		BatchSize      int32         `mapstructure:"batch-size"`
+		Lease          time.Duration `mapstructure:"lease"`
+		MaxAttempts    int32         `mapstructure:"max-attempts"`
+		InitialBackoff time.Duration `mapstructure:"initial-backoff"`
+		MaxBackoff     time.Duration `mapstructure:"max-backoff"`
+	}
+
+	// Sign up saga config variables.
+	SagaConfig struct {
+		SessionAttempts int32         `mapstructure:"session-attempts"`
+		RetryBackoff    time.Duration `mapstructure:"retry-backoff"`
+		Interval        time.Duration `mapstructure:"interval"`
+		StaleAfter      time.Duration `mapstructure:"stale-after"`
+		BatchSize       int32         `mapstructure:"batch-size"`
+		Lease           time.Duration `mapstructure:"lease"`
+	}
+
+	// Idempotency keys config variables. Keys are accepted only by the listed methods.
+	IdempotencyConfig struct {
+		TTL       time.Duration `mapstructure:"ttl"`
+		Lease     time.Duration `mapstructure:"lease"`
+		Interval  time.Duration `mapstructure:"interval"`
+		BatchSize int32         `mapstructure:"batch-size"`
+		Methods   []string      `mapstructure:"methods"`
+	}
+
+	// Auth audit log config variables. Events older than the retention are deleted.
+	AuditConfig struct {
+		Retention     time.Duration `mapstructure:"retention"`
+		Interval      time.Duration `mapstructure:"interval"`
+		FlushInterval time.Duration `mapstructure:"flush-interval"`
+		BatchSize     int           `mapstructure:"batch-size"`
+		BufferSize    int           `mapstructure:"buffer-size"`
+	}
+
+	// Auth domain events config variables. Driver is "outbox", "nats" or empty to disable
+	// events, outbox messages are relayed to nats.
+	EventsConfig struct {
+		Driver        string            `mapstructure:"driver"`
+		SubjectPrefix string            `mapstructure:"subject-prefix"`
+		NATS          NATSConfig        `mapstructure:"nats"`
+		Outbox        EventOutboxConfig `mapstructure:"outbox"`
+	}
+
+	// NATS config variables.
+	NATSConfig struct {
+		URL     string
+		Timeout time.Duration `mapstructure:"timeout"`
+	}
+
+	// Auth domain event outbox relay config variables.
+	EventOutboxConfig struct {
+		Interval       time.Duration `mapstructure:"interval"`
+		BatchSize      int32         `mapstructure:"batch-size"`
+		Lease          time.Duration `mapstructure:"lease"`
+		InitialBackoff time.Duration `mapstructure:"initial-backoff"`
+		MaxBackoff     time.Duration `mapstructure:"max-backoff"`
+	}
+
+	// Webhook dispatcher config variables. Webhooks are disabled after the number of
+	// consecutive failed delivery attempts, zero never disables webhooks.
+	WebhookConfig struct {
+		Interval       time.Duration `mapstructure:"interval"`
+		BatchSize      int32         `mapstructure:"batch-size"`
+		Lease          time.Duration `mapstructure:"lease"`
+		Timeout        time.Duration `mapstructure:"timeout"`
+		MaxAttempts    int32         `mapstructure:"max-attempts"`
+		InitialBackoff time.Duration `mapstructure:"initial-backoff"`
+		MaxBackoff     time.Duration `mapstructure:"max-backoff"`
+		DisableAfter   int32         `mapstructure:"disable-after"`
+	}
+
+	// Offline GeoIP config variables. Database is a MaxMind-format city database file, empty
+	// or missing file disables ip address locations.
+	GeoIPConfig struct {
+		Database string `mapstructure:"database"`
+	}
+
+	// Sign in risk engine config variables. Sign in requires the email verification code when
+	// the total score of the signals reaches the challenge score and is blocked when it reaches
+	// the block score, zero score disables the decision. Signals with zero score are disabled.
+	RiskConfig struct {
+		Enable         bool                 `mapstructure:"enable"`
+		ChallengeScore int                  `mapstructure:"challenge-score"`
+		BlockScore     int                  `mapstructure:"block-score"`
+		Velocity       RiskVelocityConfig   `mapstructure:"velocity"`
+		NewDevice      RiskNewDeviceConfig  `mapstructure:"new-device"`
+		Travel         RiskTravelConfig     `mapstructure:"travel"`
+		Reputation     RiskReputationConfig `mapstructure:"reputation"`
+		TimeOfDay      RiskTimeOfDayConfig  `mapstructure:"time-of-day"`
+	}
+
+	// Failed sign in attempts velocity signal config variables. Signal is triggered when the
+	// number of failed attempts within the window reaches the max failures.
+	RiskVelocityConfig struct {
+		Window      time.Duration `mapstructure:"window"`
+		MaxFailures int32         `mapstructure:"max-failures"`
+		Score       int           `mapstructure:"score"`
+	}
+
+	// New device signal config variables. Recent sessions are limited by the login alert config.
+	RiskNewDeviceConfig struct {
+		Score int `mapstructure:"score"`
+	}
+
+	// Impossible travel signal config variables. Signal is triggered when the speed between the
+	// last session and sign in locations exceeds the max speed in km/h.
+	RiskTravelConfig struct {
+		MaxSpeed float64 `mapstructure:"max-speed"`
+		Score    int     `mapstructure:"score"`
+	}
+
+	// Ip reputation signal config variables. Blocklist is a file of ip addresses and networks,
+	// one per line, empty disables the blocklist.
+	RiskReputationConfig struct {
+		Blocklist string `mapstructure:"blocklist"`
+		Score     int    `mapstructure:"score"`
+	}
+
+	// Time of day signal config variables. Signal is triggered when no recent session was
+	// created within the window hours of the sign in hour, users with fewer recent sessions than
+	// the min sessions are not assessed.
+	RiskTimeOfDayConfig struct {
+		Window      int `mapstructure:"window"`
+		MinSessions int `mapstructure:"min-sessions"`
+		Score       int `mapstructure:"score"`
+	}
+
+	// Metrics server config variables.
+	MetricsConfig struct {
+		Enable bool   `mapstructure:"enable"`
+		Host   string `mapstructure:"host"`
+		Port   string `mapstructure:"port"`
 	}
 
 	// Services config variables.
@@ -147,6 +417,12 @@ func setFromEnv(cfg *Config) {
 	// Postgres configurations.
 	cfg.Database.Postgres.URL = os.Getenv("POSTGRES_URL")
 
+	// Redis configurations.
+	cfg.Database.Redis.URL = os.Getenv("REDIS_URL")
+
 	// Auth configurations.
 	cfg.Auth.JWT.SigningKey = os.Getenv("JWT_SIGNING_KEY")
+
+	// NATS configurations.
+	cfg.Events.NATS.URL = os.Getenv("NATS_URL")
 }
@@ -29,7 +29,7 @@ import (
 // Testing creating a new config.
 func TestConfig_NewConfig(t *testing.T) {
 	// Environment configurations.
-	type env struct{ configPath, postgresUrl string }
+	type env struct{ configPath, postgresUrl, redisUrl, natsUrl string }
 
 	// Testing args.
 	type args struct{ env env }
@@ -38,6 +38,8 @@ func TestConfig_NewConfig(t *testing.T) {
 	setEnv := func(env env) {
 		os.Setenv("CONFIG_PATH", env.configPath)
 		os.Setenv("POSTGRES_URL", env.postgresUrl)
+		os.Setenv("REDIS_URL", env.redisUrl)
+		os.Setenv("NATS_URL", env.natsUrl)
 	}
 
 	// Tests structures.
@@ -52,58 +54,245 @@ func TestConfig_NewConfig(t *testing.T) {
 			args: args{env: env{
 				configPath:  "fixtures/main",
 				postgresUrl: "postgres://localhost:1",
+				redisUrl:    "redis://localhost:2",
+				natsUrl:     "nats://localhost:3",
 			}},
 			want: &config.Config{
 				GRPC: config.GRPCConfig{
 					Host: "auth.service.durudex.local",
 					Port: "8001",
 					TLS: config.TLSConfig{
+						Enable:     true,
+						CACert:     "./certs/rootCA.pem",
+						Cert:       "./certs/auth.service.durudex.local-cert.pem",
+						Key:        "./certs/auth.service.durudex.local-key.pem",
+						MinVersion: "1.2",
+						ClientAuth: "require-and-verify",
+					},
+					Log: config.LogConfig{
+						Sampling: []config.SamplingConfig{
+							{Method: "/durudex.v1.UserSessionService/GetUserSessions", Every: 10},
+						},
+					},
+					Validation: config.ValidationConfig{MaxPageSize: 50},
+					Web: config.WebConfig{
+						Enable:  true,
+						Host:    "auth.service.durudex.local",
+						Port:    "8002",
+						Origins: []string{"https://durudex.com"},
+					},
+					Authz: config.AuthzConfig{
 						Enable: true,
-						CACert: "./certs/rootCA.pem",
-						Cert:   "./certs/auth.service.durudex.local-cert.pem",
-						Key:    "./certs/auth.service.durudex.local-key.pem",
+						Local:  "auth.gateway.durudex.local",
+						Rules: []config.AuthzRuleConfig{
+							{
+								Method: "/durudex.v1.UserAuthService/*",
+								Allow:  []string{"gateway.durudex.local", "auth.gateway.durudex.local"},
+							},
+							{
+								Method: "/durudex.v1.UserSessionService/*",
+								Allow:  []string{"auth.gateway.durudex.local"},
+							},
+							{
+								Method: "/durudex.v1.AuthAuditService/*",
+								Allow:  []string{"support.durudex.local"},
+							},
+							{
+								Method: "/durudex.v1.AuthWebhookService/*",
+								Allow:  []string{"integrations.durudex.local"},
+							},
+						},
+					},
+				},
+				HTTP: config.HTTPConfig{
+					Enable: true,
+					Host:   "auth.service.durudex.local",
+					Port:   "8081",
+					Cookie: config.CookieConfig{
+						Enable:   true,
+						Name:     "durudex_refresh",
+						Domain:   "durudex.com",
+						Path:     "/v1/auth",
+						Secure:   true,
+						SameSite: "strict",
 					},
 				},
 				Database: config.DatabaseConfig{
+					Driver: "postgres",
 					Postgres: config.PostgresConfig{
 						MaxConns: 20,
 						MinConns: 5,
 						URL:      "postgres://localhost:1",
 					},
+					Redis: config.RedisConfig{
+						PoolSize: 10,
+						URL:      "redis://localhost:2",
+					},
+					Migrate: config.MigrateConfig{LockTimeout: time.Minute},
 				},
 				Auth: config.AuthConfig{
 					Session: config.SessionConfig{TTL: time.Hour * 720},
-					JWT:     config.JWTConfig{TTL: time.Minute * 15},
+					JWT:     config.JWTConfig{TTL: time.Minute * 15, StepUpTTL: time.Minute * 5},
+					LoginAlert: config.LoginAlertConfig{
+						Lookback:   time.Hour * 2160,
+						Sessions:   50,
+						IPv4Prefix: 24,
+						IPv6Prefix: 48,
+					},
 				},
 				Service: config.ServiceConfig{
 					User: config.Service{
 						Addr: "user.service.durudex.local:8004",
 						TLS: config.TLSConfig{
-							Enable: true,
-							CACert: "./certs/rootCA.pem",
-							Cert:   "./certs/client-cert.pem",
-							Key:    "./certs/client-key.pem",
+							Enable:     true,
+							CACert:     "./certs/rootCA.pem",
+							Cert:       "./certs/client-cert.pem",
+							Key:        "./certs/client-key.pem",
+							MinVersion: "1.2",
+							ServerName: "user.service.durudex.local",
+						},
+						Timeout: time.Second * 3,
+						Retry: config.RetryConfig{
+							MaxAttempts:       3,
+							InitialBackoff:    time.Millisecond * 100,
+							MaxBackoff:        time.Second,
+							BackoffMultiplier: 2,
+							Codes:             []string{"UNAVAILABLE"},
+							Methods: []string{
+								"durudex.v1.UserService/GetUserById",
+								"durudex.v1.UserService/GetUserByCreds",
+							},
+						},
+						Keepalive: config.KeepaliveConfig{
+							Time:    time.Second * 30,
+							Timeout: time.Second * 10,
+						},
+						Breaker: config.BreakerConfig{
+							Enable:           true,
+							MaxRequests:      1,
+							Interval:         time.Minute,
+							Timeout:          time.Second * 30,
+							FailureThreshold: 5,
 						},
 					},
 					Code: config.Service{
 						Addr: "code.service.durudex.local:8003",
 						TLS: config.TLSConfig{
-							Enable: true,
-							CACert: "./certs/rootCA.pem",
-							Cert:   "./certs/client-cert.pem",
-							Key:    "./certs/client-key.pem",
+							Enable:     true,
+							CACert:     "./certs/rootCA.pem",
+							Cert:       "./certs/client-cert.pem",
+							Key:        "./certs/client-key.pem",
+							MinVersion: "1.2",
+							ServerName: "code.service.durudex.local",
+						},
+						Timeout: time.Second * 3,
+						Keepalive: config.KeepaliveConfig{
+							Time:    time.Second * 30,
+							Timeout: time.Second * 10,
+						},
+						Breaker: config.BreakerConfig{
+							Enable:           true,
+							MaxRequests:      1,
+							Interval:         time.Minute,
+							Timeout:          time.Second * 30,
+							FailureThreshold: 5,
 						},
 					},
 					Email: config.Service{
 						Addr: "email.service.durudex.local:8002",
 						TLS: config.TLSConfig{
-							Enable: true,
-							CACert: "./certs/rootCA.pem",
-							Cert:   "./certs/client-cert.pem",
-							Key:    "./certs/client-key.pem",
+							Enable:     true,
+							CACert:     "./certs/rootCA.pem",
+							Cert:       "./certs/client-cert.pem",
+							Key:        "./certs/client-key.pem",
+							MinVersion: "1.2",
+							ServerName: "email.service.durudex.local",
+						},
+						Timeout: time.Second * 3,
+						Keepalive: config.KeepaliveConfig{
+							Time:    time.Second * 30,
+							Timeout: time.Second * 10,
+						},
+						Breaker: config.BreakerConfig{
+							Enable:           true,
+							MaxRequests:      1,
+							Interval:         time.Minute,
+							Timeout:          time.Second * 30,
+							FailureThreshold: 5,
 						},
 					},
 				},
+				Metrics: config.MetricsConfig{
+					Enable: true,
+					Host:   "auth.service.durudex.local",
+					Port:   "9001",
+				},
+				Outbox: config.OutboxConfig{
+					Interval:       time.Second,
+					BatchSize:      50,
+					Lease:          time.Second * 30,
+					MaxAttempts:    10,
+					InitialBackoff: time.Second,
+					MaxBackoff:     time.Minute * 5,
+				},
+				Saga: config.SagaConfig{
+					SessionAttempts: 3,
+					RetryBackoff:    time.Millisecond * 100,
+					Interval:        time.Second * 30,
+					StaleAfter:      time.Minute * 5,
+					BatchSize:       50,
+					Lease:           time.Minute * 5,
+				},
+				Idempotency: config.IdempotencyConfig{
+					TTL:       time.Hour * 24,
+					Lease:     time.Minute,
+					Interval:  time.Hour,
+					BatchSize: 1000,
+					Methods: []string{
+						"/durudex.v1.UserAuthService/UserSignUp",
+						"/durudex.v1.UserAuthService/UserSignIn",
+					},
+				},
+				Audit: config.AuditConfig{
+					Retention:     time.Hour * 2160,
+					Interval:      time.Hour,
+					FlushInterval: time.Second,
+					BatchSize:     100,
+					BufferSize:    1000,
+				},
+				Events: config.EventsConfig{
+					Driver:        "outbox",
+					SubjectPrefix: "durudex.auth",
+					NATS:          config.NATSConfig{URL: "nats://localhost:3", Timeout: time.Second * 3},
+					Outbox: config.EventOutboxConfig{
+						Interval:       time.Second,
+						BatchSize:      100,
+						Lease:          time.Second * 30,
+						InitialBackoff: time.Second,
+						MaxBackoff:     time.Minute * 5,
+					},
+				},
+				Webhook: config.WebhookConfig{
+					Interval:       time.Second,
+					BatchSize:      100,
+					Lease:          time.Minute,
+					Timeout:        time.Second * 10,
+					MaxAttempts:    8,
+					InitialBackoff: time.Second * 10,
+					MaxBackoff:     time.Hour,
+					DisableAfter:   20,
+				},
+				GeoIP: config.GeoIPConfig{Database: "./geoip/GeoLite2-City.mmdb"},
+				Risk: config.RiskConfig{
+					Enable:         true,
+					ChallengeScore: 50,
+					BlockScore:     100,
+					Velocity:       config.RiskVelocityConfig{Window: time.Minute * 15, MaxFailures: 5, Score: 60},
+					NewDevice:      config.RiskNewDeviceConfig{Score: 30},
+					Travel:         config.RiskTravelConfig{MaxSpeed: 900, Score: 60},
+					Reputation:     config.RiskReputationConfig{Blocklist: "./risk/blocklist.txt", Score: 100},
+					TimeOfDay:      config.RiskTimeOfDayConfig{Window: 2, MinSessions: 10, Score: 20},
+				},
 			},
 		},
 	}
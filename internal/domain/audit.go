@@ -0,0 +1,87 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package domain
+
+import (
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Auth audit event kind.
+type AuditKind string
+
+// Auth audit event kinds.
+const (
+	AuditSignUp        AuditKind = "sign_up"
+	AuditSignIn        AuditKind = "sign_in"
+	AuditTokenRefresh  AuditKind = "token_refresh"
+	AuditSessionRevoke AuditKind = "session_revoke"
+	AuditStepUp        AuditKind = "step_up"
+	AuditAuthz         AuditKind = "authz"
+)
+
+// Auth audit event outcome.
+type AuditOutcome string
+
+// Auth audit event outcomes.
+const (
+	AuditSuccess AuditOutcome = "success"
+	AuditFailure AuditOutcome = "failure"
+)
+
+// Auth audit event. Events are append-only, they are removed only by the retention.
+type AuditEvent struct {
+	// Event id, ordered by the creation time.
+	Id ksuid.KSUID
+	// Event kind.
+	Kind AuditKind
+	// Identity performed the action, a user id or a service peer identity.
+	Actor string
+	// User id or username when the user is unknown.
+	Subject string
+	// User session id.
+	SessionId ksuid.KSUID
+	// Client ip address.
+	Ip string
+	// Client user agent.
+	UserAgent string
+	// Event outcome.
+	Outcome AuditOutcome
+	// Failure reason.
+	Reason string
+	// Event created at.
+	CreatedAt time.Time
+}
+
+// Auth audit events list filter. Zero values do not filter.
+type AuditFilter struct {
+	// User id or username the event is about.
+	Subject string
+	// Event kind.
+	Kind AuditKind
+	// Event outcome.
+	Outcome AuditOutcome
+}
+
+// Checking is the event matching the filter.
+func (f AuditFilter) Match(event AuditEvent) bool {
+	return (f.Subject == "" || event.Subject == f.Subject) &&
+		(f.Kind == "" || event.Kind == f.Kind) &&
+		(f.Outcome == "" || event.Outcome == f.Outcome)
+}
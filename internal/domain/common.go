@@ -19,6 +19,9 @@ package domain
 
 import "github.com/segmentio/ksuid"
 
+// Default maximum query page size.
+const DefaultMaxPageSize int32 = 50
+
 // Query sorting options.
 type SortOptions struct {
 	// Get first n elements.
@@ -30,3 +33,58 @@ type SortOptions struct {
 	// After cursor.
 	After ksuid.KSUID
 }
+
+// Getting query rows limit. One extra row is fetched to check is there a next page.
+func (o SortOptions) Limit() int32 {
+	switch {
+	case o.First != nil:
+		return *o.First + 1
+	case o.Last != nil:
+		return *o.Last + 1
+	default:
+		return 0
+	}
+}
+
+// Connection page info.
+type PageInfo struct {
+	// Is there a page after the end cursor.
+	HasNextPage bool
+	// Is there a page before the start cursor.
+	HasPreviousPage bool
+	// Cursor of the first element.
+	StartCursor ksuid.KSUID
+	// Cursor of the last element.
+	EndCursor ksuid.KSUID
+}
+
+// Getting a page of elements ordered by id. Rows are expected in the query order, ascending
+// for first and descending for last, with up to one extra row fetched by the options limit.
+func NewPage[T any](rows []T, sort SortOptions, id func(T) ksuid.KSUID) ([]T, PageInfo) {
+	var info PageInfo
+
+	if sort.First != nil {
+		if len(rows) > int(*sort.First) {
+			rows, info.HasNextPage = rows[:*sort.First], true
+		}
+
+		info.HasPreviousPage = sort.After != ksuid.Nil
+	} else if sort.Last != nil {
+		if len(rows) > int(*sort.Last) {
+			rows, info.HasPreviousPage = rows[:*sort.Last], true
+		}
+
+		info.HasNextPage = sort.Before != ksuid.Nil
+
+		// Reversing descending rows.
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	if len(rows) != 0 {
+		info.StartCursor, info.EndCursor = id(rows[0]), id(rows[len(rows)-1])
+	}
+
+	return rows, info
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package domain
+
+import (
+	"encoding/base64"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Page cursor encoding version.
+const cursorVersion byte = 1
+
+// Page cursor encoding.
+var cursorEncoding = base64.RawURLEncoding
+
+// Encoding an opaque page cursor of the element id. Cursor is the base64 encoded version byte
+// followed by the id, so the cursor format can be changed without breaking clients.
+func EncodeCursor(id ksuid.KSUID) []byte {
+	raw := append([]byte{cursorVersion}, id.Bytes()...)
+
+	cursor := make([]byte, cursorEncoding.EncodedLen(len(raw)))
+	cursorEncoding.Encode(cursor, raw)
+
+	return cursor
+}
+
+// Decoding an opaque page cursor. Empty cursor is decoded to the nil id.
+func DecodeCursor(cursor []byte) (ksuid.KSUID, error) {
+	if len(cursor) == 0 {
+		return ksuid.Nil, nil
+	}
+
+	raw := make([]byte, cursorEncoding.DecodedLen(len(cursor)))
+
+	n, err := cursorEncoding.Decode(raw, cursor)
+	if err != nil || n == 0 || raw[0] != cursorVersion {
+		return ksuid.Nil, &Error{Code: CodeInvalidArgument, Message: "Invalid cursor"}
+	}
+
+	id, err := ksuid.FromBytes(raw[1:n])
+	if err != nil {
+		return ksuid.Nil, &Error{Code: CodeInvalidArgument, Message: "Invalid cursor"}
+	}
+
+	return id, nil
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package domain
+
+import (
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Notification email kind.
+type EmailKind string
+
+// Notification email kinds.
+const (
+	EmailUserRegister EmailKind = "user_register"
+	EmailUserLoggedIn EmailKind = "user_logged_in"
+)
+
+// Notification email outbox message.
+type Email struct {
+	// Outbox message id.
+	Id ksuid.KSUID
+	// Notification email kind.
+	Kind EmailKind
+	// Recipient email address.
+	Email string
+	// Recipient username, used by register email.
+	Username string
+	// User ip address, used by logged in email.
+	Ip string
+	// Number of delivery attempts.
+	Attempts int32
+	// Outbox message created at.
+	CreatedAt time.Time
+}
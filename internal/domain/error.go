@@ -28,6 +28,7 @@ const (
 	CodeNotFound
 	CodeAlreadyExists
 	CodeInvalidArgument
+	CodePermissionDenied
 )
 
 // Error structure.
@@ -0,0 +1,89 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package domain
+
+import (
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Auth domain event type.
+type EventType string
+
+// Auth domain event types.
+const (
+	EventUserSignedUp     EventType = "user_signed_up"
+	EventSessionCreated   EventType = "session_created"
+	EventSessionRefreshed EventType = "session_refreshed"
+	EventSessionRevoked   EventType = "session_revoked"
+	EventMFAEnrolled      EventType = "mfa_enrolled"
+)
+
+// Checking is the event type known.
+func (t EventType) Valid() bool {
+	switch t {
+	case EventUserSignedUp, EventSessionCreated, EventSessionRefreshed, EventSessionRevoked, EventMFAEnrolled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Auth domain events schema version. Version is changed on breaking payload changes.
+const EventVersion int32 = 1
+
+// Auth domain event. Payload fields are set by the event type.
+type Event struct {
+	// Event id, ordered by the occurrence time.
+	Id ksuid.KSUID
+	// Event type.
+	Type EventType
+	// User id.
+	UserId ksuid.KSUID
+	// User session id.
+	SessionId ksuid.KSUID
+	// Client ip address.
+	Ip string
+	// Client user agent.
+	UserAgent string
+	// Client device type.
+	DeviceType string
+	// Client device name.
+	DeviceName string
+	// MFA method, used by MFA enrolled event.
+	Method string
+	// Event occurred at.
+	OccurredAt time.Time
+}
+
+// Encoded auth domain event outbox message.
+type EventMessage struct {
+	// Event id.
+	Id ksuid.KSUID
+	// Message bus subject.
+	Subject string
+	// Encoded event envelope.
+	Payload []byte
+	// Outbox sequence number, messages are relayed in the sequence order.
+	Sequence int64
+	// Number of publish attempts.
+	Attempts int32
+	// Outbox message created at.
+	CreatedAt time.Time
+}
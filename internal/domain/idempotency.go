@@ -0,0 +1,46 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package domain
+
+import "time"
+
+// Idempotency key errors.
+var (
+	// Idempotency key is reused with different request parameters.
+	ErrIdempotencyKeyMismatch = &Error{
+		Code:    CodeInvalidArgument,
+		Message: "Idempotency key is already used with different parameters",
+	}
+	// Request with the same idempotency key is not finished yet.
+	ErrIdempotencyKeyInProgress = &Error{
+		Code:    CodeAlreadyExists,
+		Message: "Request with the idempotency key is in progress",
+	}
+)
+
+// Idempotency key record of a mutating request.
+type IdempotencyKey struct {
+	// Hash of the request method and client idempotency key.
+	Id []byte
+	// Hash of the request parameters.
+	Fingerprint []byte
+	// Encrypted response of the completed request, empty while the request is in progress.
+	Response []byte
+	// Time after which the key can be reused.
+	ExpiresAt time.Time
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package domain
+
+// Outbox records written in the same transaction as the state change.
+type Outbox struct {
+	// Notification emails.
+	Emails []Email
+	// Encoded auth domain event messages.
+	Events []EventMessage
+	// Auth domain events of the webhook deliveries.
+	Webhooks []WebhookEvent
+}
+
+// Checking is the outbox empty.
+func (o Outbox) Empty() bool {
+	return len(o.Emails) == 0 && len(o.Events) == 0 && len(o.Webhooks) == 0
+}
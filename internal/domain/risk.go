@@ -0,0 +1,82 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package domain
+
+import (
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Sign in risk decision.
+type RiskDecision string
+
+// Sign in risk decisions.
+const (
+	// Sign in is allowed.
+	RiskAllow RiskDecision = "allow"
+	// Sign in requires the email verification code.
+	RiskChallenge RiskDecision = "challenge"
+	// Sign in is blocked.
+	RiskBlock RiskDecision = "block"
+)
+
+// Sign in attempt assessed by the risk signals.
+type LoginAttempt struct {
+	// Username used to sign in.
+	Username string
+	// Sign in session, not yet created.
+	Session UserSession
+	// Recent user sessions ordered by id.
+	Recent []UserSession
+	// Client user agent.
+	UserAgent string
+	// Sign in attempt time.
+	Time time.Time
+}
+
+// Risk signal contributing to the sign in score.
+type RiskSignal struct {
+	// Signal name.
+	Name string `json:"name"`
+	// Signal score, zero for the not triggered signal.
+	Score int `json:"score"`
+	// Human readable signal details for tuning.
+	Detail string `json:"detail,omitempty"`
+}
+
+// Sign in risk assessment, recorded for the thresholds tuning.
+type RiskAssessment struct {
+	// Assessment id, ordered by the creation time.
+	Id ksuid.KSUID
+	// Signing in user id.
+	UserId ksuid.KSUID
+	// Sign in session id.
+	SessionId ksuid.KSUID
+	// Client ip address.
+	Ip string
+	// Client user agent.
+	UserAgent string
+	// Total score of the signals.
+	Score int
+	// Sign in decision.
+	Decision RiskDecision
+	// Evaluated signals.
+	Signals []RiskSignal
+	// Assessment created at.
+	CreatedAt time.Time
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package domain
+
+import (
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Sign up saga step.
+type SagaStep string
+
+// Sign up saga steps.
+const (
+	// Saga is created, verification code is not verified yet.
+	SagaStarted SagaStep = "started"
+	// Verification code is verified and consumed.
+	SagaCodeVerified SagaStep = "code_verified"
+	// User is created by the user service.
+	SagaUserCreated SagaStep = "user_created"
+	// Session is created and register email is added to the outbox.
+	SagaCompleted SagaStep = "completed"
+	// Session creation is given up, register email is added to the outbox so the user can sign in.
+	SagaCompensated SagaStep = "compensated"
+	// Saga is failed before the user is created.
+	SagaFailed SagaStep = "failed"
+	// Saga is interrupted during the user creation, the user may exist and can sign in.
+	SagaUnresolved SagaStep = "unresolved"
+)
+
+// Sign up saga state.
+type SignUpSaga struct {
+	// Saga id.
+	Id ksuid.KSUID
+	// Current saga step.
+	Step SagaStep
+	// Created user id.
+	UserId ksuid.KSUID
+	// User email address.
+	Email string
+	// Username.
+	Username string
+	// User ip address.
+	Ip string
+	// Number of failed step attempts.
+	Attempts int32
+	// Last step error.
+	LastError string
+	// Saga updated at.
+	UpdatedAt time.Time
+}
+
+// Checking is saga in the final step.
+func (s SignUpSaga) Done() bool {
+	return s.Step == SagaCompleted || s.Step == SagaCompensated || s.Step == SagaFailed || s.Step == SagaUnresolved
+}
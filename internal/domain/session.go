@@ -0,0 +1,223 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package domain
+
+import (
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/pkg/useragent"
+
+	"github.com/segmentio/ksuid"
+)
+
+// User session state.
+type SessionState int
+
+const (
+	// Any session state.
+	SessionStateAny SessionState = iota
+	// Not expired session.
+	SessionStateActive
+	// Expired session.
+	SessionStateExpired
+)
+
+// User sessions list filter. Zero values do not filter.
+type SessionFilter struct {
+	// Session ip address or network.
+	Network netip.Prefix
+	// Sessions created after the time, with the id timestamp second precision.
+	CreatedAfter time.Time
+	// Sessions created before the time, with the id timestamp second precision.
+	CreatedBefore time.Time
+	// Session state.
+	State SessionState
+	// Session device type.
+	DeviceType string
+	// Case-insensitive session device name part.
+	DeviceName string
+}
+
+// Parsing a session ip address or CIDR network filter.
+func ParseNetwork(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+
+		return netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()).Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	addr = addr.Unmap()
+
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// Validating the sessions filter.
+func (f SessionFilter) Validate() error {
+	// Checking session state.
+	if f.State < SessionStateAny || f.State > SessionStateExpired {
+		return &Error{Code: CodeInvalidArgument, Message: "Invalid session state filter"}
+	}
+
+	// Checking created time range.
+	if !f.CreatedAfter.IsZero() && !f.CreatedBefore.IsZero() && f.CreatedBefore.Before(f.CreatedAfter) {
+		return &Error{Code: CodeInvalidArgument, Message: "`created_before` must not be before `created_after`"}
+	}
+
+	// Checking device type.
+	if f.DeviceType != "" && !useragent.IsDeviceType(f.DeviceType) {
+		return &Error{Code: CodeInvalidArgument, Message: "Invalid device type filter"}
+	}
+
+	return nil
+}
+
+// Getting the lowest session id created at or after the time.
+func (f SessionFilter) AfterId() ksuid.KSUID {
+	if f.CreatedAfter.IsZero() {
+		return ksuid.Nil
+	}
+
+	id, _ := ksuid.FromParts(f.CreatedAfter, make([]byte, 16))
+
+	return id
+}
+
+// Getting the lowest session id created after the time second.
+func (f SessionFilter) BeforeId() ksuid.KSUID {
+	if f.CreatedBefore.IsZero() {
+		return ksuid.Nil
+	}
+
+	id, _ := ksuid.FromParts(f.CreatedBefore.Truncate(time.Second).Add(time.Second), make([]byte, 16))
+
+	return id
+}
+
+// Checking is the session matching the filter.
+func (f SessionFilter) Match(session UserSession, now time.Time) bool {
+	if f.Network.IsValid() {
+		ip, err := netip.ParseAddr(session.Ip)
+		if err != nil || !f.Network.Contains(ip.Unmap()) {
+			return false
+		}
+	}
+
+	if after := f.AfterId(); after != ksuid.Nil && ksuid.Compare(session.Id, after) < 0 {
+		return false
+	}
+	if before := f.BeforeId(); before != ksuid.Nil && ksuid.Compare(session.Id, before) >= 0 {
+		return false
+	}
+
+	switch f.State {
+	case SessionStateActive:
+		if !session.ExpiresIn.After(now) {
+			return false
+		}
+	case SessionStateExpired:
+		if session.ExpiresIn.After(now) {
+			return false
+		}
+	}
+
+	if f.DeviceType != "" && session.DeviceType != f.DeviceType {
+		return false
+	}
+
+	if f.DeviceName != "" && !strings.Contains(strings.ToLower(session.DeviceName), strings.ToLower(f.DeviceName)) {
+		return false
+	}
+
+	return true
+}
+
+// Sign in novelty compared to the recent user sessions.
+type LoginNovelty struct {
+	// Neither the ip address nor its network is seen in the recent sessions.
+	NewNetwork bool
+	// Device fingerprint is not seen in the recent sessions.
+	NewDevice bool
+}
+
+// Checking is the sign in unusual.
+func (n LoginNovelty) Unusual() bool {
+	return n.NewNetwork || n.NewDevice
+}
+
+// Getting the session device fingerprint.
+func (s UserSession) Fingerprint() string {
+	return s.DeviceType + "/" + s.DeviceName
+}
+
+// Getting the ip address network with the IPv4 or IPv6 prefix bits. Zero bits compare only
+// ip addresses.
+func network(ip string, ipv4Bits, ipv6Bits int) (netip.Prefix, bool) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+
+	addr = addr.Unmap()
+
+	bits := ipv6Bits
+	if addr.Is4() {
+		bits = ipv4Bits
+	}
+
+	if bits <= 0 || bits > addr.BitLen() {
+		bits = addr.BitLen()
+	}
+
+	prefix, err := addr.Prefix(bits)
+
+	return prefix, err == nil
+}
+
+// Comparing the sign in session with the recent user sessions. Networks are the ip address
+// prefixes with the IPv4 or IPv6 bits.
+func CheckLogin(session UserSession, recent []UserSession, ipv4Bits, ipv6Bits int) LoginNovelty {
+	novelty := LoginNovelty{NewNetwork: true, NewDevice: true}
+
+	prefix, ok := network(session.Ip, ipv4Bits, ipv6Bits)
+
+	for _, s := range recent {
+		if s.Ip == session.Ip {
+			novelty.NewNetwork = false
+		} else if ok {
+			if other, err := netip.ParseAddr(s.Ip); err == nil && prefix.Contains(other.Unmap()) {
+				novelty.NewNetwork = false
+			}
+		}
+
+		if s.Fingerprint() == session.Fingerprint() {
+			novelty.NewDevice = false
+		}
+	}
+
+	return novelty
+}
@@ -35,6 +35,20 @@ type UserSession struct {
 	Ip string
 	// User session expires in.
 	ExpiresIn time.Time
+	// User session device type.
+	DeviceType string
+	// User session device name.
+	DeviceName string
+	// ISO 3166-1 alpha-2 country code of the ip address.
+	Country string
+	// City name of the ip address.
+	City string
+	// User session authentication level.
+	AuthLevel int
+	// User session authentication methods.
+	AuthMethods []string
+	// User session authentication time.
+	AuthTime time.Time
 }
 
 // User SignUp auth input.
@@ -51,6 +65,8 @@ type UserSignUpInput struct {
 	Code uint64
 	// User ip address.
 	Ip string
+	// User agent of the client device.
+	UserAgent string
 }
 
 // User SignIn auth input.
@@ -63,9 +79,29 @@ type UserSignInInput struct {
 	Secret string
 	// User ip address.
 	Ip string
+	// User agent of the client device.
+	UserAgent string
+	// Email verification code, required when the sign in is challenged.
+	Code uint64
+}
+
+// User authentication step up input.
+type UserStepUpInput struct {
+	// Session refresh token.
+	Refresh string
+	// Client secret key.
+	Secret string
+	// User password hash.
+	Password string
+	// Email verification code, the second authentication factor.
+	Code uint64
+	// User ip address.
+	Ip string
+	// User agent of the client device.
+	UserAgent string
 }
 
-//	User auth tokens.
+// User auth tokens.
 type UserTokens struct {
 	// JWT access token.
 	Access string
@@ -0,0 +1,101 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package domain
+
+import (
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Webhook delivery status.
+type WebhookDeliveryStatus string
+
+// Webhook delivery statuses.
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// Webhook endpoint receiving signed auth domain events.
+type Webhook struct {
+	// Webhook id.
+	Id ksuid.KSUID
+	// Endpoint URL.
+	URL string
+	// Subscribed auth domain event types.
+	EventTypes []EventType
+	// Delivery signing secret.
+	Secret string
+	// Is webhook enabled, endpoints that keep failing are disabled.
+	Enabled bool
+	// Number of consecutive failed delivery attempts.
+	Failures int32
+	// Webhook created at.
+	CreatedAt time.Time
+	// Webhook disabled at, zero when webhook is enabled.
+	DisabledAt time.Time
+}
+
+// Checking is the webhook subscribed to the event type.
+func (w Webhook) Subscribed(typ EventType) bool {
+	for _, t := range w.EventTypes {
+		if t == typ {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Auth domain event staged in the outbox, deliveries of the event are created for the
+// subscribed webhooks by the webhook dispatcher.
+type WebhookEvent struct {
+	// Auth domain event id.
+	Id ksuid.KSUID
+	// Auth domain event type.
+	Type EventType
+	// Encoded request body.
+	Payload []byte
+}
+
+// Webhook delivery of an auth domain event, deliveries are kept as the delivery log.
+type WebhookDelivery struct {
+	// Delivery id, ordered by the creation time.
+	Id ksuid.KSUID
+	// Webhook id.
+	WebhookId ksuid.KSUID
+	// Auth domain event id.
+	EventId ksuid.KSUID
+	// Auth domain event type.
+	EventType EventType
+	// Encoded request body.
+	Payload []byte
+	// Delivery status.
+	Status WebhookDeliveryStatus
+	// Number of delivery attempts.
+	Attempts int32
+	// Last endpoint response status code, zero when endpoint did not respond.
+	ResponseCode int32
+	// Last delivery error.
+	LastError string
+	// Delivery created at.
+	CreatedAt time.Time
+	// Delivery updated at.
+	UpdatedAt time.Time
+}
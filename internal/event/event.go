@@ -0,0 +1,100 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package event
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Auth domain events driver names.
+const (
+	DriverOutbox string = "outbox"
+	DriverNATS   string = "nats"
+)
+
+// Auth domain events with the configured driver.
+type Events struct {
+	Publisher
+	// Outbox relay, nil when events are not stored in the outbox.
+	Relay *Relay
+	// Closing message bus connection, nil when events are disabled.
+	close func()
+}
+
+// Creating new auth domain events with the configured driver.
+func NewEvents(cfg config.EventsConfig, repos postgres.EventOutbox) (*Events, error) {
+	switch cfg.Driver {
+	case "":
+		log.Warn().Msg("Auth domain events are disabled")
+
+		return &Events{Publisher: nopPublisher{}}, nil
+	case DriverOutbox:
+		bus, err := NewNATS(cfg.NATS)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Events{
+			Publisher: NewOutboxPublisher(repos, cfg.SubjectPrefix),
+			Relay:     NewRelay(repos, bus, cfg.Outbox),
+			close:     bus.Close,
+		}, nil
+	case DriverNATS:
+		bus, err := NewNATS(cfg.NATS)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Events{Publisher: NewBusPublisher(bus, cfg.SubjectPrefix), close: bus.Close}, nil
+	default:
+		return nil, fmt.Errorf("unknown events driver: %s", cfg.Driver)
+	}
+}
+
+// Stopping outbox relay and closing message bus connection.
+func (e *Events) Close() {
+	if e.Relay != nil {
+		e.Relay.Stop()
+	}
+
+	if e.close != nil {
+		e.close()
+	}
+}
+
+// Publisher returned by staging of the events fully stored in the outbox, nothing is
+// published after the commit.
+var Staged Publisher = nopPublisher{}
+
+// Disabled auth domain events publisher.
+type nopPublisher struct{}
+
+// Dropping auth domain events.
+func (nopPublisher) Publish(context.Context, ...domain.Event) error { return nil }
+
+// Dropping auth domain events.
+func (nopPublisher) Stage(context.Context, *domain.Outbox, ...domain.Event) (Publisher, error) {
+	return nopPublisher{}, nil
+}
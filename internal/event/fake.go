@@ -0,0 +1,67 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package event
+
+import (
+	"context"
+	"sync"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+)
+
+// In-memory message bus.
+type FakeBus struct {
+	mu       sync.Mutex
+	messages []domain.EventMessage
+	err      error
+}
+
+// Creating a new in-memory message bus.
+func NewFakeBus() *FakeBus {
+	return &FakeBus{}
+}
+
+// Sending encoded auth domain event messages.
+func (f *FakeBus) Send(_ context.Context, messages ...domain.EventMessage) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return f.err
+	}
+
+	f.messages = append(f.messages, messages...)
+
+	return nil
+}
+
+// Setting the error returned by the next sends, nil error restores sending.
+func (f *FakeBus) SetError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.err = err
+}
+
+// Getting sent messages.
+func (f *FakeBus) Sent() []domain.EventMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]domain.EventMessage(nil), f.messages...)
+}
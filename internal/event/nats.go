@@ -0,0 +1,89 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package event
+
+import (
+	"context"
+	"errors"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+)
+
+// NATS client name.
+const natsName string = "durudex-auth-service"
+
+// NATS message bus.
+type NATS struct {
+	conn *nats.Conn
+	cfg  config.NATSConfig
+}
+
+// Creating a new NATS message bus.
+func NewNATS(cfg config.NATSConfig) (*NATS, error) {
+	log.Debug().Msg("Creating a new NATS connection...")
+
+	if cfg.URL == "" {
+		return nil, errors.New("nats url is required")
+	}
+
+	conn, err := nats.Connect(cfg.URL,
+		nats.Name(natsName),
+		nats.Timeout(cfg.Timeout),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NATS{conn: conn, cfg: cfg}, nil
+}
+
+// Sending encoded auth domain event messages. Messages are flushed to the server before
+// returning, message id is set to the deduplication header.
+func (n *NATS) Send(ctx context.Context, messages ...domain.EventMessage) error {
+	for _, message := range messages {
+		msg := nats.NewMsg(message.Subject)
+		msg.Header.Set(nats.MsgIdHdr, message.Id.String())
+		msg.Data = message.Payload
+
+		if err := n.conn.PublishMsg(msg); err != nil {
+			return err
+		}
+	}
+
+	// Set default flush timeout.
+	if _, ok := ctx.Deadline(); !ok && n.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, n.cfg.Timeout)
+		defer cancel()
+	}
+
+	return n.conn.FlushWithContext(ctx)
+}
+
+// Closing NATS connection. Pending messages are flushed.
+func (n *NATS) Close() {
+	if err := n.conn.Drain(); err != nil {
+		log.Error().Err(err).Msg("failed to close nats connection")
+	}
+}
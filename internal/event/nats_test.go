@@ -0,0 +1,117 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package event_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/event"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// Running a new embedded NATS server.
+func runServer(t *testing.T) *server.Server {
+	t.Helper()
+
+	srv, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: -1, NoLog: true, NoSigs: true})
+	if err != nil {
+		t.Fatalf("error creating nats server: %s", err.Error())
+	}
+
+	go srv.Start()
+	t.Cleanup(srv.Shutdown)
+
+	if !srv.ReadyForConnections(time.Second * 5) {
+		t.Fatal("error nats server is not ready")
+	}
+
+	return srv
+}
+
+// Testing publishing auth domain events to NATS.
+func TestNATS_Send(t *testing.T) {
+	srv := runServer(t)
+
+	// Subscribing to auth domain events.
+	sub, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatalf("error connecting to nats: %s", err.Error())
+	}
+	defer sub.Close()
+
+	received := make(chan *nats.Msg, 1)
+
+	if _, err := sub.ChanSubscribe("durudex.auth.v1.>", received); err != nil {
+		t.Fatalf("error subscribing: %s", err.Error())
+	}
+
+	if err := sub.Flush(); err != nil {
+		t.Fatalf("error flushing subscription: %s", err.Error())
+	}
+
+	bus, err := event.NewNATS(config.NATSConfig{URL: srv.ClientURL(), Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("error creating nats bus: %s", err.Error())
+	}
+	defer bus.Close()
+
+	userId, sessionId := ksuid.New(), ksuid.New()
+
+	// Publishing session revoked event.
+	if err := event.NewBusPublisher(bus, "durudex.auth").Publish(context.Background(), domain.Event{
+		Type:      domain.EventSessionRevoked,
+		UserId:    userId,
+		SessionId: sessionId,
+	}); err != nil {
+		t.Fatalf("error publishing event: %s", err.Error())
+	}
+
+	select {
+	case msg := <-received:
+		if msg.Subject != "durudex.auth.v1.session_revoked" {
+			t.Errorf("error message subject: got %s", msg.Subject)
+		}
+
+		var envelope v1.AuthDomainEvent
+
+		if err := proto.Unmarshal(msg.Data, &envelope); err != nil {
+			t.Fatalf("error decoding event: %s", err.Error())
+		}
+
+		if msg.Header.Get(nats.MsgIdHdr) != ksuid.FromBytesOrNil(envelope.Id).String() {
+			t.Errorf("error message id header: got %s", msg.Header.Get(nats.MsgIdHdr))
+		}
+
+		revoked := envelope.GetSessionRevoked()
+		if envelope.Version != domain.EventVersion || revoked == nil ||
+			ksuid.FromBytesOrNil(revoked.UserId) != userId || ksuid.FromBytesOrNil(revoked.SessionId) != sessionId {
+			t.Errorf("error event envelope: got %v", &envelope)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("error message is not received")
+	}
+}
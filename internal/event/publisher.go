@@ -0,0 +1,270 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package event
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+	"github.com/durudex/go-protobuf-type/pbtype"
+
+	"github.com/segmentio/ksuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// Auth domain events publisher interface.
+type Publisher interface {
+	// Publishing auth domain events. Event id and occurrence time are set when empty.
+	Publish(ctx context.Context, events ...domain.Event) error
+	// Staging prepared auth domain events in the outbox written in the state change transaction.
+	// Returns the publisher of the events after the transaction is committed.
+	Stage(ctx context.Context, outbox *domain.Outbox, events ...domain.Event) (Publisher, error)
+}
+
+// Message bus interface.
+type Bus interface {
+	// Sending encoded auth domain event messages.
+	Send(ctx context.Context, messages ...domain.EventMessage) error
+}
+
+// Auth domain events publisher sending events directly to the message bus.
+type BusPublisher struct {
+	bus    Bus
+	prefix string
+}
+
+// Creating a new message bus auth domain events publisher.
+func NewBusPublisher(bus Bus, prefix string) *BusPublisher {
+	return &BusPublisher{bus: bus, prefix: prefix}
+}
+
+// Publishing auth domain events to the message bus.
+func (p *BusPublisher) Publish(ctx context.Context, events ...domain.Event) error {
+	messages, err := NewMessages(p.prefix, events...)
+	if err != nil {
+		return err
+	}
+
+	return p.bus.Send(ctx, messages...)
+}
+
+// Message bus is not transactional, events are sent after the transaction is committed.
+func (p *BusPublisher) Stage(context.Context, *domain.Outbox, ...domain.Event) (Publisher, error) {
+	return p, nil
+}
+
+// Auth domain events publisher storing events in the outbox, events are sent to the message
+// bus by the outbox relay.
+type OutboxPublisher struct {
+	repos  postgres.EventOutbox
+	prefix string
+}
+
+// Creating a new outbox auth domain events publisher.
+func NewOutboxPublisher(repos postgres.EventOutbox, prefix string) *OutboxPublisher {
+	return &OutboxPublisher{repos: repos, prefix: prefix}
+}
+
+// Publishing auth domain events to the outbox.
+func (p *OutboxPublisher) Publish(ctx context.Context, events ...domain.Event) error {
+	messages, err := NewMessages(p.prefix, events...)
+	if err != nil {
+		return err
+	}
+
+	return p.repos.Create(ctx, messages...)
+}
+
+// Staging auth domain events in the outbox, nothing is published after the commit.
+func (p *OutboxPublisher) Stage(_ context.Context, outbox *domain.Outbox, events ...domain.Event) (Publisher, error) {
+	messages, err := NewMessages(p.prefix, events...)
+	if err != nil {
+		return nil, err
+	}
+
+	outbox.Events = append(outbox.Events, messages...)
+
+	return nopPublisher{}, nil
+}
+
+// Auth domain events publishers, events are published by every publisher.
+type Publishers []Publisher
+
+// Publishing auth domain events by every publisher. Event ids are set once, so all publishers
+// share them. Every publisher is called, the first error is returned.
+func (p Publishers) Publish(ctx context.Context, events ...domain.Event) error {
+	prepared, err := PrepareAll(events...)
+	if err != nil {
+		return err
+	}
+
+	var first error
+
+	for _, publisher := range p {
+		if err := publisher.Publish(ctx, prepared...); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
+
+// Staging auth domain events by every publisher. Returns the publishers of the events not
+// stored in the outbox.
+func (p Publishers) Stage(ctx context.Context, outbox *domain.Outbox, events ...domain.Event) (Publisher, error) {
+	var pending Publishers
+
+	for _, publisher := range p {
+		after, err := publisher.Stage(ctx, outbox, events...)
+		if err != nil {
+			return nil, err
+		}
+
+		if after != (nopPublisher{}) {
+			pending = append(pending, after)
+		}
+	}
+
+	return pending, nil
+}
+
+// Setting empty auth domain events ids and occurrence times, so every publisher shares them.
+func PrepareAll(events ...domain.Event) ([]domain.Event, error) {
+	prepared := make([]domain.Event, len(events))
+
+	for i, event := range events {
+		event, err := Prepare(event)
+		if err != nil {
+			return nil, err
+		}
+
+		prepared[i] = event
+	}
+
+	return prepared, nil
+}
+
+// Setting empty auth domain event id and occurrence time.
+func Prepare(event domain.Event) (domain.Event, error) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	if event.Id == ksuid.Nil {
+		id, err := ksuid.NewRandomWithTime(event.OccurredAt)
+		if err != nil {
+			return domain.Event{}, err
+		}
+
+		event.Id = id
+	}
+
+	return event, nil
+}
+
+// Creating new encoded auth domain event messages.
+func NewMessages(prefix string, events ...domain.Event) ([]domain.EventMessage, error) {
+	messages := make([]domain.EventMessage, len(events))
+
+	for i, event := range events {
+		message, err := NewMessage(prefix, event)
+		if err != nil {
+			return nil, err
+		}
+
+		messages[i] = message
+	}
+
+	return messages, nil
+}
+
+// Creating a new encoded auth domain event message. Message subject is the prefixed event
+// version and type, e.g. "durudex.auth.v1.session_created".
+func NewMessage(prefix string, event domain.Event) (domain.EventMessage, error) {
+	event, err := Prepare(event)
+	if err != nil {
+		return domain.EventMessage{}, err
+	}
+
+	envelope, err := newEnvelope(event)
+	if err != nil {
+		return domain.EventMessage{}, err
+	}
+
+	payload, err := proto.Marshal(envelope)
+	if err != nil {
+		return domain.EventMessage{}, err
+	}
+
+	return domain.EventMessage{
+		Id:      event.Id,
+		Subject: fmt.Sprintf("%s.v%d.%s", prefix, domain.EventVersion, event.Type),
+		Payload: payload,
+	}, nil
+}
+
+// Creating a new auth domain event envelope.
+func newEnvelope(event domain.Event) (*v1.AuthDomainEvent, error) {
+	envelope := &v1.AuthDomainEvent{
+		Id:         event.Id.Bytes(),
+		Type:       string(event.Type),
+		Version:    domain.EventVersion,
+		OccurredAt: pbtype.New(event.OccurredAt),
+	}
+
+	switch event.Type {
+	case domain.EventUserSignedUp:
+		envelope.Payload = &v1.AuthDomainEvent_UserSignedUp{UserSignedUp: &v1.UserSignedUp{
+			UserId:    event.UserId.Bytes(),
+			SessionId: event.SessionId.Bytes(),
+			Ip:        event.Ip,
+			UserAgent: event.UserAgent,
+		}}
+	case domain.EventSessionCreated:
+		envelope.Payload = &v1.AuthDomainEvent_SessionCreated{SessionCreated: &v1.SessionCreated{
+			UserId:     event.UserId.Bytes(),
+			SessionId:  event.SessionId.Bytes(),
+			Ip:         event.Ip,
+			DeviceType: event.DeviceType,
+			DeviceName: event.DeviceName,
+		}}
+	case domain.EventSessionRefreshed:
+		envelope.Payload = &v1.AuthDomainEvent_SessionRefreshed{SessionRefreshed: &v1.SessionRefreshed{
+			UserId:    event.UserId.Bytes(),
+			SessionId: event.SessionId.Bytes(),
+		}}
+	case domain.EventSessionRevoked:
+		envelope.Payload = &v1.AuthDomainEvent_SessionRevoked{SessionRevoked: &v1.SessionRevoked{
+			UserId:    event.UserId.Bytes(),
+			SessionId: event.SessionId.Bytes(),
+		}}
+	case domain.EventMFAEnrolled:
+		envelope.Payload = &v1.AuthDomainEvent_MfaEnrolled{MfaEnrolled: &v1.MFAEnrolled{
+			UserId: event.UserId.Bytes(),
+			Method: event.Method,
+		}}
+	default:
+		return nil, fmt.Errorf("unknown event type: %s", event.Type)
+	}
+
+	return envelope, nil
+}
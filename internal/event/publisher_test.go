@@ -0,0 +1,58 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package event_test
+
+import (
+	"testing"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/event"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+
+	"github.com/segmentio/ksuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// Testing encoding MFA enrolled event message.
+func TestNewMessage_MFAEnrolled(t *testing.T) {
+	userId := ksuid.New()
+
+	message, err := event.NewMessage("durudex.auth", domain.Event{
+		Type:   domain.EventMFAEnrolled,
+		UserId: userId,
+		Method: "totp",
+	})
+	if err != nil {
+		t.Fatalf("error creating event message: %s", err.Error())
+	}
+
+	if message.Subject != "durudex.auth.v1.mfa_enrolled" {
+		t.Errorf("error message subject: got %s", message.Subject)
+	}
+
+	var envelope v1.AuthDomainEvent
+
+	if err := proto.Unmarshal(message.Payload, &envelope); err != nil {
+		t.Fatalf("error decoding event: %s", err.Error())
+	}
+
+	enrolled := envelope.GetMfaEnrolled()
+	if enrolled == nil || ksuid.FromBytesOrNil(enrolled.UserId) != userId || enrolled.Method != "totp" {
+		t.Errorf("error event envelope: got %v", &envelope)
+	}
+}
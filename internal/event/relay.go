@@ -0,0 +1,133 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/recovery"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Auth domain event outbox relay. Sends claimed outbox messages to the message bus in id
+// order and retries failed messages with exponential backoff.
+type Relay struct {
+	repos postgres.EventOutbox
+	bus   Bus
+	cfg   config.EventOutboxConfig
+	done  chan struct{}
+	stop  chan struct{}
+}
+
+// Creating a new auth domain event outbox relay.
+func NewRelay(repos postgres.EventOutbox, bus Bus, cfg config.EventOutboxConfig) *Relay {
+	return &Relay{
+		repos: repos,
+		bus:   bus,
+		cfg:   cfg,
+		done:  make(chan struct{}),
+		stop:  make(chan struct{}),
+	}
+}
+
+// Running auth domain event outbox relay.
+func (r *Relay) Run() {
+	log.Info().Msg("Running event outbox relay...")
+
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			recovery.Do("event outbox relay", func() {
+				if err := r.Relay(context.Background()); err != nil {
+					log.Error().Err(err).Msg("failed to relay outbox events")
+				}
+			})
+		}
+	}
+}
+
+// Stopping auth domain event outbox relay. Waits for the current batch.
+func (r *Relay) Stop() {
+	log.Info().Msg("Stopping event outbox relay...")
+
+	close(r.stop)
+	<-r.done
+}
+
+// Relaying a batch of due outbox messages to the message bus.
+func (r *Relay) Relay(ctx context.Context) error {
+	messages, err := r.repos.Claim(ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		return err
+	}
+
+	for i, message := range messages {
+		if err := r.bus.Send(ctx, message); err != nil {
+			log.Warn().Err(err).Str("id", message.Id.String()).Str("subject", message.Subject).
+				Int32("attempts", message.Attempts).Msg("failed to send outbox event, retrying")
+
+			// Rest messages are retried with the failed message to keep the order.
+			return r.retry(ctx, messages[i:], time.Now().Add(r.backoff(message.Attempts)), err.Error())
+		}
+
+		// Deleting sent message.
+		if err := r.repos.Delete(ctx, message.Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Scheduling the next outbox messages publish attempt.
+func (r *Relay) retry(ctx context.Context, messages []domain.EventMessage, next time.Time, lastErr string) error {
+	for _, message := range messages {
+		if err := r.repos.Retry(ctx, message.Id, next, lastErr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Getting exponential retry backoff by number of attempts.
+func (r *Relay) backoff(attempts int32) time.Duration {
+	backoff := r.cfg.InitialBackoff
+
+	for i := int32(1); i < attempts && backoff < r.cfg.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > r.cfg.MaxBackoff {
+		return r.cfg.MaxBackoff
+	}
+
+	return backoff
+}
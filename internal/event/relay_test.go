@@ -0,0 +1,96 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package event_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/event"
+	"github.com/durudex/durudex-auth-service/internal/repository/memory"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Testing relaying outbox events in order after the message bus failure.
+func TestRelay_Relay(t *testing.T) {
+	repos := memory.NewMemoryRepository()
+	bus := event.NewFakeBus()
+	ctx := context.Background()
+
+	relay := event.NewRelay(repos.EventOutbox, bus, config.EventOutboxConfig{
+		BatchSize:      10,
+		Lease:          time.Minute,
+		InitialBackoff: 0,
+		MaxBackoff:     0,
+	})
+
+	publisher := event.NewOutboxPublisher(repos.EventOutbox, "durudex.auth")
+	userId := ksuid.New()
+
+	// Publishing events to the outbox.
+	for _, typ := range []domain.EventType{domain.EventSessionCreated, domain.EventSessionRefreshed, domain.EventSessionRevoked} {
+		if err := publisher.Publish(ctx, domain.Event{Type: typ, UserId: userId, SessionId: ksuid.New()}); err != nil {
+			t.Fatalf("error publishing event: %s", err.Error())
+		}
+	}
+
+	// Message bus is unavailable, events are scheduled for retry.
+	bus.SetError(errors.New("nats: no servers available for connection"))
+
+	if err := relay.Relay(ctx); err != nil {
+		t.Fatalf("error relaying events: %s", err.Error())
+	}
+
+	bus.SetError(nil)
+
+	if err := relay.Relay(ctx); err != nil {
+		t.Fatalf("error relaying events: %s", err.Error())
+	}
+
+	sent := bus.Sent()
+
+	want := []string{
+		"durudex.auth.v1.session_created",
+		"durudex.auth.v1.session_refreshed",
+		"durudex.auth.v1.session_revoked",
+	}
+
+	if len(sent) != len(want) {
+		t.Fatalf("error number of sent events: got %d, want %d", len(sent), len(want))
+	}
+
+	for i, message := range sent {
+		if message.Subject != want[i] {
+			t.Errorf("error sent event subject: got %s, want %s", message.Subject, want[i])
+		}
+	}
+
+	// Sent events are deleted from the outbox.
+	if err := relay.Relay(ctx); err != nil {
+		t.Fatalf("error relaying events: %s", err.Error())
+	}
+
+	if len(bus.Sent()) != len(want) {
+		t.Errorf("error events are sent again: got %v", bus.Sent())
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/recovery"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Expired idempotency keys cleaner.
+type Cleaner struct {
+	repos postgres.Idempotency
+	cfg   config.IdempotencyConfig
+	done  chan struct{}
+	stop  chan struct{}
+}
+
+// Creating a new expired idempotency keys cleaner.
+func NewCleaner(repos postgres.Idempotency, cfg config.IdempotencyConfig) *Cleaner {
+	return &Cleaner{
+		repos: repos,
+		cfg:   cfg,
+		done:  make(chan struct{}),
+		stop:  make(chan struct{}),
+	}
+}
+
+// Running expired idempotency keys cleaner.
+func (c *Cleaner) Run() {
+	log.Info().Msg("Running idempotency keys cleaner...")
+
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			recovery.Do("idempotency keys cleaner", func() {
+				if err := c.Clean(context.Background()); err != nil {
+					log.Error().Err(err).Msg("failed to delete expired idempotency keys")
+				}
+			})
+		}
+	}
+}
+
+// Stopping expired idempotency keys cleaner. Waits for the current batch.
+func (c *Cleaner) Stop() {
+	log.Info().Msg("Stopping idempotency keys cleaner...")
+
+	close(c.stop)
+	<-c.done
+}
+
+// Deleting expired idempotency keys in batches.
+func (c *Cleaner) Clean(ctx context.Context) error {
+	for {
+		select {
+		case <-c.stop:
+			return nil
+		default:
+		}
+
+		deleted, err := c.repos.DeleteExpired(ctx, c.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		if deleted < int64(c.cfg.BatchSize) {
+			return nil
+		}
+	}
+}
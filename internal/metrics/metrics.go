@@ -0,0 +1,105 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package metrics
+
+import (
+	"crypto/x509"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics namespace.
+const namespace string = "durudex_auth"
+
+// Total number of recovered panics.
+var PanicsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "panics_total",
+	Help:      "Total number of recovered panics.",
+}, []string{"source", "name"})
+
+// TLS certificate expiration time in unix seconds.
+var TLSCertificateExpiry = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Name:      "tls_certificate_expiry_timestamp_seconds",
+	Help:      "TLS certificate expiration time in unix seconds.",
+}, []string{"name"})
+
+// Getting a TLS reload function that sets the certificate expiry metric.
+func ObserveCertificate(name string) func(leaf *x509.Certificate) {
+	return func(leaf *x509.Certificate) {
+		TLSCertificateExpiry.WithLabelValues(name).Set(float64(leaf.NotAfter.Unix()))
+	}
+}
+
+// Total number of outbox email delivery results.
+var OutboxEmailsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "outbox_emails_total",
+	Help:      "Total number of outbox email delivery results.",
+}, []string{"kind", "result"})
+
+// Total number of finished sign up sagas by final step.
+var SignUpSagasTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "sign_up_sagas_total",
+	Help:      "Total number of finished sign up sagas by final step.",
+}, []string{"step"})
+
+// Total number of recorded auth audit events by kind and outcome.
+var AuditEventsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "audit_events_total",
+	Help:      "Total number of recorded auth audit events by kind and outcome.",
+}, []string{"kind", "outcome"})
+
+// Total number of auth audit events failed to be written.
+var AuditWriteErrorsTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "audit_write_errors_total",
+	Help:      "Total number of auth audit events failed to be written.",
+})
+
+// Total number of webhook delivery attempt results by event type.
+var WebhookDeliveriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "webhook_deliveries_total",
+	Help:      "Total number of webhook delivery attempt results by event type.",
+}, []string{"event_type", "result"})
+
+// Total number of webhooks disabled after consecutive failed deliveries.
+var WebhooksDisabledTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "webhooks_disabled_total",
+	Help:      "Total number of webhooks disabled after consecutive failed deliveries.",
+})
+
+// Total number of sign in risk decisions.
+var RiskDecisionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "risk_decisions_total",
+	Help:      "Total number of sign in risk decisions.",
+}, []string{"decision"})
+
+// Total number of triggered sign in risk signals.
+var RiskSignalsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "risk_signals_total",
+	Help:      "Total number of triggered sign in risk signals.",
+}, []string{"signal"})
@@ -0,0 +1,69 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+)
+
+// Metrics server shutdown timeout.
+const shutdownTimeout time.Duration = time.Second * 5
+
+// Metrics HTTP server structure.
+type Server struct{ server *http.Server }
+
+// Creating a new metrics HTTP server.
+func NewServer(cfg config.MetricsConfig) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return &Server{server: &http.Server{
+		Addr:              cfg.Host + ":" + cfg.Port,
+		Handler:           mux,
+		ReadHeaderTimeout: time.Second * 5,
+	}}
+}
+
+// Running metrics HTTP server.
+func (s *Server) Run() {
+	log.Info().Msg("Running metrics server...")
+
+	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal().Err(err).Msg("error running metrics server")
+	}
+}
+
+// Stopping metrics HTTP server.
+func (s *Server) Stop() {
+	log.Info().Msg("Stopping metrics server...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		log.Error().Err(err).Msg("failed to stop metrics server")
+	}
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/schema"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/pgx"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/rs/zerolog/log"
+)
+
+// Schema version mismatch error.
+var ErrVersionMismatch = errors.New("schema version mismatch")
+
+// Schema migrations status structure.
+type Status struct {
+	// Applied schema version, zero when no migrations are applied.
+	Version uint
+	// Schema version expected by the binary.
+	Latest uint
+	// Last migration failed and the schema must be fixed by hand.
+	Dirty bool
+}
+
+// Embedded schema migrator. Migrations are run under a postgres advisory lock, so
+// several instances can migrate on start at the same time.
+type Migrator struct {
+	m      *migrate.Migrate
+	latest uint
+}
+
+// Creating a new embedded schema migrator.
+func NewMigrator(url string, cfg config.MigrateConfig) (*Migrator, error) {
+	log.Debug().Msg("Creating a new schema migrator")
+
+	src, err := newSource(schema.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := latestVersion(src)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := migrate.NewWithSourceInstance("iofs", src, databaseURL(url))
+	if err != nil {
+		return nil, err
+	}
+
+	m.Log = logger{}
+
+	// Set advisory lock timeout.
+	if cfg.LockTimeout != 0 {
+		m.LockTimeout = cfg.LockTimeout
+	}
+
+	return &Migrator{m: m, latest: latest}, nil
+}
+
+// Applying all pending migrations.
+func (m *Migrator) Up() error {
+	if err := m.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+
+	return nil
+}
+
+// Rolling back the last applied migration.
+func (m *Migrator) Down() error {
+	if err := m.m.Steps(-1); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+
+	return nil
+}
+
+// Getting schema migrations status.
+func (m *Migrator) Status() (Status, error) {
+	version, dirty, err := m.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return Status{}, err
+	}
+
+	return Status{Version: version, Latest: m.latest, Dirty: dirty}, nil
+}
+
+// Checking the applied schema version matches the binary.
+func (m *Migrator) Check() error {
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	return status.Check()
+}
+
+// Closing migrator source and database connections.
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	if srcErr != nil {
+		return srcErr
+	}
+
+	return dbErr
+}
+
+// Checking the applied schema version matches the binary.
+func (s Status) Check() error {
+	switch {
+	case s.Dirty:
+		return fmt.Errorf("%w: version %d is dirty", ErrVersionMismatch, s.Version)
+	case s.Version != s.Latest:
+		return fmt.Errorf("%w: got %d, want %d", ErrVersionMismatch, s.Version, s.Latest)
+	default:
+		return nil
+	}
+}
+
+// Getting the latest embedded schema version.
+func LatestVersion() (uint, error) {
+	src, err := newSource(schema.FS)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	return latestVersion(src)
+}
+
+// Creating a new migrations source driver.
+func newSource(fsys fs.FS) (source.Driver, error) {
+	return iofs.New(fsys, ".")
+}
+
+// Getting the latest source migration version.
+func latestVersion(src source.Driver) (uint, error) {
+	version, err := src.First()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		} else if err != nil {
+			return 0, err
+		}
+
+		version = next
+	}
+}
+
+// Getting migrate database url with pgx driver scheme.
+func databaseURL(url string) string {
+	for _, scheme := range []string{"postgresql://", "postgres://"} {
+		if strings.HasPrefix(url, scheme) {
+			return "pgx://" + strings.TrimPrefix(url, scheme)
+		}
+	}
+
+	return url
+}
+
+// Migrate logger writing to zerolog.
+type logger struct{}
+
+// Printing migrate log message.
+func (logger) Printf(format string, v ...interface{}) {
+	log.Info().Msgf(strings.TrimSuffix(format, "\n"), v...)
+}
+
+// Verbose logging is disabled.
+func (logger) Verbose() bool { return false }
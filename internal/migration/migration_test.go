@@ -0,0 +1,103 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package migration
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/durudex/durudex-auth-service/schema"
+)
+
+// Testing every embedded migration has up and down files.
+func TestLatestVersion(t *testing.T) {
+	files, err := fs.Glob(schema.FS, "*.up.sql")
+	if err != nil {
+		t.Fatalf("error getting up migrations: %s", err.Error())
+	}
+
+	for _, file := range files {
+		if _, err := fs.Stat(schema.FS, strings.TrimSuffix(file, ".up.sql")+".down.sql"); err != nil {
+			t.Errorf("error getting down migration: %s", err.Error())
+		}
+	}
+
+	latest, err := LatestVersion()
+	if err != nil {
+		t.Fatalf("error getting latest version: %s", err.Error())
+	}
+
+	if latest != uint(len(files)) {
+		t.Errorf("error latest version: got %d, want %d", latest, len(files))
+	}
+}
+
+// Testing schema version check.
+func TestStatus_Check(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name    string
+		status  Status
+		wantErr bool
+	}{
+		{name: "OK", status: Status{Version: 4, Latest: 4}},
+		{name: "Behind", status: Status{Version: 3, Latest: 4}, wantErr: true},
+		{name: "Ahead", status: Status{Version: 5, Latest: 4}, wantErr: true},
+		{name: "Empty", status: Status{Latest: 4}, wantErr: true},
+		{name: "Dirty", status: Status{Version: 4, Latest: 4, Dirty: true}, wantErr: true},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.status.Check()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error checking status: %v, want error %t", err, tt.wantErr)
+			}
+
+			if err != nil && !errors.Is(err, ErrVersionMismatch) {
+				t.Errorf("error checking status: got %v, want %v", err, ErrVersionMismatch)
+			}
+		})
+	}
+}
+
+// Testing migrate database url scheme.
+func TestDatabaseURL(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{name: "Postgres", url: "postgres://localhost/durudex", want: "pgx://localhost/durudex"},
+		{name: "PostgreSQL", url: "postgresql://localhost/durudex", want: "pgx://localhost/durudex"},
+		{name: "Pgx", url: "pgx://localhost/durudex", want: "pgx://localhost/durudex"},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := databaseURL(tt.url); got != tt.want {
+				t.Errorf("error database url: got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/client"
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/metrics"
+	"github.com/durudex/durudex-auth-service/internal/recovery"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Email delivery results.
+const (
+	resultDelivered string = "delivered"
+	resultRetried   string = "retried"
+	resultDead      string = "dead"
+)
+
+// Email outbox dispatcher. Delivers claimed outbox emails to the email service with
+// exponential backoff and moves emails to the dead letter after max attempts.
+type Dispatcher struct {
+	repos postgres.Outbox
+	email client.Email
+	cfg   config.OutboxConfig
+	done  chan struct{}
+	stop  chan struct{}
+}
+
+// Creating a new email outbox dispatcher.
+func NewDispatcher(repos postgres.Outbox, email client.Email, cfg config.OutboxConfig) *Dispatcher {
+	return &Dispatcher{
+		repos: repos,
+		email: email,
+		cfg:   cfg,
+		done:  make(chan struct{}),
+		stop:  make(chan struct{}),
+	}
+}
+
+// Running email outbox dispatcher.
+func (d *Dispatcher) Run() {
+	log.Info().Msg("Running email outbox dispatcher...")
+
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+			recovery.Do("email outbox dispatcher", func() {
+				if err := d.Dispatch(context.Background()); err != nil {
+					log.Error().Err(err).Msg("failed to dispatch outbox emails")
+				}
+			})
+		}
+	}
+}
+
+// Stopping email outbox dispatcher. Waits for the current batch.
+func (d *Dispatcher) Stop() {
+	log.Info().Msg("Stopping email outbox dispatcher...")
+
+	close(d.stop)
+	<-d.done
+}
+
+// Dispatching a batch of due outbox emails.
+func (d *Dispatcher) Dispatch(ctx context.Context) error {
+	emails, err := d.repos.Claim(ctx, d.cfg.BatchSize, d.cfg.Lease)
+	if err != nil {
+		return err
+	}
+
+	for _, email := range emails {
+		if err := d.deliver(ctx, email); err != nil {
+			if err := d.fail(ctx, email, err); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// Deleting delivered email.
+		if err := d.repos.Delete(ctx, email.Id); err != nil {
+			return err
+		}
+
+		metrics.OutboxEmailsTotal.WithLabelValues(string(email.Kind), resultDelivered).Inc()
+	}
+
+	return nil
+}
+
+// Delivering an outbox email to the email service.
+func (d *Dispatcher) deliver(ctx context.Context, email domain.Email) error {
+	switch email.Kind {
+	case domain.EmailUserRegister:
+		_, err := d.email.SendEmailUserRegister(ctx, &v1.SendEmailUserRegisterRequest{
+			Email:    email.Email,
+			Username: email.Username,
+		})
+
+		return err
+	case domain.EmailUserLoggedIn:
+		_, err := d.email.SendEmailUserLoggedIn(ctx, &v1.SendEmailUserLoggedInRequest{
+			Email: email.Email,
+			Ip:    email.Ip,
+		})
+
+		return err
+	default:
+		return fmt.Errorf("unknown email kind: %s", email.Kind)
+	}
+}
+
+// Scheduling a retry or moving a failed email to the dead letter.
+func (d *Dispatcher) fail(ctx context.Context, email domain.Email, deliveryErr error) error {
+	logger := log.With().Str("id", email.Id.String()).Str("kind", string(email.Kind)).
+		Int32("attempts", email.Attempts).Err(deliveryErr).Logger()
+
+	// Moving email to the dead letter.
+	if email.Attempts >= d.cfg.MaxAttempts {
+		logger.Error().Msg("outbox email moved to the dead letter")
+		metrics.OutboxEmailsTotal.WithLabelValues(string(email.Kind), resultDead).Inc()
+
+		return d.repos.Dead(ctx, email.Id, deliveryErr.Error())
+	}
+
+	logger.Warn().Msg("failed to deliver outbox email, retrying")
+	metrics.OutboxEmailsTotal.WithLabelValues(string(email.Kind), resultRetried).Inc()
+
+	return d.repos.Retry(ctx, email.Id, time.Now().Add(d.backoff(email.Attempts)), deliveryErr.Error())
+}
+
+// Getting exponential retry backoff by number of attempts.
+func (d *Dispatcher) backoff(attempts int32) time.Duration {
+	backoff := d.cfg.InitialBackoff
+
+	for i := int32(1); i < attempts && backoff < d.cfg.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > d.cfg.MaxBackoff {
+		return d.cfg.MaxBackoff
+	}
+
+	return backoff
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/outbox"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc"
+)
+
+// In-memory email outbox repository.
+type outboxRepository struct {
+	emails  []domain.Email
+	deleted []ksuid.KSUID
+	retried map[ksuid.KSUID]time.Time
+	dead    []ksuid.KSUID
+}
+
+// Claiming all emails.
+func (r *outboxRepository) Claim(context.Context, int32, time.Duration) ([]domain.Email, error) {
+	return r.emails, nil
+}
+
+// Deleting a delivered email.
+func (r *outboxRepository) Delete(_ context.Context, id ksuid.KSUID) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+// Scheduling a retry.
+func (r *outboxRepository) Retry(_ context.Context, id ksuid.KSUID, next time.Time, _ string) error {
+	r.retried[id] = next
+	return nil
+}
+
+// Moving to the dead letter.
+func (r *outboxRepository) Dead(_ context.Context, id ksuid.KSUID, _ string) error {
+	r.dead = append(r.dead, id)
+	return nil
+}
+
+// Email service client with failing recipients.
+type emailClient struct {
+	v1.EmailUserServiceClient
+	failing map[string]bool
+}
+
+// Sending register email.
+func (c *emailClient) SendEmailUserRegister(_ context.Context, in *v1.SendEmailUserRegisterRequest, _ ...grpc.CallOption) (*v1.SendEmailUserRegisterResponse, error) {
+	if c.failing[in.Email] {
+		return nil, errors.New("email service unavailable")
+	}
+
+	return &v1.SendEmailUserRegisterResponse{}, nil
+}
+
+// Sending logged in email.
+func (c *emailClient) SendEmailUserLoggedIn(_ context.Context, in *v1.SendEmailUserLoggedInRequest, _ ...grpc.CallOption) (*v1.SendEmailUserLoggedInResponse, error) {
+	if c.failing[in.Email] {
+		return nil, errors.New("email service unavailable")
+	}
+
+	return &v1.SendEmailUserLoggedInResponse{}, nil
+}
+
+// Testing dispatching outbox emails.
+func TestDispatcher_Dispatch(t *testing.T) {
+	cfg := config.OutboxConfig{
+		BatchSize:      10,
+		Lease:          time.Minute,
+		MaxAttempts:    3,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Second * 3,
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name        string
+		email       domain.Email
+		failing     bool
+		wantDeleted bool
+		wantRetry   time.Duration
+		wantDead    bool
+	}{
+		{
+			name:        "Delivered register email",
+			email:       domain.Email{Kind: domain.EmailUserRegister, Email: "ok@durudex.com", Attempts: 1},
+			wantDeleted: true,
+		},
+		{
+			name:        "Delivered logged in email",
+			email:       domain.Email{Kind: domain.EmailUserLoggedIn, Email: "ok@durudex.com", Attempts: 1},
+			wantDeleted: true,
+		},
+		{
+			name:      "Retry with backoff",
+			email:     domain.Email{Kind: domain.EmailUserLoggedIn, Email: "fail@durudex.com", Attempts: 2},
+			failing:   true,
+			wantRetry: time.Second * 2,
+		},
+		{
+			name:     "Dead letter after max attempts",
+			email:    domain.Email{Kind: domain.EmailUserRegister, Email: "fail@durudex.com", Attempts: 3},
+			failing:  true,
+			wantDead: true,
+		},
+		{
+			name:     "Dead letter unknown kind",
+			email:    domain.Email{Kind: "unknown", Email: "ok@durudex.com", Attempts: 3},
+			wantDead: true,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.email.Id = ksuid.New()
+
+			repos := &outboxRepository{emails: []domain.Email{tt.email}, retried: map[ksuid.KSUID]time.Time{}}
+			client := &emailClient{failing: map[string]bool{"fail@durudex.com": tt.failing}}
+
+			start := time.Now()
+
+			if err := outbox.NewDispatcher(repos, client, cfg).Dispatch(context.Background()); err != nil {
+				t.Fatalf("error dispatching emails: %s", err.Error())
+			}
+
+			// Check delivered email.
+			if got := len(repos.deleted) == 1; got != tt.wantDeleted {
+				t.Errorf("error deleted email: got %t, want %t", got, tt.wantDeleted)
+			}
+
+			// Check dead letter email.
+			if got := len(repos.dead) == 1; got != tt.wantDead {
+				t.Errorf("error dead email: got %t, want %t", got, tt.wantDead)
+			}
+
+			// Check retry backoff.
+			next, ok := repos.retried[tt.email.Id]
+			if ok != (tt.wantRetry != 0) {
+				t.Fatalf("error retried email: got %t, want %t", ok, tt.wantRetry != 0)
+			}
+
+			if ok && (next.Before(start.Add(tt.wantRetry)) || next.After(time.Now().Add(tt.wantRetry))) {
+				t.Errorf("error retry backoff: got %s, want %s", next.Sub(start), tt.wantRetry)
+			}
+		})
+	}
+}
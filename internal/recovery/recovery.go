@@ -0,0 +1,65 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package recovery
+
+import (
+	"runtime/debug"
+
+	"github.com/durudex/durudex-auth-service/internal/metrics"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Panic sources.
+const (
+	SourceUnary  string = "grpc_unary"
+	SourceStream string = "grpc_stream"
+	SourceJob    string = "job"
+)
+
+// Reporting a recovered panic with the stack trace.
+func Report(logger zerolog.Logger, source, name string, value interface{}) {
+	metrics.PanicsTotal.WithLabelValues(source, name).Inc()
+
+	logger.Error().
+		Str("source", source).
+		Str("name", name).
+		Interface("panic", value).
+		Bytes("stack", debug.Stack()).
+		Msg("recovered from panic")
+}
+
+// Calling a job function with panic recovery. Reports whether the function panicked.
+func Do(name string, fn func()) (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			Report(log.Logger, SourceJob, name, r)
+			panicked = true
+		}
+	}()
+
+	fn()
+
+	return false
+}
+
+// Running a background job with panic recovery.
+func Go(name string, fn func()) {
+	go Do(name, fn)
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package recovery_test
+
+import (
+	"testing"
+
+	"github.com/durudex/durudex-auth-service/internal/metrics"
+	"github.com/durudex/durudex-auth-service/internal/recovery"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// Testing calling a job function with panic recovery.
+func TestDo(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name string
+		fn   func()
+		want bool
+	}{
+		{
+			name: "OK",
+			fn:   func() {},
+			want: false,
+		},
+		{
+			name: "Panic",
+			fn: func() {
+				var m map[string]int
+				m["key"]++
+			},
+			want: true,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := metrics.PanicsTotal.WithLabelValues(recovery.SourceJob, tt.name)
+			before := testutil.ToFloat64(counter)
+
+			// Calling a job function.
+			if got := recovery.Do(tt.name, tt.fn); got != tt.want {
+				t.Errorf("error panicked status: got %t, want %t", got, tt.want)
+			}
+
+			// Check panic metric.
+			if tt.want && testutil.ToFloat64(counter) != before+1 {
+				t.Error("error panic is not counted")
+			}
+		})
+	}
+}
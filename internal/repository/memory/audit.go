@@ -0,0 +1,99 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	"github.com/segmentio/ksuid"
+)
+
+// In-memory auth audit repository.
+type AuditRepository struct{ store *store }
+
+// Creating auth audit events.
+func (r *AuditRepository) Create(_ context.Context, events ...domain.AuditEvent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, event := range events {
+		r.store.events[event.Id] = event
+	}
+
+	return nil
+}
+
+// Getting a filtered auth audit events list page ordered by id.
+func (r *AuditRepository) GetList(_ context.Context, filter domain.AuditFilter, sortOptions domain.SortOptions) ([]domain.AuditEvent, domain.PageInfo, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var events []domain.AuditEvent
+
+	for _, event := range r.store.events {
+		if !filter.Match(event) {
+			continue
+		}
+
+		// Filtering by before and after cursors.
+		if sortOptions.Before != ksuid.Nil && ksuid.Compare(event.Id, sortOptions.Before) >= 0 {
+			continue
+		}
+		if sortOptions.After != ksuid.Nil && ksuid.Compare(event.Id, sortOptions.After) <= 0 {
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	// Sorting by first or last option.
+	if sortOptions.First != nil {
+		sort.Slice(events, func(i, j int) bool { return ksuid.Compare(events[i].Id, events[j].Id) < 0 })
+	} else if sortOptions.Last != nil {
+		sort.Slice(events, func(i, j int) bool { return ksuid.Compare(events[i].Id, events[j].Id) > 0 })
+	}
+
+	if n := int(sortOptions.Limit()); len(events) > n {
+		events = events[:n]
+	}
+
+	events, info := domain.NewPage(events, sortOptions, func(e domain.AuditEvent) ksuid.KSUID { return e.Id })
+
+	return events, info, nil
+}
+
+// Preparing storage for events, in-memory storage does not need it.
+func (r *AuditRepository) Prepare(context.Context, time.Time) error { return nil }
+
+// Deleting auth audit events created before the time.
+func (r *AuditRepository) DeleteBefore(_ context.Context, before time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, event := range r.store.events {
+		if event.CreatedAt.Before(before) {
+			delete(r.store.events, id)
+		}
+	}
+
+	return nil
+}
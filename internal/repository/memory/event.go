@@ -0,0 +1,95 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	"github.com/segmentio/ksuid"
+)
+
+// In-memory auth domain event outbox repository.
+type EventOutboxRepository struct{ store *store }
+
+// Creating auth domain event outbox messages.
+func (r *EventOutboxRepository) Create(_ context.Context, messages ...domain.EventMessage) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.addMessages(messages)
+
+	return nil
+}
+
+// Claiming due outbox messages ordered by sequence.
+func (r *EventOutboxRepository) Claim(_ context.Context, limit int32, lease time.Duration) ([]domain.EventMessage, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+
+	var due []*outboxMessage
+
+	for _, m := range r.store.messages {
+		if !m.nextAt.After(now) {
+			due = append(due, m)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool { return due[i].message.Sequence < due[j].message.Sequence })
+
+	if len(due) > int(limit) {
+		due = due[:limit]
+	}
+
+	messages := make([]domain.EventMessage, len(due))
+
+	for i, m := range due {
+		m.message.Attempts++
+		m.nextAt = now.Add(lease)
+		messages[i] = m.message
+	}
+
+	return messages, nil
+}
+
+// Deleting a published outbox message.
+func (r *EventOutboxRepository) Delete(_ context.Context, id ksuid.KSUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.messages, id)
+
+	return nil
+}
+
+// Scheduling the next outbox message publish attempt.
+func (r *EventOutboxRepository) Retry(_ context.Context, id ksuid.KSUID, next time.Time, lastErr string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if m, ok := r.store.messages[id]; ok {
+		m.nextAt, m.lastError = next, lastErr
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+)
+
+// In-memory idempotency key repository.
+type IdempotencyRepository struct{ store *store }
+
+// Reserving an idempotency key.
+func (r *IdempotencyRepository) Reserve(_ context.Context, key domain.IdempotencyKey) (domain.IdempotencyKey, bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if stored, ok := r.store.keys[string(key.Id)]; ok && !stored.ExpiresAt.Before(time.Now()) {
+		return stored, false, nil
+	}
+
+	r.store.keys[string(key.Id)] = key
+
+	return key, true, nil
+}
+
+// Storing response of the completed request.
+func (r *IdempotencyRepository) Complete(_ context.Context, id, response []byte, expiresAt time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if key, ok := r.store.keys[string(id)]; ok {
+		key.Response, key.ExpiresAt = response, expiresAt
+		r.store.keys[string(id)] = key
+	}
+
+	return nil
+}
+
+// Releasing an idempotency key of the failed request.
+func (r *IdempotencyRepository) Release(_ context.Context, id []byte) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if key, ok := r.store.keys[string(id)]; ok && key.Response == nil {
+		delete(r.store.keys, string(id))
+	}
+
+	return nil
+}
+
+// Deleting expired idempotency keys.
+func (r *IdempotencyRepository) DeleteExpired(_ context.Context, limit int32) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var deleted int64
+
+	for id, key := range r.store.keys {
+		if deleted == int64(limit) {
+			break
+		}
+
+		if key.ExpiresAt.Before(time.Now()) {
+			delete(r.store.keys, id)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/ksuid"
+)
+
+// In-memory repository structure. Repositories share one store, so writes to several tables
+// are atomic like postgres transactions.
+type MemoryRepository struct {
+	Session         *SessionRepository
+	Outbox          *OutboxRepository
+	Saga            *SagaRepository
+	Idempotency     *IdempotencyRepository
+	Audit           *AuditRepository
+	EventOutbox     *EventOutboxRepository
+	Webhook         *WebhookRepository
+	WebhookDelivery *WebhookDeliveryRepository
+	Risk            *RiskRepository
+}
+
+// In-memory email outbox record.
+type outboxEmail struct {
+	email     domain.Email
+	nextAt    time.Time
+	lastError string
+	dead      bool
+}
+
+// In-memory auth domain event outbox record.
+type outboxMessage struct {
+	message   domain.EventMessage
+	nextAt    time.Time
+	lastError string
+}
+
+// In-memory webhook delivery record.
+type webhookDelivery struct {
+	delivery domain.WebhookDelivery
+	nextAt   time.Time
+}
+
+// In-memory store of all repositories.
+type store struct {
+	mu         sync.Mutex
+	sessions   map[ksuid.KSUID]domain.UserSession
+	emails     map[ksuid.KSUID]*outboxEmail
+	sagas      map[ksuid.KSUID]domain.SignUpSaga
+	keys       map[string]domain.IdempotencyKey
+	events     map[ksuid.KSUID]domain.AuditEvent
+	messages   map[ksuid.KSUID]*outboxMessage
+	webhooks   map[ksuid.KSUID]domain.Webhook
+	deliveries map[ksuid.KSUID]*webhookDelivery
+	staged     map[ksuid.KSUID]domain.WebhookEvent
+	risks      map[ksuid.KSUID]domain.RiskAssessment
+	// Last auth domain event outbox sequence number.
+	sequence int64
+}
+
+// Creating a new in-memory repository.
+func NewMemoryRepository() *MemoryRepository {
+	log.Debug().Msg("Creating a new memory repository...")
+
+	s := &store{
+		sessions:   make(map[ksuid.KSUID]domain.UserSession),
+		emails:     make(map[ksuid.KSUID]*outboxEmail),
+		sagas:      make(map[ksuid.KSUID]domain.SignUpSaga),
+		keys:       make(map[string]domain.IdempotencyKey),
+		events:     make(map[ksuid.KSUID]domain.AuditEvent),
+		messages:   make(map[ksuid.KSUID]*outboxMessage),
+		webhooks:   make(map[ksuid.KSUID]domain.Webhook),
+		deliveries: make(map[ksuid.KSUID]*webhookDelivery),
+		staged:     make(map[ksuid.KSUID]domain.WebhookEvent),
+		risks:      make(map[ksuid.KSUID]domain.RiskAssessment),
+	}
+
+	return &MemoryRepository{
+		Session:         &SessionRepository{store: s},
+		Outbox:          &OutboxRepository{store: s},
+		Saga:            &SagaRepository{store: s},
+		Idempotency:     &IdempotencyRepository{store: s},
+		Audit:           &AuditRepository{store: s},
+		EventOutbox:     &EventOutboxRepository{store: s},
+		Webhook:         &WebhookRepository{store: s},
+		WebhookDelivery: &WebhookDeliveryRepository{store: s},
+		Risk:            &RiskRepository{store: s},
+	}
+}
+
+// Adding the outbox records of the state change. Store must be locked.
+func (s *store) addOutbox(outbox domain.Outbox) {
+	s.addEmails(outbox.Emails)
+	s.addMessages(outbox.Events)
+	s.addWebhookEvents(outbox.Webhooks)
+}
+
+// Adding notification emails to the outbox. Store must be locked.
+func (s *store) addEmails(emails []domain.Email) {
+	for _, email := range emails {
+		email.CreatedAt = time.Now()
+		s.emails[email.Id] = &outboxEmail{email: email, nextAt: email.CreatedAt}
+	}
+}
+
+// Adding auth domain event messages to the outbox. Store must be locked.
+func (s *store) addMessages(messages []domain.EventMessage) {
+	now := time.Now()
+
+	for _, message := range messages {
+		s.sequence++
+
+		message.Sequence, message.CreatedAt = s.sequence, now
+		s.messages[message.Id] = &outboxMessage{message: message, nextAt: now}
+	}
+}
+
+// Staging auth domain events of the webhook deliveries. Store must be locked.
+func (s *store) addWebhookEvents(events []domain.WebhookEvent) {
+	for _, e := range events {
+		s.staged[e.Id] = e
+	}
+}
+
+// Adding pending webhook deliveries. Store must be locked.
+func (s *store) addDeliveries(deliveries []domain.WebhookDelivery) {
+	now := time.Now()
+
+	for _, delivery := range deliveries {
+		delivery.Status, delivery.CreatedAt, delivery.UpdatedAt = domain.WebhookDeliveryPending, now, now
+		s.deliveries[delivery.Id] = &webhookDelivery{delivery: delivery, nextAt: now}
+	}
+}
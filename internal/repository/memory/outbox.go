@@ -0,0 +1,98 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	"github.com/segmentio/ksuid"
+)
+
+// In-memory email outbox repository.
+type OutboxRepository struct{ store *store }
+
+// Claiming due outbox emails.
+func (r *OutboxRepository) Claim(_ context.Context, limit int32, lease time.Duration) ([]domain.Email, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+
+	var due []*outboxEmail
+
+	for _, e := range r.store.emails {
+		if !e.dead && !e.nextAt.After(now) {
+			due = append(due, e)
+		}
+	}
+
+	// Claiming the oldest emails first.
+	sort.Slice(due, func(i, j int) bool { return due[i].nextAt.Before(due[j].nextAt) })
+
+	if len(due) > int(limit) {
+		due = due[:limit]
+	}
+
+	emails := make([]domain.Email, len(due))
+
+	for i, e := range due {
+		e.email.Attempts++
+		e.nextAt = now.Add(lease)
+		emails[i] = e.email
+	}
+
+	return emails, nil
+}
+
+// Deleting a delivered outbox email.
+func (r *OutboxRepository) Delete(_ context.Context, id ksuid.KSUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.emails, id)
+
+	return nil
+}
+
+// Scheduling the next outbox email delivery attempt.
+func (r *OutboxRepository) Retry(_ context.Context, id ksuid.KSUID, next time.Time, lastErr string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if e, ok := r.store.emails[id]; ok {
+		e.nextAt, e.lastError = next, lastErr
+	}
+
+	return nil
+}
+
+// Moving an outbox email to the dead letter.
+func (r *OutboxRepository) Dead(_ context.Context, id ksuid.KSUID, lastErr string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if e, ok := r.store.emails[id]; ok {
+		e.dead, e.lastError = true, lastErr
+	}
+
+	return nil
+}
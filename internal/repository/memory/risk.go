@@ -0,0 +1,79 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	"github.com/segmentio/ksuid"
+)
+
+// In-memory sign in risk assessment repository.
+type RiskRepository struct{ store *store }
+
+// Creating a sign in risk assessment.
+func (r *RiskRepository) Create(_ context.Context, assessment domain.RiskAssessment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	assessment.Signals = append([]domain.RiskSignal{}, assessment.Signals...)
+	r.store.risks[assessment.Id] = assessment
+
+	return nil
+}
+
+// Getting a user risk assessments list page ordered by id.
+func (r *RiskRepository) GetList(_ context.Context, userId ksuid.KSUID, sortOptions domain.SortOptions) ([]domain.RiskAssessment, domain.PageInfo, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var assessments []domain.RiskAssessment
+
+	for _, assessment := range r.store.risks {
+		if assessment.UserId != userId {
+			continue
+		}
+
+		// Filtering by before and after cursors.
+		if sortOptions.Before != ksuid.Nil && ksuid.Compare(assessment.Id, sortOptions.Before) >= 0 {
+			continue
+		}
+		if sortOptions.After != ksuid.Nil && ksuid.Compare(assessment.Id, sortOptions.After) <= 0 {
+			continue
+		}
+
+		assessments = append(assessments, assessment)
+	}
+
+	// Sorting by first or last option.
+	if sortOptions.First != nil {
+		sort.Slice(assessments, func(i, j int) bool { return ksuid.Compare(assessments[i].Id, assessments[j].Id) < 0 })
+	} else if sortOptions.Last != nil {
+		sort.Slice(assessments, func(i, j int) bool { return ksuid.Compare(assessments[i].Id, assessments[j].Id) > 0 })
+	}
+
+	if n := int(sortOptions.Limit()); len(assessments) > n {
+		assessments = assessments[:n]
+	}
+
+	assessments, info := domain.NewPage(assessments, sortOptions, func(a domain.RiskAssessment) ksuid.KSUID { return a.Id })
+
+	return assessments, info, nil
+}
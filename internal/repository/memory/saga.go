@@ -0,0 +1,111 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+)
+
+// In-memory sign up saga repository.
+type SagaRepository struct{ store *store }
+
+// Creating a new sign up saga.
+func (r *SagaRepository) Create(_ context.Context, saga domain.SignUpSaga) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	saga.UpdatedAt = time.Now()
+	r.store.sagas[saga.Id] = saga
+
+	return nil
+}
+
+// Updating a sign up saga step state.
+func (r *SagaRepository) Update(_ context.Context, saga domain.SignUpSaga) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.update(saga)
+
+	return nil
+}
+
+// Updating a sign up saga step state. Store must be locked.
+func (r *SagaRepository) update(saga domain.SignUpSaga) {
+	stored, ok := r.store.sagas[saga.Id]
+	if !ok {
+		return
+	}
+
+	stored.Step, stored.UserId, stored.Attempts, stored.LastError = saga.Step, saga.UserId, saga.Attempts, saga.LastError
+	stored.UpdatedAt = time.Now()
+
+	r.store.sagas[saga.Id] = stored
+}
+
+// Finishing a sign up saga.
+func (r *SagaRepository) Finish(_ context.Context, saga domain.SignUpSaga, session *domain.UserSession, outbox domain.Outbox) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if session != nil {
+		if _, ok := r.store.sessions[session.Id]; ok {
+			return &domain.Error{Code: domain.CodeAlreadyExists, Message: "Session already exists"}
+		}
+
+		r.store.sessions[session.Id] = *session
+	}
+
+	r.store.addOutbox(outbox)
+	r.update(saga)
+
+	return nil
+}
+
+// Claiming stale sign up sagas in progress.
+func (r *SagaRepository) ClaimStale(_ context.Context, before time.Time, limit int32, lease time.Duration) ([]domain.SignUpSaga, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var sagas []domain.SignUpSaga
+
+	for _, saga := range r.store.sagas {
+		if !saga.Done() && saga.UpdatedAt.Before(before) {
+			sagas = append(sagas, saga)
+		}
+	}
+
+	sort.Slice(sagas, func(i, j int) bool { return sagas[i].UpdatedAt.Before(sagas[j].UpdatedAt) })
+
+	if len(sagas) > int(limit) {
+		sagas = sagas[:limit]
+	}
+
+	// Claimed sagas are stale again after the lease.
+	for _, saga := range sagas {
+		stored := r.store.sagas[saga.Id]
+		stored.UpdatedAt = before.Add(lease)
+		r.store.sagas[saga.Id] = stored
+	}
+
+	return sagas, nil
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	"github.com/segmentio/ksuid"
+)
+
+// In-memory user session repository.
+type SessionRepository struct{ store *store }
+
+// Creating a new user session.
+func (r *SessionRepository) Create(_ context.Context, session domain.UserSession, outbox domain.Outbox) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.sessions[session.Id]; ok {
+		return &domain.Error{Code: domain.CodeAlreadyExists, Message: "Session already exists"}
+	}
+
+	r.store.sessions[session.Id] = session
+	r.store.addOutbox(outbox)
+
+	return nil
+}
+
+// Getting a user session.
+func (r *SessionRepository) Get(_ context.Context, userId, id ksuid.KSUID) (domain.UserSession, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	session, ok := r.store.sessions[id]
+	if !ok || session.UserId != userId {
+		return domain.UserSession{}, &domain.Error{Code: domain.CodeNotFound, Message: "Session not found"}
+	}
+
+	return session, nil
+}
+
+// Getting a filtered user sessions list page ordered by id.
+func (r *SessionRepository) GetList(_ context.Context, userId ksuid.KSUID, filter domain.SessionFilter, sortOptions domain.SortOptions) ([]domain.UserSession, domain.PageInfo, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var sessions []domain.UserSession
+
+	now := time.Now()
+
+	for _, session := range r.store.sessions {
+		if session.UserId != userId || !filter.Match(session, now) {
+			continue
+		}
+
+		// Filtering by before and after cursors.
+		if sortOptions.Before != ksuid.Nil && ksuid.Compare(session.Id, sortOptions.Before) >= 0 {
+			continue
+		}
+		if sortOptions.After != ksuid.Nil && ksuid.Compare(session.Id, sortOptions.After) <= 0 {
+			continue
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	// Sorting by first or last option.
+	if sortOptions.First != nil {
+		sort.Slice(sessions, func(i, j int) bool { return ksuid.Compare(sessions[i].Id, sessions[j].Id) < 0 })
+	} else if sortOptions.Last != nil {
+		sort.Slice(sessions, func(i, j int) bool { return ksuid.Compare(sessions[i].Id, sessions[j].Id) > 0 })
+	}
+
+	if n := int(sortOptions.Limit()); len(sessions) > n {
+		sessions = sessions[:n]
+	}
+
+	sessions, info := domain.NewPage(sessions, sortOptions, func(s domain.UserSession) ksuid.KSUID { return s.Id })
+
+	return sessions, info, nil
+}
+
+// Deleting a user session.
+func (r *SessionRepository) Delete(_ context.Context, userId, id ksuid.KSUID, outbox domain.Outbox) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	session, ok := r.store.sessions[id]
+	if !ok || session.UserId != userId {
+		return &domain.Error{Code: domain.CodeNotFound, Message: "Session not found"}
+	}
+
+	delete(r.store.sessions, id)
+	r.store.addOutbox(outbox)
+
+	return nil
+}
+
+// Getting total user session count.
+func (r *SessionRepository) GetTotalCount(_ context.Context, userId ksuid.KSUID) (int32, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var count int32
+
+	for _, session := range r.store.sessions {
+		if session.UserId == userId {
+			count++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,299 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Webhook not found error.
+var errWebhookNotFound = &domain.Error{Code: domain.CodeNotFound, Message: "Webhook not found"}
+
+// In-memory webhook repository.
+type WebhookRepository struct{ store *store }
+
+// Creating a new webhook.
+func (r *WebhookRepository) Create(_ context.Context, webhook domain.Webhook) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	webhook.EventTypes = append([]domain.EventType(nil), webhook.EventTypes...)
+	r.store.webhooks[webhook.Id] = webhook
+
+	return nil
+}
+
+// Getting a webhook by id.
+func (r *WebhookRepository) Get(_ context.Context, id ksuid.KSUID) (domain.Webhook, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	webhook, ok := r.store.webhooks[id]
+	if !ok {
+		return domain.Webhook{}, errWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+// Getting all webhooks ordered by id.
+func (r *WebhookRepository) GetList(context.Context) ([]domain.Webhook, error) {
+	return r.list(func(domain.Webhook) bool { return true }), nil
+}
+
+// Deleting a webhook with its deliveries.
+func (r *WebhookRepository) Delete(_ context.Context, id ksuid.KSUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.webhooks[id]; !ok {
+		return errWebhookNotFound
+	}
+
+	delete(r.store.webhooks, id)
+
+	for deliveryId, d := range r.store.deliveries {
+		if d.delivery.WebhookId == id {
+			delete(r.store.deliveries, deliveryId)
+		}
+	}
+
+	return nil
+}
+
+// Enabling a webhook and resetting its consecutive failures.
+func (r *WebhookRepository) Enable(_ context.Context, id ksuid.KSUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	webhook, ok := r.store.webhooks[id]
+	if !ok {
+		return errWebhookNotFound
+	}
+
+	webhook.Enabled, webhook.Failures, webhook.DisabledAt = true, 0, time.Time{}
+	r.store.webhooks[id] = webhook
+
+	return nil
+}
+
+// Resetting consecutive failures of the webhook after a successful delivery.
+func (r *WebhookRepository) Succeed(_ context.Context, id ksuid.KSUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if webhook, ok := r.store.webhooks[id]; ok {
+		webhook.Failures = 0
+		r.store.webhooks[id] = webhook
+	}
+
+	return nil
+}
+
+// Counting a failed delivery attempt of the enabled webhook.
+func (r *WebhookRepository) Fail(_ context.Context, id ksuid.KSUID, limit int32) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Disabled or deleted webhooks are not counted.
+	webhook, ok := r.store.webhooks[id]
+	if !ok || !webhook.Enabled {
+		return false, nil
+	}
+
+	webhook.Failures++
+
+	if webhook.Failures >= limit {
+		webhook.Enabled, webhook.DisabledAt = false, time.Now()
+	}
+
+	r.store.webhooks[id] = webhook
+
+	return !webhook.Enabled, nil
+}
+
+// Getting matched webhooks ordered by id.
+func (r *WebhookRepository) list(match func(domain.Webhook) bool) []domain.Webhook {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var webhooks []domain.Webhook
+
+	for _, webhook := range r.store.webhooks {
+		if match(webhook) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool { return ksuid.Compare(webhooks[i].Id, webhooks[j].Id) < 0 })
+
+	return webhooks
+}
+
+// In-memory webhook delivery repository.
+type WebhookDeliveryRepository struct{ store *store }
+
+// Creating pending webhook deliveries.
+func (r *WebhookDeliveryRepository) Create(_ context.Context, deliveries ...domain.WebhookDelivery) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.addDeliveries(deliveries)
+
+	return nil
+}
+
+// Staging auth domain events of the webhook deliveries.
+func (r *WebhookDeliveryRepository) CreateEvents(_ context.Context, events ...domain.WebhookEvent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.addWebhookEvents(events)
+
+	return nil
+}
+
+// Creating pending deliveries of the staged auth domain events.
+func (r *WebhookDeliveryRepository) Fanout(_ context.Context, limit int32) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	events := make([]domain.WebhookEvent, 0, len(r.store.staged))
+	for _, e := range r.store.staged {
+		events = append(events, e)
+	}
+
+	sort.Slice(events, func(i, j int) bool { return ksuid.Compare(events[i].Id, events[j].Id) < 0 })
+
+	if len(events) > int(limit) {
+		events = events[:limit]
+	}
+
+	var deliveries []domain.WebhookDelivery
+
+	for _, e := range events {
+		for _, webhook := range r.store.webhooks {
+			if webhook.Enabled && webhook.Subscribed(e.Type) {
+				deliveries = append(deliveries, domain.WebhookDelivery{
+					Id:        ksuid.New(),
+					WebhookId: webhook.Id,
+					EventId:   e.Id,
+					EventType: e.Type,
+					Payload:   e.Payload,
+				})
+			}
+		}
+
+		delete(r.store.staged, e.Id)
+	}
+
+	r.store.addDeliveries(deliveries)
+
+	return nil
+}
+
+// Claiming due pending deliveries.
+func (r *WebhookDeliveryRepository) Claim(_ context.Context, limit int32, lease time.Duration) ([]domain.WebhookDelivery, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+
+	var due []*webhookDelivery
+
+	for _, d := range r.store.deliveries {
+		if d.delivery.Status == domain.WebhookDeliveryPending && !d.nextAt.After(now) {
+			due = append(due, d)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool { return due[i].nextAt.Before(due[j].nextAt) })
+
+	if len(due) > int(limit) {
+		due = due[:limit]
+	}
+
+	deliveries := make([]domain.WebhookDelivery, len(due))
+
+	for i, d := range due {
+		d.delivery.Attempts++
+		d.delivery.UpdatedAt = now
+		d.nextAt = now.Add(lease)
+		deliveries[i] = d.delivery
+	}
+
+	return deliveries, nil
+}
+
+// Updating the delivery attempt result.
+func (r *WebhookDeliveryRepository) Update(_ context.Context, delivery domain.WebhookDelivery, next time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if d, ok := r.store.deliveries[delivery.Id]; ok {
+		d.delivery.Status = delivery.Status
+		d.delivery.ResponseCode, d.delivery.LastError = delivery.ResponseCode, delivery.LastError
+		d.delivery.UpdatedAt, d.nextAt = time.Now(), next
+	}
+
+	return nil
+}
+
+// Getting a webhook deliveries list page ordered by id.
+func (r *WebhookDeliveryRepository) GetList(_ context.Context, webhookId ksuid.KSUID, sortOptions domain.SortOptions) ([]domain.WebhookDelivery, domain.PageInfo, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var deliveries []domain.WebhookDelivery
+
+	for _, d := range r.store.deliveries {
+		if d.delivery.WebhookId != webhookId {
+			continue
+		}
+
+		// Filtering by before and after cursors.
+		if sortOptions.Before != ksuid.Nil && ksuid.Compare(d.delivery.Id, sortOptions.Before) >= 0 {
+			continue
+		}
+		if sortOptions.After != ksuid.Nil && ksuid.Compare(d.delivery.Id, sortOptions.After) <= 0 {
+			continue
+		}
+
+		deliveries = append(deliveries, d.delivery)
+	}
+
+	// Sorting by first or last option.
+	if sortOptions.First != nil {
+		sort.Slice(deliveries, func(i, j int) bool { return ksuid.Compare(deliveries[i].Id, deliveries[j].Id) < 0 })
+	} else if sortOptions.Last != nil {
+		sort.Slice(deliveries, func(i, j int) bool { return ksuid.Compare(deliveries[i].Id, deliveries[j].Id) > 0 })
+	}
+
+	if n := int(sortOptions.Limit()); len(deliveries) > n {
+		deliveries = deliveries[:n]
+	}
+
+	deliveries, info := domain.NewPage(deliveries, sortOptions, func(d domain.WebhookDelivery) ksuid.KSUID { return d.Id })
+
+	return deliveries, info, nil
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/pkg/database/postgres"
+
+	"github.com/leporo/sqlf"
+	"github.com/segmentio/ksuid"
+)
+
+// Auth audit repository interface. Events are append-only.
+type Audit interface {
+	// Creating auth audit events.
+	Create(ctx context.Context, events ...domain.AuditEvent) error
+	// Getting a filtered auth audit events list page ordered by id.
+	GetList(ctx context.Context, filter domain.AuditFilter, sort domain.SortOptions) ([]domain.AuditEvent, domain.PageInfo, error)
+	// Preparing storage for events created until the time.
+	Prepare(ctx context.Context, until time.Time) error
+	// Deleting auth audit events created before the time. Storage may keep events until the
+	// whole partition is expired.
+	DeleteBefore(ctx context.Context, before time.Time) error
+}
+
+// Auth audit repository structure.
+type AuditRepository struct{ psql postgres.Postgres }
+
+// Creating a new auth audit postgres repository.
+func NewAuditRepository(psql postgres.Postgres) *AuditRepository {
+	return &AuditRepository{psql: psql}
+}
+
+// Creating auth audit events.
+func (r *AuditRepository) Create(ctx context.Context, events ...domain.AuditEvent) error {
+	// Starting a new transaction.
+	tx, err := r.psql.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO auth_audit (id, kind, actor, subject, session_id, ip, user_agent, outcome, reason,
+		created_at) VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::inet, $7, $8, $9, $10)`
+
+	for _, event := range events {
+		if _, err := tx.Exec(ctx, query, postgres.KSUID(event.Id), event.Kind, event.Actor, event.Subject,
+			postgres.KSUID(event.SessionId), event.Ip, event.UserAgent, event.Outcome, event.Reason,
+			event.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Getting a filtered auth audit events list page ordered by id.
+func (r *AuditRepository) GetList(ctx context.Context, filter domain.AuditFilter, sort domain.SortOptions) ([]domain.AuditEvent, domain.PageInfo, error) {
+	qb := sqlf.PostgreSQL.Select(`id, kind, actor, subject, session_id, COALESCE(host(ip), ''), user_agent,
+		outcome, reason, created_at`).From("auth_audit")
+
+	// Added query filter.
+	if filter.Subject != "" {
+		qb.Where("subject = ?", filter.Subject)
+	}
+	if filter.Kind != "" {
+		qb.Where("kind = ?", filter.Kind)
+	}
+	if filter.Outcome != "" {
+		qb.Where("outcome = ?", filter.Outcome)
+	}
+
+	// Added before sort option.
+	if sort.Before != ksuid.Nil {
+		qb.Where("id < ?", postgres.KSUID(sort.Before))
+	}
+	// Added after sort option.
+	if sort.After != ksuid.Nil {
+		qb.Where("id > ?", postgres.KSUID(sort.After))
+	}
+
+	// Added first or last sort option, one extra row is fetched to check the next page.
+	if sort.First != nil {
+		qb.OrderBy("id ASC").Limit(sort.Limit())
+	} else if sort.Last != nil {
+		qb.OrderBy("id DESC").Limit(sort.Limit())
+	}
+
+	rows, err := r.psql.Query(ctx, qb.String(), qb.Args()...)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+	defer rows.Close()
+
+	var events []domain.AuditEvent
+
+	for rows.Next() {
+		var event domain.AuditEvent
+
+		// Scanning query row.
+		if err := rows.Scan((*postgres.KSUID)(&event.Id), &event.Kind, &event.Actor, &event.Subject,
+			(*postgres.KSUID)(&event.SessionId), &event.Ip, &event.UserAgent, &event.Outcome, &event.Reason,
+			&event.CreatedAt); err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+
+		events = append(events, event)
+	}
+
+	// Checking for errors.
+	if err := rows.Err(); err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	events, info := domain.NewPage(events, sort, func(e domain.AuditEvent) ksuid.KSUID { return e.Id })
+
+	return events, info, nil
+}
+
+// Creating missing monthly partitions for events created from now until the time.
+func (r *AuditRepository) Prepare(ctx context.Context, until time.Time) error {
+	query := "SELECT auth_audit_create_partitions($1, $2)"
+	_, err := r.psql.Exec(ctx, query, time.Now(), until)
+
+	return err
+}
+
+// Dropping monthly partitions with events created before the time.
+func (r *AuditRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	query := "SELECT auth_audit_drop_partitions($1)"
+	_, err := r.psql.Exec(ctx, query, before)
+
+	return err
+}
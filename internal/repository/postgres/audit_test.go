@@ -0,0 +1,180 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	pgtype "github.com/durudex/durudex-auth-service/pkg/database/postgres"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/segmentio/ksuid"
+)
+
+// Testing creating auth audit events.
+func TestAuditRepository_Create(t *testing.T) {
+	// Creating a new mock pool connection.
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
+	if err != nil {
+		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
+	}
+	defer mock.Close()
+
+	// Creating a new repository.
+	repos := postgres.NewAuditRepository(mock)
+
+	events := []domain.AuditEvent{
+		{
+			Id:        ksuid.New(),
+			Kind:      domain.AuditSignIn,
+			Actor:     "example",
+			Subject:   "example",
+			Ip:        "0.0.0.0",
+			Outcome:   domain.AuditFailure,
+			Reason:    "User not found",
+			CreatedAt: time.Now(),
+		},
+		{
+			Id:        ksuid.New(),
+			Kind:      domain.AuditTokenRefresh,
+			Actor:     ksuid.New().String(),
+			Subject:   ksuid.New().String(),
+			SessionId: ksuid.New(),
+			Outcome:   domain.AuditSuccess,
+			CreatedAt: time.Now(),
+		},
+	}
+
+	mock.ExpectBegin()
+
+	for _, event := range events {
+		mock.ExpectExec("INSERT INTO auth_audit").
+			WithArgs(pgtype.KSUID(event.Id), event.Kind, event.Actor, event.Subject, pgtype.KSUID(event.SessionId),
+				event.Ip, event.UserAgent, event.Outcome, event.Reason, event.CreatedAt).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	}
+
+	mock.ExpectCommit()
+
+	// Creating auth audit events.
+	if err := repos.Create(context.Background(), events...); err != nil {
+		t.Errorf("error creating auth audit events: %s", err.Error())
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("error expectations: %s", err.Error())
+	}
+}
+
+// Testing getting a filtered auth audit events list page.
+func TestAuditRepository_GetList(t *testing.T) {
+	// Creating a new mock pool connection.
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
+	if err != nil {
+		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
+	}
+	defer mock.Close()
+
+	// Creating a new repository.
+	repos := postgres.NewAuditRepository(mock)
+
+	first := int32(1)
+	subject := ksuid.New().String()
+
+	want := []domain.AuditEvent{
+		{
+			Id:        ksuid.New(),
+			Kind:      domain.AuditSessionRevoke,
+			Actor:     subject,
+			Subject:   subject,
+			SessionId: ksuid.New(),
+			Outcome:   domain.AuditSuccess,
+			CreatedAt: time.Now(),
+		},
+		{
+			Id:        ksuid.New(),
+			Kind:      domain.AuditSessionRevoke,
+			Actor:     subject,
+			Subject:   subject,
+			Outcome:   domain.AuditSuccess,
+			CreatedAt: time.Now(),
+		},
+	}
+
+	rows := mock.NewRows([]string{"id", "kind", "actor", "subject", "session_id", "ip", "user_agent", "outcome",
+		"reason", "created_at"})
+
+	for _, event := range want {
+		var sessionId interface{}
+		if event.SessionId != ksuid.Nil {
+			sessionId = event.SessionId.Bytes()
+		}
+
+		rows.AddRow(event.Id.Bytes(), event.Kind, event.Actor, event.Subject, sessionId, event.Ip, event.UserAgent,
+			event.Outcome, event.Reason, event.CreatedAt)
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM auth_audit WHERE subject = (.+) AND kind = (.+) ORDER BY id ASC LIMIT").
+		WithArgs(subject, domain.AuditSessionRevoke, first+1).
+		WillReturnRows(rows)
+
+	// Getting auth audit events.
+	got, info, err := repos.GetList(context.Background(),
+		domain.AuditFilter{Subject: subject, Kind: domain.AuditSessionRevoke},
+		domain.SortOptions{First: &first})
+	if err != nil {
+		t.Fatalf("error getting auth audit events: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(got, want[:1]) {
+		t.Errorf("error auth audit events are not similar: got %v, want %v", got, want[:1])
+	}
+
+	if !info.HasNextPage || info.EndCursor != want[0].Id {
+		t.Errorf("error page info: got %+v", info)
+	}
+}
+
+// Testing deleting expired auth audit events.
+func TestAuditRepository_DeleteBefore(t *testing.T) {
+	// Creating a new mock pool connection.
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
+	if err != nil {
+		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
+	}
+	defer mock.Close()
+
+	// Creating a new repository.
+	repos := postgres.NewAuditRepository(mock)
+
+	before := time.Now().Add(-time.Hour * 24 * 90)
+
+	mock.ExpectExec("SELECT auth_audit_drop_partitions").
+		WithArgs(before).
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+
+	// Deleting expired auth audit events.
+	if err := repos.DeleteBefore(context.Background(), before); err != nil {
+		t.Errorf("error deleting auth audit events: %s", err.Error())
+	}
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/pkg/database/postgres"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Auth domain event outbox repository interface.
+type EventOutbox interface {
+	// Creating auth domain event outbox messages.
+	Create(ctx context.Context, messages ...domain.EventMessage) error
+	// Claiming due outbox messages ordered by sequence. Claimed messages are hidden from other
+	// relays for the lease duration and their attempts counter is incremented.
+	Claim(ctx context.Context, limit int32, lease time.Duration) ([]domain.EventMessage, error)
+	// Deleting a published outbox message.
+	Delete(ctx context.Context, id ksuid.KSUID) error
+	// Scheduling the next outbox message publish attempt.
+	Retry(ctx context.Context, id ksuid.KSUID, next time.Time, lastErr string) error
+}
+
+// Auth domain event outbox repository structure.
+type EventOutboxRepository struct{ psql postgres.Postgres }
+
+// Creating a new auth domain event outbox postgres repository.
+func NewEventOutboxRepository(psql postgres.Postgres) *EventOutboxRepository {
+	return &EventOutboxRepository{psql: psql}
+}
+
+// Creating auth domain event outbox messages.
+func (r *EventOutboxRepository) Create(ctx context.Context, messages ...domain.EventMessage) error {
+	// Starting a new transaction.
+	tx, err := r.psql.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, message := range messages {
+		if err := insertEventMessage(ctx, tx, message); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Inserting a new auth domain event outbox message.
+func insertEventMessage(ctx context.Context, exec executor, message domain.EventMessage) error {
+	query := "INSERT INTO event_outbox (id, subject, payload) VALUES ($1, $2, $3)"
+	_, err := exec.Exec(ctx, query, postgres.KSUID(message.Id), message.Subject, message.Payload)
+
+	return err
+}
+
+// Claiming due outbox messages ordered by sequence.
+func (r *EventOutboxRepository) Claim(ctx context.Context, limit int32, lease time.Duration) ([]domain.EventMessage, error) {
+	query := `UPDATE event_outbox SET attempts=attempts+1, next_at=$1
+		WHERE id IN (
+			SELECT id FROM event_outbox WHERE next_at <= now()
+			ORDER BY seq LIMIT $2 FOR UPDATE SKIP LOCKED
+		) RETURNING id, subject, payload, seq, attempts, created_at`
+
+	rows, err := r.psql.Query(ctx, query, time.Now().Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []domain.EventMessage
+
+	// Scanning query rows.
+	for rows.Next() {
+		var message domain.EventMessage
+
+		// Scanning query row.
+		if err := rows.Scan((*postgres.KSUID)(&message.Id), &message.Subject, &message.Payload,
+			&message.Sequence, &message.Attempts, &message.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Returned rows are not ordered by the update.
+	sort.Slice(messages, func(i, j int) bool { return messages[i].Sequence < messages[j].Sequence })
+
+	return messages, nil
+}
+
+// Deleting a published outbox message.
+func (r *EventOutboxRepository) Delete(ctx context.Context, id ksuid.KSUID) error {
+	_, err := r.psql.Exec(ctx, "DELETE FROM event_outbox WHERE id=$1", postgres.KSUID(id))
+	return err
+}
+
+// Scheduling the next outbox message publish attempt.
+func (r *EventOutboxRepository) Retry(ctx context.Context, id ksuid.KSUID, next time.Time, lastErr string) error {
+	query := "UPDATE event_outbox SET next_at=$1, last_error=$2 WHERE id=$3"
+	_, err := r.psql.Exec(ctx, query, next, lastErr, postgres.KSUID(id))
+
+	return err
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package postgres_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	pgtype "github.com/durudex/durudex-auth-service/pkg/database/postgres"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/segmentio/ksuid"
+)
+
+// Testing creating auth domain event outbox messages.
+func TestEventOutboxRepository_Create(t *testing.T) {
+	// Creating a new mock pool connection.
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
+	if err != nil {
+		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
+	}
+	defer mock.Close()
+
+	// Creating a new repository.
+	repos := postgres.NewEventOutboxRepository(mock)
+
+	messages := []domain.EventMessage{
+		{Id: ksuid.New(), Subject: "durudex.auth.v1.user_signed_up", Payload: []byte("user")},
+		{Id: ksuid.New(), Subject: "durudex.auth.v1.session_created", Payload: []byte("session")},
+	}
+
+	mock.ExpectBegin()
+
+	for _, message := range messages {
+		mock.ExpectExec("INSERT INTO event_outbox").
+			WithArgs(pgtype.KSUID(message.Id), message.Subject, message.Payload).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	}
+
+	mock.ExpectCommit()
+
+	// Creating auth domain event outbox messages.
+	if err := repos.Create(context.Background(), messages...); err != nil {
+		t.Errorf("error creating outbox messages: %s", err.Error())
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("error expectations: %s", err.Error())
+	}
+}
+
+// Testing claiming due auth domain event outbox messages.
+func TestEventOutboxRepository_Claim(t *testing.T) {
+	// Creating a new mock pool connection.
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
+	if err != nil {
+		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
+	}
+	defer mock.Close()
+
+	// Creating a new repository.
+	repos := postgres.NewEventOutboxRepository(mock)
+
+	want := []domain.EventMessage{
+		{
+			Id:        ksuid.New(),
+			Subject:   "durudex.auth.v1.user_signed_up",
+			Payload:   []byte("user"),
+			Sequence:  1,
+			Attempts:  1,
+			CreatedAt: time.Now(),
+		},
+		{
+			Id:        ksuid.New(),
+			Subject:   "durudex.auth.v1.session_created",
+			Payload:   []byte("session"),
+			Sequence:  2,
+			Attempts:  3,
+			CreatedAt: time.Now(),
+		},
+	}
+
+	rows := mock.NewRows([]string{"id", "subject", "payload", "seq", "attempts", "created_at"})
+
+	// Returning rows in reverse order.
+	for i := len(want) - 1; i >= 0; i-- {
+		rows.AddRow(want[i].Id.Bytes(), want[i].Subject, want[i].Payload, want[i].Sequence, want[i].Attempts,
+			want[i].CreatedAt)
+	}
+
+	mock.ExpectQuery("UPDATE event_outbox SET (.+) RETURNING").
+		WithArgs(pgxmock.AnyArg(), int32(10)).
+		WillReturnRows(rows)
+
+	// Claiming outbox messages.
+	got, err := repos.Claim(context.Background(), 10, time.Minute)
+	if err != nil {
+		t.Fatalf("error claiming outbox messages: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("error outbox messages are not similar: got %v, want %v", got, want)
+	}
+}
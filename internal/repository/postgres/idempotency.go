@@ -0,0 +1,109 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/pkg/database/postgres"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// Idempotency key repository interface.
+type Idempotency interface {
+	// Reserving an idempotency key. Expired keys are reserved again. When the key is already
+	// reserved the stored key is returned.
+	Reserve(ctx context.Context, key domain.IdempotencyKey) (domain.IdempotencyKey, bool, error)
+	// Storing response of the completed request.
+	Complete(ctx context.Context, id, response []byte, expiresAt time.Time) error
+	// Releasing an idempotency key of the failed request.
+	Release(ctx context.Context, id []byte) error
+	// Deleting expired idempotency keys.
+	DeleteExpired(ctx context.Context, limit int32) (int64, error)
+}
+
+// Idempotency key repository structure.
+type IdempotencyRepository struct{ psql postgres.Postgres }
+
+// Creating a new idempotency key postgres repository.
+func NewIdempotencyRepository(psql postgres.Postgres) *IdempotencyRepository {
+	return &IdempotencyRepository{psql: psql}
+}
+
+// Reserving an idempotency key.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, key domain.IdempotencyKey) (domain.IdempotencyKey, bool, error) {
+	query := `INSERT INTO idempotency_key (id, fingerprint, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET fingerprint=EXCLUDED.fingerprint, response=NULL,
+		expires_at=EXCLUDED.expires_at, created_at=now() WHERE idempotency_key.expires_at < $4
+		RETURNING id`
+
+	var id []byte
+
+	err := r.psql.QueryRow(ctx, query, key.Id, key.Fingerprint, key.ExpiresAt, time.Now()).Scan(&id)
+	if err == nil {
+		return key, true, nil
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return domain.IdempotencyKey{}, false, err
+	}
+
+	// Getting the key reserved by another request.
+	query = "SELECT id, fingerprint, response, expires_at FROM idempotency_key WHERE id=$1"
+
+	var stored domain.IdempotencyKey
+
+	if err := r.psql.QueryRow(ctx, query, key.Id).Scan(&stored.Id, &stored.Fingerprint,
+		&stored.Response, &stored.ExpiresAt); err != nil {
+		return domain.IdempotencyKey{}, false, err
+	}
+
+	return stored, false, nil
+}
+
+// Storing response of the completed request.
+func (r *IdempotencyRepository) Complete(ctx context.Context, id, response []byte, expiresAt time.Time) error {
+	query := "UPDATE idempotency_key SET response=$1, expires_at=$2 WHERE id=$3"
+	_, err := r.psql.Exec(ctx, query, response, expiresAt, id)
+
+	return err
+}
+
+// Releasing an idempotency key of the failed request.
+func (r *IdempotencyRepository) Release(ctx context.Context, id []byte) error {
+	query := "DELETE FROM idempotency_key WHERE id=$1 AND response IS NULL"
+	_, err := r.psql.Exec(ctx, query, id)
+
+	return err
+}
+
+// Deleting expired idempotency keys.
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, limit int32) (int64, error) {
+	query := `DELETE FROM idempotency_key WHERE id IN (
+		SELECT id FROM idempotency_key WHERE expires_at < $1 LIMIT $2
+	)`
+
+	tag, err := r.psql.Exec(ctx, query, time.Now(), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
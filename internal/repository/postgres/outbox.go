@@ -0,0 +1,173 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/pkg/database/postgres"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/segmentio/ksuid"
+)
+
+// Email outbox repository interface.
+type Outbox interface {
+	// Claiming due outbox emails. Claimed emails are hidden from other dispatchers for the
+	// lease duration and their attempts counter is incremented.
+	Claim(ctx context.Context, limit int32, lease time.Duration) ([]domain.Email, error)
+	// Deleting a delivered outbox email.
+	Delete(ctx context.Context, id ksuid.KSUID) error
+	// Scheduling the next outbox email delivery attempt.
+	Retry(ctx context.Context, id ksuid.KSUID, next time.Time, lastErr string) error
+	// Moving an outbox email to the dead letter.
+	Dead(ctx context.Context, id ksuid.KSUID, lastErr string) error
+}
+
+// Email outbox payload structure.
+type emailPayload struct {
+	Email    string `json:"email"`
+	Username string `json:"username,omitempty"`
+	Ip       string `json:"ip,omitempty"`
+}
+
+// Query executor shared by the pool and transactions.
+type executor interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+// Query runner shared by the pool and transactions.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+// Email outbox repository structure.
+type OutboxRepository struct{ psql postgres.Postgres }
+
+// Creating a new email outbox postgres repository.
+func NewOutboxRepository(psql postgres.Postgres) *OutboxRepository {
+	return &OutboxRepository{psql: psql}
+}
+
+// Inserting a new outbox email.
+func insertEmail(ctx context.Context, exec executor, email domain.Email) error {
+	payload, err := json.Marshal(emailPayload{Email: email.Email, Username: email.Username, Ip: email.Ip})
+	if err != nil {
+		return err
+	}
+
+	query := "INSERT INTO email_outbox (id, kind, payload) VALUES ($1, $2, $3)"
+	_, err = exec.Exec(ctx, query, postgres.KSUID(email.Id), email.Kind, payload)
+
+	return err
+}
+
+// Inserting the outbox records of the state change.
+func insertOutbox(ctx context.Context, exec executor, outbox domain.Outbox) error {
+	// Adding notification emails to the outbox.
+	for _, email := range outbox.Emails {
+		if err := insertEmail(ctx, exec, email); err != nil {
+			return err
+		}
+	}
+
+	// Adding auth domain event messages to the outbox.
+	for _, message := range outbox.Events {
+		if err := insertEventMessage(ctx, exec, message); err != nil {
+			return err
+		}
+	}
+
+	// Adding auth domain events of the webhook deliveries to the outbox.
+	for _, e := range outbox.Webhooks {
+		if err := insertWebhookEvent(ctx, exec, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Claiming due outbox emails.
+func (r *OutboxRepository) Claim(ctx context.Context, limit int32, lease time.Duration) ([]domain.Email, error) {
+	query := `UPDATE email_outbox SET attempts=attempts+1, next_at=$1
+		WHERE id IN (
+			SELECT id FROM email_outbox WHERE dead_at IS NULL AND next_at <= now()
+			ORDER BY next_at LIMIT $2 FOR UPDATE SKIP LOCKED
+		) RETURNING id, kind, payload, attempts, created_at`
+
+	rows, err := r.psql.Query(ctx, query, time.Now().Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var emails []domain.Email
+
+	// Scanning query rows.
+	for rows.Next() {
+		var (
+			email   domain.Email
+			payload []byte
+		)
+
+		// Scanning query row.
+		if err := rows.Scan((*postgres.KSUID)(&email.Id), &email.Kind, &payload, &email.Attempts, &email.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		var p emailPayload
+
+		// Decoding email payload.
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, err
+		}
+
+		email.Email, email.Username, email.Ip = p.Email, p.Username, p.Ip
+
+		emails = append(emails, email)
+	}
+
+	return emails, rows.Err()
+}
+
+// Deleting a delivered outbox email.
+func (r *OutboxRepository) Delete(ctx context.Context, id ksuid.KSUID) error {
+	_, err := r.psql.Exec(ctx, "DELETE FROM email_outbox WHERE id=$1", postgres.KSUID(id))
+	return err
+}
+
+// Scheduling the next outbox email delivery attempt.
+func (r *OutboxRepository) Retry(ctx context.Context, id ksuid.KSUID, next time.Time, lastErr string) error {
+	query := "UPDATE email_outbox SET next_at=$1, last_error=$2 WHERE id=$3"
+	_, err := r.psql.Exec(ctx, query, next, lastErr, postgres.KSUID(id))
+
+	return err
+}
+
+// Moving an outbox email to the dead letter.
+func (r *OutboxRepository) Dead(ctx context.Context, id ksuid.KSUID, lastErr string) error {
+	query := "UPDATE email_outbox SET dead_at=now(), last_error=$1 WHERE id=$2"
+	_, err := r.psql.Exec(ctx, query, lastErr, postgres.KSUID(id))
+
+	return err
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	pgtype "github.com/durudex/durudex-auth-service/pkg/database/postgres"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/segmentio/ksuid"
+)
+
+// Testing claiming due outbox emails.
+func TestOutboxRepository_Claim(t *testing.T) {
+	// Creating a new mock pool connection.
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
+	if err != nil {
+		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
+	}
+	defer mock.Close()
+
+	// Creating a new repository.
+	repos := postgres.NewOutboxRepository(mock)
+
+	want := []domain.Email{
+		{
+			Id:        ksuid.New(),
+			Kind:      domain.EmailUserLoggedIn,
+			Email:     "example@durudex.com",
+			Ip:        "0.0.0.0",
+			Attempts:  1,
+			CreatedAt: time.Now(),
+		},
+	}
+
+	mock.ExpectQuery("UPDATE email_outbox SET (.+) RETURNING").
+		WithArgs(pgxmock.AnyArg(), int32(10)).
+		WillReturnRows(mock.NewRows([]string{"id", "kind", "payload", "attempts", "created_at"}).
+			AddRow(want[0].Id.Bytes(), want[0].Kind, []byte(`{"email":"example@durudex.com","ip":"0.0.0.0"}`),
+				want[0].Attempts, want[0].CreatedAt))
+
+	// Claiming outbox emails.
+	got, err := repos.Claim(context.Background(), 10, time.Minute)
+	if err != nil {
+		t.Fatalf("error claiming outbox emails: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("error outbox emails are not similar: got %v, want %v", got, want)
+	}
+}
+
+// Testing deleting a delivered outbox email.
+func TestOutboxRepository_Delete(t *testing.T) {
+	// Creating a new mock pool connection.
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
+	if err != nil {
+		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
+	}
+	defer mock.Close()
+
+	// Creating a new repository.
+	repos := postgres.NewOutboxRepository(mock)
+
+	id := ksuid.New()
+
+	mock.ExpectExec("DELETE FROM email_outbox").
+		WithArgs(pgtype.KSUID(id)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	if err := repos.Delete(context.Background(), id); err != nil {
+		t.Fatalf("error deleting outbox email: %s", err.Error())
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("error mock expectations: %s", err.Error())
+	}
+}
@@ -26,12 +26,20 @@ import (
 
 // Postgres repository structure.
 type PostgresRepository struct {
-	Session Session
-	pool    postgres.Postgres
+	Session         Session
+	Outbox          Outbox
+	Saga            Saga
+	Idempotency     Idempotency
+	Audit           Audit
+	EventOutbox     EventOutbox
+	Webhook         Webhook
+	WebhookDelivery WebhookDelivery
+	Risk            Risk
+	pool            postgres.Postgres
 }
 
 // Creating a new postgres repository.
-func NewPostgresRepository(cfg config.PostgresConfig) *PostgresRepository {
+func NewPostgresRepository(cfg config.PostgresConfig) (*PostgresRepository, error) {
 	log.Debug().Msg("Creating a new postgres repository...")
 
 	// Creating a new postgres pool connection.
@@ -41,10 +49,21 @@ func NewPostgresRepository(cfg config.PostgresConfig) *PostgresRepository {
 		MinConns: cfg.MinConns,
 	})
 	if err != nil {
-		log.Fatal().Err(err).Msg("failed to create postgres pool connection")
+		return nil, err
 	}
 
-	return &PostgresRepository{Session: NewSessionRepository(pool), pool: pool}
+	return &PostgresRepository{
+		Session:         NewSessionRepository(pool),
+		Outbox:          NewOutboxRepository(pool),
+		Saga:            NewSagaRepository(pool),
+		Idempotency:     NewIdempotencyRepository(pool),
+		Audit:           NewAuditRepository(pool),
+		EventOutbox:     NewEventOutboxRepository(pool),
+		Webhook:         NewWebhookRepository(pool),
+		WebhookDelivery: NewWebhookDeliveryRepository(pool),
+		Risk:            NewRiskRepository(pool),
+		pool:            pool,
+	}, nil
 }
 
 // Closing postgres pool connections.
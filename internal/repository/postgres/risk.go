@@ -0,0 +1,128 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/pkg/database/postgres"
+
+	"github.com/leporo/sqlf"
+	"github.com/segmentio/ksuid"
+)
+
+// Sign in risk assessment repository interface.
+type Risk interface {
+	// Creating a sign in risk assessment.
+	Create(ctx context.Context, assessment domain.RiskAssessment) error
+	// Getting a user risk assessments list page ordered by id.
+	GetList(ctx context.Context, userId ksuid.KSUID, sort domain.SortOptions) ([]domain.RiskAssessment, domain.PageInfo, error)
+}
+
+// Sign in risk assessment repository structure.
+type RiskRepository struct{ psql postgres.Postgres }
+
+// Creating a new sign in risk assessment postgres repository.
+func NewRiskRepository(psql postgres.Postgres) *RiskRepository {
+	return &RiskRepository{psql: psql}
+}
+
+// Creating a sign in risk assessment.
+func (r *RiskRepository) Create(ctx context.Context, assessment domain.RiskAssessment) error {
+	signals, err := json.Marshal(riskSignals(assessment.Signals))
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO risk_assessment (id, user_id, session_id, ip, user_agent, score, decision, signals,
+		created_at) VALUES ($1, $2, $3, NULLIF($4, '')::inet, $5, $6, $7, $8, $9)`
+	_, err = r.psql.Exec(ctx, query, postgres.KSUID(assessment.Id), postgres.KSUID(assessment.UserId),
+		postgres.KSUID(assessment.SessionId), assessment.Ip, assessment.UserAgent, assessment.Score,
+		assessment.Decision, string(signals), assessment.CreatedAt)
+
+	return err
+}
+
+// Getting a user risk assessments list page ordered by id.
+func (r *RiskRepository) GetList(ctx context.Context, userId ksuid.KSUID, sort domain.SortOptions) ([]domain.RiskAssessment, domain.PageInfo, error) {
+	qb := sqlf.PostgreSQL.Select(`id, user_id, session_id, COALESCE(host(ip), ''), user_agent, score, decision,
+		signals, created_at`).From("risk_assessment").Where("user_id = ?", postgres.KSUID(userId))
+
+	// Added before sort option.
+	if sort.Before != ksuid.Nil {
+		qb.Where("id < ?", postgres.KSUID(sort.Before))
+	}
+	// Added after sort option.
+	if sort.After != ksuid.Nil {
+		qb.Where("id > ?", postgres.KSUID(sort.After))
+	}
+
+	// Added first or last sort option, one extra row is fetched to check the next page.
+	if sort.First != nil {
+		qb.OrderBy("id ASC").Limit(sort.Limit())
+	} else if sort.Last != nil {
+		qb.OrderBy("id DESC").Limit(sort.Limit())
+	}
+
+	rows, err := r.psql.Query(ctx, qb.String(), qb.Args()...)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+	defer rows.Close()
+
+	var assessments []domain.RiskAssessment
+
+	// Scanning query rows.
+	for rows.Next() {
+		var (
+			assessment domain.RiskAssessment
+			signals    []byte
+		)
+
+		// Scanning query row.
+		if err := rows.Scan((*postgres.KSUID)(&assessment.Id), (*postgres.KSUID)(&assessment.UserId),
+			(*postgres.KSUID)(&assessment.SessionId), &assessment.Ip, &assessment.UserAgent, &assessment.Score,
+			&assessment.Decision, &signals, &assessment.CreatedAt); err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+
+		if err := json.Unmarshal(signals, &assessment.Signals); err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+
+		assessments = append(assessments, assessment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	assessments, info := domain.NewPage(assessments, sort, func(a domain.RiskAssessment) ksuid.KSUID { return a.Id })
+
+	return assessments, info, nil
+}
+
+// Getting stored risk signals, nil signals are stored as an empty array.
+func riskSignals(signals []domain.RiskSignal) []domain.RiskSignal {
+	if signals == nil {
+		return []domain.RiskSignal{}
+	}
+
+	return signals
+}
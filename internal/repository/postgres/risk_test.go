@@ -0,0 +1,134 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package postgres_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	pgtype "github.com/durudex/durudex-auth-service/pkg/database/postgres"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/segmentio/ksuid"
+)
+
+// Testing creating a sign in risk assessment.
+func TestRiskRepository_Create(t *testing.T) {
+	// Creating a new mock pool connection.
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
+	if err != nil {
+		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
+	}
+	defer mock.Close()
+
+	// Creating a new repository.
+	repos := postgres.NewRiskRepository(mock)
+
+	assessment := domain.RiskAssessment{
+		Id:        ksuid.New(),
+		UserId:    ksuid.New(),
+		SessionId: ksuid.New(),
+		Ip:        "0.0.0.0",
+		Score:     60,
+		Decision:  domain.RiskChallenge,
+		Signals:   []domain.RiskSignal{{Name: "velocity", Score: 60, Detail: "5 failed attempts"}},
+		CreatedAt: time.Now(),
+	}
+
+	mock.ExpectExec("INSERT INTO risk_assessment").
+		WithArgs(pgtype.KSUID(assessment.Id), pgtype.KSUID(assessment.UserId), pgtype.KSUID(assessment.SessionId),
+			assessment.Ip, assessment.UserAgent, assessment.Score, assessment.Decision,
+			`[{"name":"velocity","score":60,"detail":"5 failed attempts"}]`, assessment.CreatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	// Creating a sign in risk assessment.
+	if err := repos.Create(context.Background(), assessment); err != nil {
+		t.Errorf("error creating a sign in risk assessment: %s", err.Error())
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("error expectations: %s", err.Error())
+	}
+}
+
+// Testing getting a user risk assessments list page.
+func TestRiskRepository_GetList(t *testing.T) {
+	// Creating a new mock pool connection.
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
+	if err != nil {
+		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
+	}
+	defer mock.Close()
+
+	// Creating a new repository.
+	repos := postgres.NewRiskRepository(mock)
+
+	last := int32(1)
+	userId := ksuid.New()
+
+	want := []domain.RiskAssessment{
+		{
+			Id:        ksuid.New(),
+			UserId:    userId,
+			SessionId: ksuid.New(),
+			Ip:        "0.0.0.0",
+			Score:     100,
+			Decision:  domain.RiskBlock,
+			Signals:   []domain.RiskSignal{{Name: "reputation", Score: 100}},
+			CreatedAt: time.Now(),
+		},
+		{
+			Id:        ksuid.New(),
+			UserId:    userId,
+			SessionId: ksuid.New(),
+			Ip:        "0.0.0.0",
+			Decision:  domain.RiskAllow,
+			Signals:   []domain.RiskSignal{},
+			CreatedAt: time.Now(),
+		},
+	}
+
+	rows := mock.NewRows([]string{"id", "user_id", "session_id", "ip", "user_agent", "score", "decision",
+		"signals", "created_at"})
+
+	rows.AddRow(want[0].Id.Bytes(), userId.Bytes(), want[0].SessionId.Bytes(), want[0].Ip, want[0].UserAgent,
+		want[0].Score, want[0].Decision, []byte(`[{"name":"reputation","score":100}]`), want[0].CreatedAt)
+	rows.AddRow(want[1].Id.Bytes(), userId.Bytes(), want[1].SessionId.Bytes(), want[1].Ip, want[1].UserAgent,
+		want[1].Score, want[1].Decision, []byte(`[]`), want[1].CreatedAt)
+
+	mock.ExpectQuery("SELECT (.+) FROM risk_assessment WHERE user_id = (.+) ORDER BY id DESC LIMIT").
+		WithArgs(pgtype.KSUID(userId), last+1).
+		WillReturnRows(rows)
+
+	// Getting user risk assessments.
+	got, info, err := repos.GetList(context.Background(), userId, domain.SortOptions{Last: &last})
+	if err != nil {
+		t.Fatalf("error getting risk assessments: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(got, want[:1]) {
+		t.Errorf("error risk assessments are not similar: got %v, want %v", got, want[:1])
+	}
+
+	if !info.HasPreviousPage || info.StartCursor != want[0].Id {
+		t.Errorf("error page info: got %+v", info)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/pkg/database/postgres"
+)
+
+// Sign up saga repository interface.
+type Saga interface {
+	// Creating a new sign up saga.
+	Create(ctx context.Context, saga domain.SignUpSaga) error
+	// Updating a sign up saga step state.
+	Update(ctx context.Context, saga domain.SignUpSaga) error
+	// Finishing a sign up saga. Session, if set, and outbox records are inserted in the same
+	// transaction as the final step.
+	Finish(ctx context.Context, saga domain.SignUpSaga, session *domain.UserSession, outbox domain.Outbox) error
+	// Claiming sign up sagas in progress not updated since the time. Claimed sagas are hidden
+	// from other workers for the lease duration.
+	ClaimStale(ctx context.Context, before time.Time, limit int32, lease time.Duration) ([]domain.SignUpSaga, error)
+}
+
+// Sign up saga repository structure.
+type SagaRepository struct{ psql postgres.Postgres }
+
+// Creating a new sign up saga postgres repository.
+func NewSagaRepository(psql postgres.Postgres) *SagaRepository {
+	return &SagaRepository{psql: psql}
+}
+
+// Creating a new sign up saga.
+func (r *SagaRepository) Create(ctx context.Context, saga domain.SignUpSaga) error {
+	query := "INSERT INTO sign_up_saga (id, step, email, username, ip) VALUES ($1, $2, $3, $4, $5)"
+	_, err := r.psql.Exec(ctx, query, saga.Id, saga.Step, saga.Email, saga.Username, saga.Ip)
+
+	return err
+}
+
+// Updating a sign up saga step state.
+func (r *SagaRepository) Update(ctx context.Context, saga domain.SignUpSaga) error {
+	return updateSaga(ctx, r.psql, saga)
+}
+
+// Updating a sign up saga step state with executor.
+func updateSaga(ctx context.Context, exec executor, saga domain.SignUpSaga) error {
+	query := `UPDATE sign_up_saga SET step=$1, user_id=$2, attempts=$3, last_error=NULLIF($4, ''),
+		updated_at=now() WHERE id=$5`
+	_, err := exec.Exec(ctx, query, saga.Step, saga.UserId, saga.Attempts, saga.LastError, saga.Id)
+
+	return err
+}
+
+// Finishing a sign up saga.
+func (r *SagaRepository) Finish(ctx context.Context, saga domain.SignUpSaga, session *domain.UserSession, outbox domain.Outbox) error {
+	// Starting a new transaction.
+	tx, err := r.psql.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Inserting a new user session.
+	if session != nil {
+		if err := insertSession(ctx, tx, *session); err != nil {
+			return err
+		}
+	}
+
+	if err := insertOutbox(ctx, tx, outbox); err != nil {
+		return err
+	}
+
+	if err := updateSaga(ctx, tx, saga); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Claiming stale sign up sagas in progress.
+func (r *SagaRepository) ClaimStale(ctx context.Context, before time.Time, limit int32, lease time.Duration) ([]domain.SignUpSaga, error) {
+	query := `UPDATE sign_up_saga SET updated_at=$1
+		WHERE id IN (
+			SELECT id FROM sign_up_saga
+			WHERE step NOT IN ('completed', 'compensated', 'failed', 'unresolved') AND updated_at < $2
+			ORDER BY updated_at LIMIT $3 FOR UPDATE SKIP LOCKED
+		) RETURNING id, step, user_id, email, username, host(ip), attempts, COALESCE(last_error, ''), updated_at`
+
+	// Claimed sagas are stale again after the lease.
+	rows, err := r.psql.Query(ctx, query, before.Add(lease), before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sagas []domain.SignUpSaga
+
+	// Scanning query rows.
+	for rows.Next() {
+		var saga domain.SignUpSaga
+
+		// Scanning query row.
+		if err := rows.Scan(&saga.Id, &saga.Step, &saga.UserId, &saga.Email, &saga.Username, &saga.Ip,
+			&saga.Attempts, &saga.LastError, &saga.UpdatedAt); err != nil {
+			return nil, err
+		}
+
+		sagas = append(sagas, saga)
+	}
+
+	return sagas, rows.Err()
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	pgtype "github.com/durudex/durudex-auth-service/pkg/database/postgres"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/segmentio/ksuid"
+)
+
+// Testing finishing a sign up saga.
+func TestSagaRepository_Finish(t *testing.T) {
+	// Creating a new mock pool connection.
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
+	if err != nil {
+		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
+	}
+	defer mock.Close()
+
+	// Testing args.
+	type args struct {
+		saga    domain.SignUpSaga
+		session *domain.UserSession
+		outbox  domain.Outbox
+	}
+
+	// Test behavior.
+	type mockBehavior func(args args)
+
+	// Creating a new repository.
+	repos := postgres.NewSagaRepository(mock)
+
+	// Tests structures.
+	tests := []struct {
+		name         string
+		args         args
+		wantErr      bool
+		mockBehavior mockBehavior
+	}{
+		{
+			name: "Completed",
+			args: args{
+				saga: domain.SignUpSaga{Id: ksuid.New(), Step: domain.SagaCompleted, UserId: ksuid.New()},
+				session: &domain.UserSession{
+					Id:        ksuid.New(),
+					UserId:    ksuid.New(),
+					Payload:   "0000000000000000000000000000000000000000000000000000000000000000",
+					Ip:        "0.0.0.0",
+					ExpiresIn: time.Now(),
+				},
+				outbox: domain.Outbox{
+					Emails: []domain.Email{{Id: ksuid.New(), Kind: domain.EmailUserRegister, Email: "example@durudex.com"}},
+					Events: []domain.EventMessage{
+						{Id: ksuid.New(), Subject: "durudex.auth.v1.user_signed_up", Payload: []byte("user")},
+					},
+				},
+			},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO user_session").
+					WithArgs(pgtype.KSUID(args.session.Id), pgtype.KSUID(args.session.UserId), make([]byte, 32),
+						args.session.Ip, args.session.ExpiresIn, args.session.DeviceType, args.session.DeviceName,
+						args.session.Country, args.session.City, args.session.AuthLevel, []string{}, args.session.AuthTime).
+					WillReturnResult(pgxmock.NewResult("", 1))
+				mock.ExpectExec("INSERT INTO email_outbox").
+					WithArgs(pgtype.KSUID(args.outbox.Emails[0].Id), args.outbox.Emails[0].Kind,
+						[]byte(`{"email":"example@durudex.com"}`)).
+					WillReturnResult(pgxmock.NewResult("", 1))
+				mock.ExpectExec("INSERT INTO event_outbox").
+					WithArgs(pgtype.KSUID(args.outbox.Events[0].Id), args.outbox.Events[0].Subject,
+						args.outbox.Events[0].Payload).
+					WillReturnResult(pgxmock.NewResult("", 1))
+				mock.ExpectExec("UPDATE sign_up_saga").
+					WithArgs(args.saga.Step, args.saga.UserId, args.saga.Attempts, args.saga.LastError, args.saga.Id).
+					WillReturnResult(pgxmock.NewResult("", 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Compensated without session",
+			args: args{
+				saga: domain.SignUpSaga{Id: ksuid.New(), Step: domain.SagaCompensated, UserId: ksuid.New()},
+			},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE sign_up_saga").
+					WithArgs(args.saga.Step, args.saga.UserId, args.saga.Attempts, args.saga.LastError, args.saga.Id).
+					WillReturnResult(pgxmock.NewResult("", 1))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior(tt.args)
+
+			// Finishing a sign up saga.
+			err := repos.Finish(context.Background(), tt.args.saga, tt.args.session, tt.args.outbox)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error finishing a sign up saga: %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("error mock expectations: %s", err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/durudex/durudex-auth-service/schema"
+
+	"github.com/pashagolub/pgxmock"
+	pg "github.com/pganalyze/pg_query_go/v2"
+)
+
+// Schema catalog with table columns built from the up migrations.
+var catalog struct {
+	once   sync.Once
+	tables map[string]map[string]struct{}
+	err    error
+}
+
+// Query matcher parsing the actual query and checking it against the migrated schema before
+// matching the expected regexp.
+var schemaMatcher = pgxmock.QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
+	if err := checkQuery(actualSQL); err != nil {
+		return err
+	}
+
+	return pgxmock.QueryMatcherRegexp.Match(expectedSQL, actualSQL)
+})
+
+// Testing every schema migration is a valid postgres SQL.
+func TestSchema(t *testing.T) {
+	files, err := fs.Glob(schema.FS, "*.sql")
+	if err != nil {
+		t.Fatalf("error getting migrations: %s", err.Error())
+	}
+
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+			sql, err := fs.ReadFile(schema.FS, file)
+			if err != nil {
+				t.Fatalf("error reading migration: %s", err.Error())
+			}
+
+			tree, err := pg.Parse(string(sql))
+			if err != nil {
+				t.Fatalf("error parsing migration: %s", err.Error())
+			}
+
+			// Parsing PL/pgSQL function bodies.
+			for _, stmt := range tree.Stmts {
+				if stmt.Stmt.GetCreateFunctionStmt() == nil {
+					continue
+				}
+
+				body := string(sql)[stmt.StmtLocation : stmt.StmtLocation+stmt.StmtLen]
+				if _, err := pg.ParsePlPgSqlToJSON(body); err != nil {
+					t.Errorf("error parsing function: %s", err.Error())
+				}
+			}
+		})
+	}
+
+	if err := loadCatalog(); err != nil {
+		t.Fatalf("error loading schema catalog: %s", err.Error())
+	}
+}
+
+// Loading schema catalog from the up migrations in version order.
+func loadCatalog() error {
+	catalog.once.Do(func() {
+		catalog.tables = make(map[string]map[string]struct{})
+
+		files, err := fs.Glob(schema.FS, "*.up.sql")
+		if err != nil {
+			catalog.err = err
+			return
+		}
+
+		for _, file := range files {
+			sql, err := fs.ReadFile(schema.FS, file)
+			if err != nil {
+				catalog.err = err
+				return
+			}
+
+			tree, err := pg.Parse(string(sql))
+			if err != nil {
+				catalog.err = fmt.Errorf("%s: %w", file, err)
+				return
+			}
+
+			for _, stmt := range tree.Stmts {
+				applyStmt(stmt.Stmt)
+			}
+		}
+	})
+
+	return catalog.err
+}
+
+// Applying a migration statement to the schema catalog.
+func applyStmt(stmt *pg.Node) {
+	if create := stmt.GetCreateStmt(); create != nil {
+		columns := make(map[string]struct{}, len(create.TableElts))
+
+		for _, elt := range create.TableElts {
+			if def := elt.GetColumnDef(); def != nil {
+				columns[def.Colname] = struct{}{}
+			}
+		}
+
+		catalog.tables[create.Relation.Relname] = columns
+	}
+
+	if alter := stmt.GetAlterTableStmt(); alter != nil {
+		columns := catalog.tables[alter.Relation.Relname]
+
+		for _, cmd := range alter.Cmds {
+			switch c := cmd.GetAlterTableCmd(); c.Subtype {
+			case pg.AlterTableType_AT_AddColumn:
+				columns[c.Def.GetColumnDef().Colname] = struct{}{}
+			case pg.AlterTableType_AT_DropColumn:
+				delete(columns, c.Name)
+			}
+		}
+	}
+
+	if drop := stmt.GetDropStmt(); drop != nil && drop.RemoveType == pg.ObjectType_OBJECT_TABLE {
+		for _, object := range drop.Objects {
+			names := object.GetList().GetItems()
+			delete(catalog.tables, names[len(names)-1].GetString_().Str)
+		}
+	}
+}
+
+// Query references collected from the parse tree.
+type queryRefs struct {
+	// Referenced tables by name and alias.
+	tables map[string]string
+	// Referenced columns with optional table qualifier.
+	columns [][2]string
+	// Number of "?" placeholders not supported by postgres.
+	placeholders int
+}
+
+// Checking the query is a valid postgres SQL referencing existing tables and columns.
+func checkQuery(sql string) error {
+	if err := loadCatalog(); err != nil {
+		return err
+	}
+
+	tree, err := pg.ParseToJSON(sql)
+	if err != nil {
+		return fmt.Errorf("invalid query %q: %w", sql, err)
+	}
+
+	var node interface{}
+	if err := json.Unmarshal([]byte(tree), &node); err != nil {
+		return err
+	}
+
+	refs := queryRefs{tables: make(map[string]string)}
+	refs.walk(node)
+
+	if refs.placeholders != 0 {
+		return fmt.Errorf("query %q: \"?\" placeholders are not supported", sql)
+	}
+
+	for alias, table := range refs.tables {
+		if _, ok := catalog.tables[table]; !ok {
+			return fmt.Errorf("query %q: table %s (%s) does not exist", sql, table, alias)
+		}
+	}
+
+	for _, column := range refs.columns {
+		if !refs.exists(column[0], column[1]) {
+			return fmt.Errorf("query %q: column %s does not exist", sql, strings.TrimPrefix(column[0]+"."+column[1], "."))
+		}
+	}
+
+	return nil
+}
+
+// Walking the JSON parse tree.
+func (r *queryRefs) walk(node interface{}) {
+	switch n := node.(type) {
+	case []interface{}:
+		for _, v := range n {
+			r.walk(v)
+		}
+	case map[string]interface{}:
+		// Getting range variable.
+		if relname, ok := n["relname"].(string); ok {
+			r.tables[relname] = relname
+
+			if alias, ok := n["alias"].(map[string]interface{}); ok {
+				r.tables[alias["aliasname"].(string)] = relname
+			}
+		}
+
+		// Getting "?" placeholder, parsed as parameter without number.
+		if ref, ok := n["ParamRef"].(map[string]interface{}); ok && ref["number"] == nil {
+			r.placeholders++
+		}
+
+		// Getting column reference.
+		if ref, ok := n["ColumnRef"].(map[string]interface{}); ok {
+			var names []string
+
+			for _, field := range ref["fields"].([]interface{}) {
+				if s, ok := field.(map[string]interface{})["String"]; ok {
+					names = append(names, s.(map[string]interface{})["str"].(string))
+				}
+			}
+
+			switch len(names) {
+			case 1:
+				r.columns = append(r.columns, [2]string{"", names[0]})
+			case 2:
+				r.columns = append(r.columns, [2]string{names[0], names[1]})
+			}
+		}
+
+		// Getting inserted columns and conflict target.
+		if stmt, ok := n["InsertStmt"].(map[string]interface{}); ok {
+			table := stmt["relation"].(map[string]interface{})["relname"].(string)
+			r.tables["excluded"] = table
+
+			r.assign(table, stmt["cols"], "ResTarget")
+
+			if clause, ok := stmt["onConflictClause"].(map[string]interface{}); ok {
+				r.assign(table, clause["targetList"], "ResTarget")
+
+				if infer, ok := clause["infer"].(map[string]interface{}); ok {
+					r.assign(table, infer["indexElems"], "IndexElem")
+				}
+			}
+		}
+
+		// Getting updated columns.
+		if stmt, ok := n["UpdateStmt"].(map[string]interface{}); ok {
+			r.assign(stmt["relation"].(map[string]interface{})["relname"].(string), stmt["targetList"], "ResTarget")
+		}
+
+		for _, v := range n {
+			r.walk(v)
+		}
+	}
+}
+
+// Adding named list items as the table columns.
+func (r *queryRefs) assign(table string, list interface{}, key string) {
+	items, _ := list.([]interface{})
+
+	for _, item := range items {
+		if elem, ok := item.(map[string]interface{})[key].(map[string]interface{}); ok {
+			if name, ok := elem["name"].(string); ok {
+				r.columns = append(r.columns, [2]string{table, name})
+			}
+		}
+	}
+}
+
+// Checking the column exists in the qualified table or in any referenced table.
+func (r *queryRefs) exists(qualifier, column string) bool {
+	if qualifier != "" {
+		_, ok := catalog.tables[r.tables[qualifier]][column]
+		return ok
+	}
+
+	for _, table := range r.tables {
+		if _, ok := catalog.tables[table][column]; ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -19,7 +19,9 @@ package postgres
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
+	"strings"
 
 	"github.com/durudex/durudex-auth-service/internal/domain"
 	"github.com/durudex/durudex-auth-service/pkg/database/postgres"
@@ -31,18 +33,22 @@ import (
 
 // User session repository interface.
 type Session interface {
-	// Creating a new user session.
-	Create(ctx context.Context, session domain.UserSession) error
+	// Creating a new user session. Outbox records are inserted in the same transaction.
+	Create(ctx context.Context, session domain.UserSession, outbox domain.Outbox) error
 	// Getting a user session.
 	Get(ctx context.Context, userId, id ksuid.KSUID) (domain.UserSession, error)
-	// Getting a user sessions list.
-	GetList(ctx context.Context, userId ksuid.KSUID, sort domain.SortOptions) ([]domain.UserSession, error)
-	// Deleting a user session.
-	Delete(ctx context.Context, userId, id ksuid.KSUID) error
+	// Getting a filtered user sessions list page ordered by id.
+	GetList(ctx context.Context, userId ksuid.KSUID, filter domain.SessionFilter, sort domain.SortOptions) ([]domain.UserSession, domain.PageInfo, error)
+	// Deleting a user session. Outbox records are inserted in the same transaction, a missing
+	// session or a session of another user is not found.
+	Delete(ctx context.Context, userId, id ksuid.KSUID, outbox domain.Outbox) error
 	// Getting total user session count.
 	GetTotalCount(ctx context.Context, userId ksuid.KSUID) (int32, error)
 }
 
+// Escaper of the LIKE pattern special characters.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 // User session repository structure.
 type SessionRepository struct{ psql postgres.Postgres }
 
@@ -51,23 +57,62 @@ func NewSessionRepository(psql postgres.Postgres) *SessionRepository {
 	return &SessionRepository{psql: psql}
 }
 
-// Creating a new user session.
-func (r *SessionRepository) Create(ctx context.Context, session domain.UserSession) error {
-	query := "INSERT INTO user_session (id, user_id, payload, ip, expires_in) VALUES ($1, $2, $3, $4, $5)"
-	_, err := r.psql.Exec(ctx, query, session.Id, session.UserId, session.Payload, session.Ip, session.ExpiresIn)
+// Inserting a new user session.
+func insertSession(ctx context.Context, exec executor, session domain.UserSession) error {
+	// Decoding hex session payload.
+	payload, err := hex.DecodeString(session.Payload)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO user_session (id, user_id, payload, ip, expires_in, device_type, device_name, country, city,
+		auth_level, auth_methods, auth_time) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+	_, err = exec.Exec(ctx, query, postgres.KSUID(session.Id), postgres.KSUID(session.UserId), payload,
+		session.Ip, session.ExpiresIn, session.DeviceType, session.DeviceName, session.Country, session.City,
+		session.AuthLevel, authMethods(session.AuthMethods), session.AuthTime)
 
 	return err
 }
 
+// Creating a new user session.
+func (r *SessionRepository) Create(ctx context.Context, session domain.UserSession, outbox domain.Outbox) error {
+	if outbox.Empty() {
+		return insertSession(ctx, r.psql, session)
+	}
+
+	// Starting a new transaction.
+	tx, err := r.psql.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertSession(ctx, tx, session); err != nil {
+		return err
+	}
+
+	if err := insertOutbox(ctx, tx, outbox); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
 // Getting a user session.
 func (r *SessionRepository) Get(ctx context.Context, userId, id ksuid.KSUID) (domain.UserSession, error) {
-	var session domain.UserSession
+	var (
+		session domain.UserSession
+		payload []byte
+	)
 
-	query := "SELECT payload, ip, expires_in FROM user_session WHERE user_id=$1 AND id=$2"
-	row := r.psql.QueryRow(ctx, query, userId, id)
+	query := `SELECT id, user_id, payload, ip, expires_in, device_type, device_name, country, city, auth_level,
+		auth_methods, auth_time FROM user_session WHERE user_id=$1 AND id=$2`
+	row := r.psql.QueryRow(ctx, query, postgres.KSUID(userId), postgres.KSUID(id))
 
 	// Scanning query row.
-	if err := row.Scan(&session.Payload, &session.Ip, &session.ExpiresIn); err != nil {
+	if err := row.Scan((*postgres.KSUID)(&session.Id), (*postgres.KSUID)(&session.UserId), &payload, &session.Ip,
+		&session.ExpiresIn, &session.DeviceType, &session.DeviceName, &session.Country, &session.City,
+		&session.AuthLevel, &session.AuthMethods, &session.AuthTime); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return domain.UserSession{}, &domain.Error{Code: domain.CodeNotFound, Message: "Session not found"}
 		}
@@ -75,80 +120,133 @@ func (r *SessionRepository) Get(ctx context.Context, userId, id ksuid.KSUID) (do
 		return domain.UserSession{}, err
 	}
 
+	session.Payload = hex.EncodeToString(payload)
+
 	return session, nil
 }
 
-// Getting a user sessions list.
-func (r *SessionRepository) GetList(ctx context.Context, userId ksuid.KSUID, sort domain.SortOptions) ([]domain.UserSession, error) {
-	var n int32
+// Getting stored authentication methods, the column is not nullable.
+func authMethods(methods []string) []string {
+	if methods == nil {
+		return []string{}
+	}
 
-	qb := sqlf.Select("id, ip, expires_in").From("user_session").Where("user_id = ?", userId)
+	return methods
+}
 
-	// Added first or last sort option.
-	if sort.First != nil {
-		n = *sort.First
-		qb.OrderBy("user_id ASC").Limit(*sort.First)
-	} else if sort.Last != nil {
-		n = *sort.Last
-		qb.OrderBy("user_id DESC").Limit(*sort.Last)
-	}
+// Getting a filtered user sessions list page ordered by id.
+func (r *SessionRepository) GetList(ctx context.Context, userId ksuid.KSUID, filter domain.SessionFilter, sort domain.SortOptions) ([]domain.UserSession, domain.PageInfo, error) {
+	qb := sqlf.PostgreSQL.Select("id, ip, expires_in, device_type, device_name, country, city").From("user_session").
+		Where("user_id = ?", postgres.KSUID(userId))
+
+	// Added query filter.
+	filterSessions(qb, filter)
 
 	// Added before sort option.
 	if sort.Before != ksuid.Nil {
-		qb.Where("id < ?", sort.Before)
+		qb.Where("id < ?", postgres.KSUID(sort.Before))
 	}
 	// Added after sort option.
 	if sort.After != ksuid.Nil {
-		qb.Where("id > ?", sort.After)
+		qb.Where("id > ?", postgres.KSUID(sort.After))
 	}
 
-	sessions := make([]domain.UserSession, n)
+	// Added first or last sort option, one extra row is fetched to check the next page.
+	if sort.First != nil {
+		qb.OrderBy("id ASC").Limit(sort.Limit())
+	} else if sort.Last != nil {
+		qb.OrderBy("id DESC").Limit(sort.Limit())
+	}
 
-	// Query for getting author posts by author id.
+	// Query for getting user sessions by user id.
 	rows, err := r.psql.Query(ctx, qb.String(), qb.Args()...)
 	if err != nil {
-		return nil, err
+		return nil, domain.PageInfo{}, err
 	}
 	defer rows.Close()
 
-	var i int
+	sessions := make([]domain.UserSession, 0, sort.Limit())
 
 	// Scanning query rows.
 	for rows.Next() {
 		var session domain.UserSession
 
 		// Scanning query row.
-		if err := rows.Scan(&session.Id, &session.Ip, &session.ExpiresIn); err != nil {
-			return nil, err
+		if err := rows.Scan((*postgres.KSUID)(&session.Id), &session.Ip, &session.ExpiresIn, &session.DeviceType,
+			&session.DeviceName, &session.Country, &session.City); err != nil {
+			return nil, domain.PageInfo{}, err
 		}
 
-		sessions[i] = session
-
-		i++
+		sessions = append(sessions, session)
 	}
 
 	// Check is rows error.
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, domain.PageInfo{}, err
+	}
+
+	sessions, info := domain.NewPage(sessions, sort, func(s domain.UserSession) ksuid.KSUID { return s.Id })
+
+	return sessions, info, nil
+}
+
+// Adding user sessions filter conditions to the query.
+func filterSessions(qb *sqlf.Stmt, filter domain.SessionFilter) {
+	// Added ip address or network filter.
+	if filter.Network.IsValid() {
+		qb.Where("ip <<= ?::inet", filter.Network.String())
 	}
 
-	// Check for fullness of the slice.
-	if i == int(n) {
-		return sessions, nil
+	// Added created time filters by id timestamp.
+	if after := filter.AfterId(); after != ksuid.Nil {
+		qb.Where("id >= ?", postgres.KSUID(after))
+	}
+	if before := filter.BeforeId(); before != ksuid.Nil {
+		qb.Where("id < ?", postgres.KSUID(before))
 	}
 
-	res := make([]domain.UserSession, i)
-	copy(res, sessions[:i])
+	// Added session state filter.
+	switch filter.State {
+	case domain.SessionStateActive:
+		qb.Where("expires_in > now()")
+	case domain.SessionStateExpired:
+		qb.Where("expires_in <= now()")
+	}
 
-	return res, nil
+	// Added device filters.
+	if filter.DeviceType != "" {
+		qb.Where("device_type = ?", filter.DeviceType)
+	}
+	if filter.DeviceName != "" {
+		qb.Where("device_name ILIKE ?", "%"+likeEscaper.Replace(filter.DeviceName)+"%")
+	}
 }
 
-// TODO: fix payload.
 // Deleting a user session.
-func (r *SessionRepository) Delete(ctx context.Context, userId, id ksuid.KSUID) error {
+func (r *SessionRepository) Delete(ctx context.Context, userId, id ksuid.KSUID, outbox domain.Outbox) error {
+	// Starting a new transaction.
+	tx, err := r.psql.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	// Deleting user session.
-	_, err := r.psql.Exec(ctx, "DELETE FROM user_session WHERE id=$1", id)
-	return err
+	tag, err := tx.Exec(ctx, "DELETE FROM user_session WHERE user_id=$1 AND id=$2", postgres.KSUID(userId),
+		postgres.KSUID(id))
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return &domain.Error{Code: domain.CodeNotFound, Message: "Session not found"}
+	}
+
+	if err := insertOutbox(ctx, tx, outbox); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 // Getting total user session count.
@@ -156,8 +254,8 @@ func (r *SessionRepository) GetTotalCount(ctx context.Context, userId ksuid.KSUI
 	var count int32
 
 	// Get total user session count.
-	query := "SELECT count(*) FROM user_session WHERE author_id=$1"
-	row := r.psql.QueryRow(ctx, query, userId)
+	query := "SELECT count(*) FROM user_session WHERE user_id=$1"
+	row := r.psql.QueryRow(ctx, query, postgres.KSUID(userId))
 
 	// Scanning query row.
 	if err := row.Scan(&count); err != nil {
@@ -19,13 +19,17 @@ package postgres_test
 
 import (
 	"context"
+	"net/netip"
 	"reflect"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/durudex/durudex-auth-service/internal/domain"
 	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	pgtype "github.com/durudex/durudex-auth-service/pkg/database/postgres"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/pashagolub/pgxmock"
 	"github.com/segmentio/ksuid"
 )
@@ -33,14 +37,17 @@ import (
 // Testing creating a new user session.
 func TestSessionRepository_Create(t *testing.T) {
 	// Creating a new mock pool connection.
-	mock, err := pgxmock.NewPool()
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
 	if err != nil {
 		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
 	}
 	defer mock.Close()
 
 	// Testing args.
-	type args struct{ session domain.UserSession }
+	type args struct {
+		session domain.UserSession
+		outbox  domain.Outbox
+	}
 
 	// Test behavior.
 	type mockBehavior func(args args)
@@ -58,16 +65,61 @@ func TestSessionRepository_Create(t *testing.T) {
 		{
 			name: "OK",
 			args: args{session: domain.UserSession{
-				Id:        ksuid.New(),
-				UserId:    ksuid.New(),
-				Payload:   "0000000000000000000000000000000000000000000000000000000000000000",
-				Ip:        "0.0.0.0",
-				ExpiresIn: time.Now(),
+				Id:          ksuid.New(),
+				UserId:      ksuid.New(),
+				Payload:     "0000000000000000000000000000000000000000000000000000000000000000",
+				Ip:          "0.0.0.0",
+				ExpiresIn:   time.Now(),
+				DeviceType:  "desktop",
+				DeviceName:  "Firefox on Linux",
+				Country:     "GB",
+				City:        "London",
+				AuthLevel:   2,
+				AuthMethods: []string{"pwd", "otp"},
+				AuthTime:    time.Now(),
 			}},
 			mockBehavior: func(args args) {
 				mock.ExpectExec("INSERT INTO user_session").
-					WithArgs(args.session.Id, args.session.UserId, args.session.Payload, args.session.Ip, args.session.ExpiresIn).
+					WithArgs(pgtype.KSUID(args.session.Id), pgtype.KSUID(args.session.UserId), make([]byte, 32),
+						args.session.Ip, args.session.ExpiresIn, args.session.DeviceType, args.session.DeviceName,
+						args.session.Country, args.session.City, args.session.AuthLevel, args.session.AuthMethods,
+						args.session.AuthTime).
+					WillReturnResult(pgxmock.NewResult("", 1))
+			},
+		},
+		{
+			name: "With outbox",
+			args: args{
+				session: domain.UserSession{
+					Id:        ksuid.New(),
+					UserId:    ksuid.New(),
+					Payload:   "0000000000000000000000000000000000000000000000000000000000000000",
+					Ip:        "0.0.0.0",
+					ExpiresIn: time.Now(),
+				},
+				outbox: domain.Outbox{
+					Emails: []domain.Email{{Id: ksuid.New(), Kind: domain.EmailUserLoggedIn, Email: "example@durudex.com"}},
+					Events: []domain.EventMessage{
+						{Id: ksuid.New(), Subject: "durudex.auth.v1.session_created", Payload: []byte("session")},
+					},
+				},
+			},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO user_session").
+					WithArgs(pgtype.KSUID(args.session.Id), pgtype.KSUID(args.session.UserId), make([]byte, 32),
+						args.session.Ip, args.session.ExpiresIn, args.session.DeviceType, args.session.DeviceName,
+						args.session.Country, args.session.City, args.session.AuthLevel, []string{}, args.session.AuthTime).
+					WillReturnResult(pgxmock.NewResult("", 1))
+				mock.ExpectExec("INSERT INTO email_outbox").
+					WithArgs(pgtype.KSUID(args.outbox.Emails[0].Id), args.outbox.Emails[0].Kind,
+						[]byte(`{"email":"example@durudex.com"}`)).
+					WillReturnResult(pgxmock.NewResult("", 1))
+				mock.ExpectExec("INSERT INTO event_outbox").
+					WithArgs(pgtype.KSUID(args.outbox.Events[0].Id), args.outbox.Events[0].Subject,
+						args.outbox.Events[0].Payload).
 					WillReturnResult(pgxmock.NewResult("", 1))
+				mock.ExpectCommit()
 			},
 		},
 	}
@@ -78,7 +130,7 @@ func TestSessionRepository_Create(t *testing.T) {
 			tt.mockBehavior(tt.args)
 
 			// Creating a new user session.
-			err := repos.Create(context.Background(), tt.args.session)
+			err := repos.Create(context.Background(), tt.args.session, tt.args.outbox)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error creating a new user session: %s", err.Error())
 			}
@@ -89,7 +141,7 @@ func TestSessionRepository_Create(t *testing.T) {
 // Testing getting a user session.
 func TestSessionRepository_Get(t *testing.T) {
 	// Creating a new mock pool connection.
-	mock, err := pgxmock.NewPool()
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
 	if err != nil {
 		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
 	}
@@ -98,6 +150,8 @@ func TestSessionRepository_Get(t *testing.T) {
 	// Testing args.
 	type args struct{ id, userId ksuid.KSUID }
 
+	id, userId := ksuid.New(), ksuid.New()
+
 	// Test behavior.
 	type mockBehavior func(args args, session domain.UserSession)
 
@@ -114,21 +168,43 @@ func TestSessionRepository_Get(t *testing.T) {
 	}{
 		{
 			name: "OK",
-			args: args{id: ksuid.New(), userId: ksuid.New()},
+			args: args{id: id, userId: userId},
 			want: domain.UserSession{
-				Payload:   "0000000000000000000000000000000000000000000000000000000000000000",
-				Ip:        "0.0.0.0",
-				ExpiresIn: time.Now(),
+				Id:          id,
+				UserId:      userId,
+				Payload:     "0000000000000000000000000000000000000000000000000000000000000000",
+				Ip:          "0.0.0.0",
+				ExpiresIn:   time.Now(),
+				DeviceType:  "desktop",
+				DeviceName:  "Firefox on Linux",
+				Country:     "GB",
+				City:        "London",
+				AuthLevel:   1,
+				AuthMethods: []string{"pwd"},
+				AuthTime:    time.Now(),
 			},
 			mockBehavior: func(args args, session domain.UserSession) {
-				rows := mock.NewRows([]string{"payload", "ip", "expires_in"}).AddRow(
-					session.Payload, session.Ip, session.ExpiresIn)
+				rows := mock.NewRows([]string{"id", "user_id", "payload", "ip", "expires_in", "device_type", "device_name",
+					"country", "city", "auth_level", "auth_methods", "auth_time"}).
+					AddRow(pgtype.KSUID(session.Id), pgtype.KSUID(session.UserId), make([]byte, 32), session.Ip,
+						session.ExpiresIn, session.DeviceType, session.DeviceName, session.Country, session.City,
+						session.AuthLevel, session.AuthMethods, session.AuthTime)
 
 				mock.ExpectQuery("SELECT (.+) FROM user_session").
-					WithArgs(args.userId, args.id).
+					WithArgs(pgtype.KSUID(args.userId), pgtype.KSUID(args.id)).
 					WillReturnRows(rows)
 			},
 		},
+		{
+			name:    "Not found",
+			args:    args{id: ksuid.New(), userId: ksuid.New()},
+			wantErr: true,
+			mockBehavior: func(args args, session domain.UserSession) {
+				mock.ExpectQuery("SELECT (.+) FROM user_session").
+					WithArgs(pgtype.KSUID(args.userId), pgtype.KSUID(args.id)).
+					WillReturnError(pgx.ErrNoRows)
+			},
+		},
 	}
 
 	// Conducting tests in various structures.
@@ -153,7 +229,7 @@ func TestSessionRepository_Get(t *testing.T) {
 // Testing getting a user session list.
 func TestSessionRepository_GetList(t *testing.T) {
 	// Creating a new mock pool connection.
-	mock, err := pgxmock.NewPool()
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
 	if err != nil {
 		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
 	}
@@ -162,50 +238,94 @@ func TestSessionRepository_GetList(t *testing.T) {
 	// Testing args.
 	type args struct {
 		userId ksuid.KSUID
+		filter domain.SessionFilter
 		sort   domain.SortOptions
 	}
 
 	// Test behavior.
-	type mockBehavior func(args args, want []domain.UserSession)
+	type mockBehavior func(args args, rows []domain.UserSession)
 
 	// Creating a new repository.
 	repos := postgres.NewSessionRepository(mock)
 
 	// Query filter.
-	var limit int32 = 12
+	var limit int32 = 2
+
+	// Sessions ordered by id.
+	sessions := []domain.UserSession{
+		{Id: ksuid.New(), Ip: "0.0.0.0", ExpiresIn: time.Now(), DeviceType: "mobile", DeviceName: "Chrome on Android"},
+		{Id: ksuid.New(), Ip: "0.0.0.0", ExpiresIn: time.Now(), DeviceType: "mobile", DeviceName: "Chrome on Android"},
+		{Id: ksuid.New(), Ip: "0.0.0.0", ExpiresIn: time.Now(), DeviceType: "mobile", DeviceName: "Chrome on Android"},
+	}
+	sort.Slice(sessions, func(i, j int) bool { return ksuid.Compare(sessions[i].Id, sessions[j].Id) < 0 })
 
 	// Tests structures.
 	tests := []struct {
 		name         string
 		args         args
+		rows         []domain.UserSession
 		want         []domain.UserSession
+		wantInfo     domain.PageInfo
 		wantErr      bool
 		mockBehavior mockBehavior
 	}{
 		{
-			name: "OK",
+			name: "First with next page",
 			args: args{
 				userId: ksuid.New(),
-				sort: domain.SortOptions{
-					First:  &limit,
-					Before: ksuid.New(),
-				},
+				sort:   domain.SortOptions{First: &limit, Before: ksuid.New()},
 			},
-			want: []domain.UserSession{
-				{
-					Id:        ksuid.New(),
-					Ip:        "0.0.0.0",
-					ExpiresIn: time.Now(),
+			rows: sessions,
+			want: sessions[:2],
+			wantInfo: domain.PageInfo{
+				HasNextPage: true,
+				StartCursor: sessions[0].Id,
+				EndCursor:   sessions[1].Id,
+			},
+			mockBehavior: func(args args, rows []domain.UserSession) {
+				mock.ExpectQuery("SELECT (.+) FROM user_session (.+) ORDER BY id ASC LIMIT").
+					WithArgs(pgtype.KSUID(args.userId), pgtype.KSUID(args.sort.Before), limit+1).
+					WillReturnRows(sessionRows(mock, rows))
+			},
+		},
+		{
+			name: "Last before cursor",
+			args: args{
+				userId: ksuid.New(),
+				sort:   domain.SortOptions{Last: &limit, Before: sessions[2].Id},
+			},
+			rows: []domain.UserSession{sessions[1], sessions[0]},
+			want: sessions[:2],
+			wantInfo: domain.PageInfo{
+				HasNextPage: true,
+				StartCursor: sessions[0].Id,
+				EndCursor:   sessions[1].Id,
+			},
+			mockBehavior: func(args args, rows []domain.UserSession) {
+				mock.ExpectQuery("SELECT (.+) FROM user_session (.+) ORDER BY id DESC LIMIT").
+					WithArgs(pgtype.KSUID(args.userId), pgtype.KSUID(args.sort.Before), limit+1).
+					WillReturnRows(sessionRows(mock, rows))
+			},
+		},
+		{
+			name: "Filtered",
+			args: args{
+				userId: ksuid.New(),
+				filter: domain.SessionFilter{
+					Network:    netip.MustParsePrefix("10.0.0.0/8"),
+					State:      domain.SessionStateActive,
+					DeviceType: "mobile",
+					DeviceName: "50%_android",
 				},
+				sort: domain.SortOptions{First: &limit},
 			},
-			mockBehavior: func(args args, want []domain.UserSession) {
-				rows := mock.NewRows([]string{"id", "ip", "expires_in"}).AddRow(
-					want[0].Id, want[0].Ip, want[0].ExpiresIn,
-				)
-
-				mock.ExpectQuery("SELECT (.+) FROM user_session").
-					WithArgs(args.userId, args.sort.Before, *args.sort.First).
-					WillReturnRows(rows)
+			rows:     sessions[:1],
+			want:     sessions[:1],
+			wantInfo: domain.PageInfo{StartCursor: sessions[0].Id, EndCursor: sessions[0].Id},
+			mockBehavior: func(args args, rows []domain.UserSession) {
+				mock.ExpectQuery(`SELECT (.+) FROM user_session WHERE user_id = (.+) AND ip <<= (.+) AND expires_in > now\(\) AND device_type = (.+) AND device_name ILIKE (.+) ORDER BY id ASC LIMIT`).
+					WithArgs(pgtype.KSUID(args.userId), "10.0.0.0/8", "mobile", `%50\%\_android%`, limit+1).
+					WillReturnRows(sessionRows(mock, rows))
 			},
 		},
 	}
@@ -213,26 +333,43 @@ func TestSessionRepository_GetList(t *testing.T) {
 	// Conducting tests in various structures.
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.mockBehavior(tt.args, tt.want)
+			tt.mockBehavior(tt.args, tt.rows)
 
 			// Getting a user session list.
-			got, err := repos.GetList(context.Background(), tt.args.userId, tt.args.sort)
+			got, info, err := repos.GetList(context.Background(), tt.args.userId, tt.args.filter, tt.args.sort)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("error getting user sessions: %s", err.Error())
+				t.Fatalf("error getting user sessions: %s", err.Error())
 			}
 
-			// Check for similarity of posts.
+			// Check for similarity of sessions.
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Error("error user sessions are not similar")
 			}
+
+			// Check for similarity of page info.
+			if info != tt.wantInfo {
+				t.Errorf("error page info: got %+v, want %+v", info, tt.wantInfo)
+			}
 		})
 	}
 }
 
+// Getting user session list mock rows.
+func sessionRows(mock pgxmock.PgxPoolIface, sessions []domain.UserSession) *pgxmock.Rows {
+	rows := mock.NewRows([]string{"id", "ip", "expires_in", "device_type", "device_name", "country", "city"})
+
+	for _, session := range sessions {
+		rows.AddRow(session.Id.Bytes(), session.Ip, session.ExpiresIn, session.DeviceType, session.DeviceName,
+			session.Country, session.City)
+	}
+
+	return rows
+}
+
 // Testing deleting a user session.
 func TestSessionRepository_Delete(t *testing.T) {
 	// Creating a new mock pool connection.
-	mock, err := pgxmock.NewPool()
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
 	if err != nil {
 		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
 	}
@@ -242,6 +379,7 @@ func TestSessionRepository_Delete(t *testing.T) {
 	type args struct {
 		id     ksuid.KSUID
 		userId ksuid.KSUID
+		outbox domain.Outbox
 	}
 
 	// Test behavior.
@@ -262,12 +400,39 @@ func TestSessionRepository_Delete(t *testing.T) {
 			args: args{
 				id:     ksuid.New(),
 				userId: ksuid.New(),
+				outbox: domain.Outbox{Events: []domain.EventMessage{
+					{Id: ksuid.New(), Subject: "durudex.auth.v1.session_revoked", Payload: []byte("session")},
+				}},
 			},
 			wantErr: false,
 			mockBehavior: func(args args) {
+				mock.ExpectBegin()
 				mock.ExpectExec("DELETE FROM user_session").
-					WithArgs(args.id).
+					WithArgs(pgtype.KSUID(args.userId), pgtype.KSUID(args.id)).
 					WillReturnResult(pgxmock.NewResult("", 1))
+				mock.ExpectExec("INSERT INTO event_outbox").
+					WithArgs(pgtype.KSUID(args.outbox.Events[0].Id), args.outbox.Events[0].Subject,
+						args.outbox.Events[0].Payload).
+					WillReturnResult(pgxmock.NewResult("", 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Not found",
+			args: args{
+				id:     ksuid.New(),
+				userId: ksuid.New(),
+				outbox: domain.Outbox{Events: []domain.EventMessage{
+					{Id: ksuid.New(), Subject: "durudex.auth.v1.session_revoked", Payload: []byte("session")},
+				}},
+			},
+			wantErr: true,
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM user_session").
+					WithArgs(pgtype.KSUID(args.userId), pgtype.KSUID(args.id)).
+					WillReturnResult(pgxmock.NewResult("", 0))
+				mock.ExpectRollback()
 			},
 		},
 	}
@@ -278,9 +443,13 @@ func TestSessionRepository_Delete(t *testing.T) {
 			tt.mockBehavior(tt.args)
 
 			// Deleting a user session.
-			err := repos.Delete(context.Background(), tt.args.userId, tt.args.id)
+			err := repos.Delete(context.Background(), tt.args.userId, tt.args.id, tt.args.outbox)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("error deleting user session: %s", err.Error())
+				t.Errorf("error deleting user session: %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("error mock expectations: %s", err.Error())
 			}
 		})
 	}
@@ -289,7 +458,7 @@ func TestSessionRepository_Delete(t *testing.T) {
 // Testing getting total user session count.
 func TestSessionRepository_GetTotalCount(t *testing.T) {
 	// Creating a new mock pool connection.
-	mock, err := pgxmock.NewPool()
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
 	if err != nil {
 		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
 	}
@@ -320,7 +489,7 @@ func TestSessionRepository_GetTotalCount(t *testing.T) {
 				rows := mock.NewRows([]string{"count(*)"}).AddRow(want)
 
 				mock.ExpectQuery("SELECT (.+) FROM user_session").
-					WithArgs(args.userId).
+					WithArgs(pgtype.KSUID(args.userId)).
 					WillReturnRows(rows)
 			},
 		},
@@ -0,0 +1,446 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/pkg/database/postgres"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/leporo/sqlf"
+	"github.com/segmentio/ksuid"
+)
+
+// Webhook repository interface.
+type Webhook interface {
+	// Creating a new webhook.
+	Create(ctx context.Context, webhook domain.Webhook) error
+	// Getting a webhook by id.
+	Get(ctx context.Context, id ksuid.KSUID) (domain.Webhook, error)
+	// Getting all webhooks ordered by id.
+	GetList(ctx context.Context) ([]domain.Webhook, error)
+	// Deleting a webhook with its deliveries.
+	Delete(ctx context.Context, id ksuid.KSUID) error
+	// Enabling a webhook and resetting its consecutive failures.
+	Enable(ctx context.Context, id ksuid.KSUID) error
+	// Resetting consecutive failures of the webhook after a successful delivery.
+	Succeed(ctx context.Context, id ksuid.KSUID) error
+	// Counting a failed delivery attempt of the enabled webhook. The webhook is disabled when
+	// consecutive failures reach the limit, returns true when it is disabled by this failure.
+	Fail(ctx context.Context, id ksuid.KSUID, limit int32) (bool, error)
+}
+
+// Webhook delivery repository interface.
+type WebhookDelivery interface {
+	// Creating pending webhook deliveries.
+	Create(ctx context.Context, deliveries ...domain.WebhookDelivery) error
+	// Staging auth domain events of the webhook deliveries, deliveries are created by fan out.
+	CreateEvents(ctx context.Context, events ...domain.WebhookEvent) error
+	// Creating pending deliveries of the staged auth domain events for the enabled webhooks
+	// subscribed to the event type. Fanned out events are deleted.
+	Fanout(ctx context.Context, limit int32) error
+	// Claiming due pending deliveries. Claimed deliveries are hidden from other dispatchers
+	// for the lease duration and their attempts counter is incremented.
+	Claim(ctx context.Context, limit int32, lease time.Duration) ([]domain.WebhookDelivery, error)
+	// Updating the delivery attempt result, pending delivery is retried at the next time.
+	Update(ctx context.Context, delivery domain.WebhookDelivery, next time.Time) error
+	// Getting a webhook deliveries list page ordered by id.
+	GetList(ctx context.Context, webhookId ksuid.KSUID, sort domain.SortOptions) ([]domain.WebhookDelivery, domain.PageInfo, error)
+}
+
+// Webhook not found error.
+var errWebhookNotFound = &domain.Error{Code: domain.CodeNotFound, Message: "Webhook not found"}
+
+// Webhook columns.
+const webhookColumns string = "id, url, event_types, secret, enabled, failures, created_at, disabled_at"
+
+// Webhook repository structure.
+type WebhookRepository struct{ psql postgres.Postgres }
+
+// Creating a new webhook postgres repository.
+func NewWebhookRepository(psql postgres.Postgres) *WebhookRepository {
+	return &WebhookRepository{psql: psql}
+}
+
+// Creating a new webhook.
+func (r *WebhookRepository) Create(ctx context.Context, webhook domain.Webhook) error {
+	query := `INSERT INTO webhook (id, url, event_types, secret, enabled, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.psql.Exec(ctx, query, postgres.KSUID(webhook.Id), webhook.URL, eventTypes(webhook.EventTypes),
+		webhook.Secret, webhook.Enabled, webhook.CreatedAt)
+
+	return err
+}
+
+// Getting a webhook by id.
+func (r *WebhookRepository) Get(ctx context.Context, id ksuid.KSUID) (domain.Webhook, error) {
+	query := "SELECT " + webhookColumns + " FROM webhook WHERE id=$1"
+
+	webhook, err := scanWebhook(r.psql.QueryRow(ctx, query, postgres.KSUID(id)))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Webhook{}, errWebhookNotFound
+	}
+
+	return webhook, err
+}
+
+// Getting all webhooks ordered by id.
+func (r *WebhookRepository) GetList(ctx context.Context) ([]domain.Webhook, error) {
+	return r.query(ctx, "SELECT "+webhookColumns+" FROM webhook ORDER BY id")
+}
+
+// Deleting a webhook with its deliveries.
+func (r *WebhookRepository) Delete(ctx context.Context, id ksuid.KSUID) error {
+	tag, err := r.psql.Exec(ctx, "DELETE FROM webhook WHERE id=$1", postgres.KSUID(id))
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errWebhookNotFound
+	}
+
+	return nil
+}
+
+// Enabling a webhook and resetting its consecutive failures.
+func (r *WebhookRepository) Enable(ctx context.Context, id ksuid.KSUID) error {
+	query := "UPDATE webhook SET enabled=true, failures=0, disabled_at=NULL WHERE id=$1"
+
+	tag, err := r.psql.Exec(ctx, query, postgres.KSUID(id))
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errWebhookNotFound
+	}
+
+	return nil
+}
+
+// Resetting consecutive failures of the webhook after a successful delivery.
+func (r *WebhookRepository) Succeed(ctx context.Context, id ksuid.KSUID) error {
+	_, err := r.psql.Exec(ctx, "UPDATE webhook SET failures=0 WHERE id=$1 AND failures<>0", postgres.KSUID(id))
+	return err
+}
+
+// Counting a failed delivery attempt of the enabled webhook.
+func (r *WebhookRepository) Fail(ctx context.Context, id ksuid.KSUID, limit int32) (bool, error) {
+	query := `UPDATE webhook SET failures=failures+1, enabled=failures+1<$2,
+		disabled_at=CASE WHEN failures+1>=$2 THEN now() END
+		WHERE id=$1 AND enabled RETURNING enabled`
+
+	var enabled bool
+
+	if err := r.psql.QueryRow(ctx, query, postgres.KSUID(id), limit).Scan(&enabled); err != nil {
+		// Disabled or deleted webhooks are not counted.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return !enabled, nil
+}
+
+// Querying webhooks.
+func (r *WebhookRepository) query(ctx context.Context, query string, args ...interface{}) ([]domain.Webhook, error) {
+	return queryWebhooks(ctx, r.psql, query, args...)
+}
+
+// Querying webhooks by the pool or transaction.
+func queryWebhooks(ctx context.Context, q querier, query string, args ...interface{}) ([]domain.Webhook, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []domain.Webhook
+
+	// Scanning query rows.
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// Scanning a webhook row.
+func scanWebhook(row pgx.Row) (domain.Webhook, error) {
+	var (
+		webhook    domain.Webhook
+		types      []string
+		disabledAt *time.Time
+	)
+
+	if err := row.Scan((*postgres.KSUID)(&webhook.Id), &webhook.URL, &types, &webhook.Secret, &webhook.Enabled,
+		&webhook.Failures, &webhook.CreatedAt, &disabledAt); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	webhook.EventTypes = make([]domain.EventType, len(types))
+	for i, typ := range types {
+		webhook.EventTypes[i] = domain.EventType(typ)
+	}
+
+	if disabledAt != nil {
+		webhook.DisabledAt = *disabledAt
+	}
+
+	return webhook, nil
+}
+
+// Getting stored event types.
+func eventTypes(types []domain.EventType) []string {
+	values := make([]string, len(types))
+	for i, typ := range types {
+		values[i] = string(typ)
+	}
+
+	return values
+}
+
+// Webhook delivery columns.
+const webhookDeliveryColumns string = `id, webhook_id, event_id, event_type, payload, status, attempts,
+	response_code, last_error, created_at, updated_at`
+
+// Webhook delivery repository structure.
+type WebhookDeliveryRepository struct{ psql postgres.Postgres }
+
+// Creating a new webhook delivery postgres repository.
+func NewWebhookDeliveryRepository(psql postgres.Postgres) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{psql: psql}
+}
+
+// Creating pending webhook deliveries.
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, deliveries ...domain.WebhookDelivery) error {
+	// Starting a new transaction.
+	tx, err := r.psql.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO webhook_delivery (id, webhook_id, event_id, event_type, payload)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	for _, delivery := range deliveries {
+		if _, err := tx.Exec(ctx, query, postgres.KSUID(delivery.Id), postgres.KSUID(delivery.WebhookId),
+			postgres.KSUID(delivery.EventId), delivery.EventType, delivery.Payload); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Staging auth domain events of the webhook deliveries.
+func (r *WebhookDeliveryRepository) CreateEvents(ctx context.Context, events ...domain.WebhookEvent) error {
+	// Starting a new transaction.
+	tx, err := r.psql.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, e := range events {
+		if err := insertWebhookEvent(ctx, tx, e); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Inserting a new staged auth domain event of the webhook deliveries.
+func insertWebhookEvent(ctx context.Context, exec executor, e domain.WebhookEvent) error {
+	query := "INSERT INTO webhook_event (id, event_type, payload) VALUES ($1, $2, $3)"
+	_, err := exec.Exec(ctx, query, postgres.KSUID(e.Id), e.Type, e.Payload)
+
+	return err
+}
+
+// Creating pending deliveries of the staged auth domain events. Events locked by other
+// dispatchers are skipped.
+func (r *WebhookDeliveryRepository) Fanout(ctx context.Context, limit int32) error {
+	// Starting a new transaction.
+	tx, err := r.psql.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `DELETE FROM webhook_event WHERE id IN (
+			SELECT id FROM webhook_event ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED
+		) RETURNING id, event_type, payload`
+
+	events, err := queryWebhookEvents(ctx, tx, query, limit)
+	if err != nil {
+		return err
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	webhooks, err := queryWebhooks(ctx, tx, "SELECT "+webhookColumns+" FROM webhook WHERE enabled ORDER BY id")
+	if err != nil {
+		return err
+	}
+
+	query = `INSERT INTO webhook_delivery (id, webhook_id, event_id, event_type, payload)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	for _, e := range events {
+		for _, webhook := range webhooks {
+			if !webhook.Subscribed(e.Type) {
+				continue
+			}
+
+			if _, err := tx.Exec(ctx, query, postgres.KSUID(ksuid.New()), postgres.KSUID(webhook.Id),
+				postgres.KSUID(e.Id), e.Type, e.Payload); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Querying staged auth domain events of the webhook deliveries.
+func queryWebhookEvents(ctx context.Context, q querier, query string, args ...interface{}) ([]domain.WebhookEvent, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.WebhookEvent
+
+	// Scanning query rows.
+	for rows.Next() {
+		var e domain.WebhookEvent
+
+		if err := rows.Scan((*postgres.KSUID)(&e.Id), &e.Type, &e.Payload); err != nil {
+			return nil, err
+		}
+
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+// Claiming due pending deliveries.
+func (r *WebhookDeliveryRepository) Claim(ctx context.Context, limit int32, lease time.Duration) ([]domain.WebhookDelivery, error) {
+	query := `UPDATE webhook_delivery SET attempts=attempts+1, next_at=$1, updated_at=now()
+		WHERE id IN (
+			SELECT id FROM webhook_delivery WHERE status='pending' AND next_at <= now()
+			ORDER BY next_at LIMIT $2 FOR UPDATE SKIP LOCKED
+		) RETURNING ` + webhookDeliveryColumns
+
+	return r.query(ctx, query, time.Now().Add(lease), limit)
+}
+
+// Updating the delivery attempt result.
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, delivery domain.WebhookDelivery, next time.Time) error {
+	query := `UPDATE webhook_delivery SET status=$1, response_code=$2, last_error=$3, next_at=$4, updated_at=now()
+		WHERE id=$5`
+	_, err := r.psql.Exec(ctx, query, delivery.Status, delivery.ResponseCode, delivery.LastError, next,
+		postgres.KSUID(delivery.Id))
+
+	return err
+}
+
+// Getting a webhook deliveries list page ordered by id.
+func (r *WebhookDeliveryRepository) GetList(ctx context.Context, webhookId ksuid.KSUID, sort domain.SortOptions) ([]domain.WebhookDelivery, domain.PageInfo, error) {
+	qb := sqlf.PostgreSQL.Select(webhookDeliveryColumns).From("webhook_delivery").
+		Where("webhook_id = ?", postgres.KSUID(webhookId))
+
+	// Added before sort option.
+	if sort.Before != ksuid.Nil {
+		qb.Where("id < ?", postgres.KSUID(sort.Before))
+	}
+	// Added after sort option.
+	if sort.After != ksuid.Nil {
+		qb.Where("id > ?", postgres.KSUID(sort.After))
+	}
+
+	// Added first or last sort option, one extra row is fetched to check the next page.
+	if sort.First != nil {
+		qb.OrderBy("id ASC").Limit(sort.Limit())
+	} else if sort.Last != nil {
+		qb.OrderBy("id DESC").Limit(sort.Limit())
+	}
+
+	deliveries, err := r.query(ctx, qb.String(), qb.Args()...)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	deliveries, info := domain.NewPage(deliveries, sort, func(d domain.WebhookDelivery) ksuid.KSUID { return d.Id })
+
+	return deliveries, info, nil
+}
+
+// Querying webhook deliveries.
+func (r *WebhookDeliveryRepository) query(ctx context.Context, query string, args ...interface{}) ([]domain.WebhookDelivery, error) {
+	rows, err := r.psql.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []domain.WebhookDelivery
+
+	// Scanning query rows.
+	for rows.Next() {
+		var delivery domain.WebhookDelivery
+
+		// Scanning query row.
+		if err := rows.Scan((*postgres.KSUID)(&delivery.Id), (*postgres.KSUID)(&delivery.WebhookId),
+			(*postgres.KSUID)(&delivery.EventId), &delivery.EventType, &delivery.Payload, &delivery.Status,
+			&delivery.Attempts, &delivery.ResponseCode, &delivery.LastError, &delivery.CreatedAt,
+			&delivery.UpdatedAt); err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
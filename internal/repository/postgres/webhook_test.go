@@ -0,0 +1,73 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	pgtype "github.com/durudex/durudex-auth-service/pkg/database/postgres"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/segmentio/ksuid"
+)
+
+// Testing creating deliveries of the staged auth domain events for the subscribed webhooks.
+func TestWebhookDeliveryRepository_Fanout(t *testing.T) {
+	// Creating a new mock pool connection.
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(schemaMatcher))
+	if err != nil {
+		t.Fatalf("error creating a new mock pool connection: %s", err.Error())
+	}
+	defer mock.Close()
+
+	// Creating a new repository.
+	repos := postgres.NewWebhookDeliveryRepository(mock)
+
+	eventId, subscribed, other := ksuid.New(), ksuid.New(), ksuid.New()
+	payload := []byte(`{"type":"session_created"}`)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("DELETE FROM webhook_event (.+) RETURNING").
+		WithArgs(int32(10)).
+		WillReturnRows(mock.NewRows([]string{"id", "event_type", "payload"}).
+			AddRow(eventId.Bytes(), domain.EventSessionCreated, payload))
+	mock.ExpectQuery("SELECT (.+) FROM webhook WHERE enabled").
+		WillReturnRows(mock.NewRows([]string{"id", "url", "event_types", "secret", "enabled", "failures",
+			"created_at", "disabled_at"}).
+			AddRow(subscribed.Bytes(), "https://example.com/webhook", []string{string(domain.EventSessionCreated)},
+				"0123456789abcdef", true, int32(0), time.Now(), nil).
+			AddRow(other.Bytes(), "https://example.com/other", []string{string(domain.EventSessionRevoked)},
+				"0123456789abcdef", true, int32(0), time.Now(), nil))
+	mock.ExpectExec("INSERT INTO webhook_delivery").
+		WithArgs(pgxmock.AnyArg(), pgtype.KSUID(subscribed), pgtype.KSUID(eventId), domain.EventSessionCreated, payload).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	// Creating deliveries of the staged events.
+	if err := repos.Fanout(context.Background(), 10); err != nil {
+		t.Fatalf("error fanning out webhook events: %s", err.Error())
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("error mock expectations: %s", err.Error())
+	}
+}
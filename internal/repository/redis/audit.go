@@ -0,0 +1,254 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/segmentio/ksuid"
+)
+
+// Number of expired auth audit events deleted in one batch.
+const auditDeleteBatchSize int64 = 1000
+
+// Auth audit redis repository structure.
+type AuditRepository struct{ client goredis.UniversalClient }
+
+// Creating a new auth audit redis repository.
+func NewAuditRepository(client goredis.UniversalClient) *AuditRepository {
+	return &AuditRepository{client: client}
+}
+
+// Creating auth audit events.
+func (r *AuditRepository) Create(ctx context.Context, events ...domain.AuditEvent) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for _, event := range events {
+			id := event.Id.String()
+
+			pipe.HSet(ctx, auditKey+id,
+				"kind", string(event.Kind),
+				"actor", event.Actor,
+				"subject", event.Subject,
+				"session_id", formatKSUID(event.SessionId),
+				"ip", event.Ip,
+				"user_agent", event.UserAgent,
+				"outcome", string(event.Outcome),
+				"reason", event.Reason,
+				"created_at", formatTime(event.CreatedAt),
+			)
+			pipe.ZAdd(ctx, auditEventsKey, &goredis.Z{Member: id})
+			pipe.ZAdd(ctx, subjectAuditKey+event.Subject, &goredis.Z{Member: id})
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// Getting a filtered auth audit events list page ordered by id.
+func (r *AuditRepository) GetList(ctx context.Context, filter domain.AuditFilter, sort domain.SortOptions) ([]domain.AuditEvent, domain.PageInfo, error) {
+	rangeBy := &goredis.ZRangeBy{Min: "-", Max: "+"}
+
+	// Added after sort option.
+	if sort.After != ksuid.Nil {
+		rangeBy.Min = "(" + sort.After.String()
+	}
+	// Added before sort option.
+	if sort.Before != ksuid.Nil {
+		rangeBy.Max = "(" + sort.Before.String()
+	}
+
+	// Subject events are read from the subject index.
+	key := auditEventsKey
+	if filter.Subject != "" {
+		key = subjectAuditKey + filter.Subject
+	}
+
+	// One extra event is fetched to check the next page.
+	limit := int(sort.Limit())
+	rangeBy.Count = int64(limit)
+
+	events := make([]domain.AuditEvent, 0, limit)
+
+	// Fetching id batches until the page is filled with matched events.
+	for len(events) < limit {
+		var (
+			ids []string
+			err error
+		)
+
+		if sort.First != nil {
+			ids, err = r.client.ZRangeByLex(ctx, key, rangeBy).Result()
+		} else if sort.Last != nil {
+			ids, err = r.client.ZRevRangeByLex(ctx, key, rangeBy).Result()
+		}
+
+		if err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+
+		batch, err := r.getEvents(ctx, ids)
+		if err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+
+		for _, event := range batch {
+			if len(events) < limit && filter.Match(event) {
+				events = append(events, event)
+			}
+		}
+
+		if len(ids) < limit {
+			break
+		}
+
+		// Moving the range bound past the last fetched id.
+		if sort.First != nil {
+			rangeBy.Min = "(" + ids[len(ids)-1]
+		} else {
+			rangeBy.Max = "(" + ids[len(ids)-1]
+		}
+	}
+
+	events, info := domain.NewPage(events, sort, func(e domain.AuditEvent) ksuid.KSUID { return e.Id })
+
+	return events, info, nil
+}
+
+// Getting auth audit events by ids.
+func (r *AuditRepository) getEvents(ctx context.Context, ids []string) ([]domain.AuditEvent, error) {
+	if len(ids) == 0 {
+		return []domain.AuditEvent{}, nil
+	}
+
+	pipe := r.client.Pipeline()
+
+	cmds := make([]*goredis.StringStringMapCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, auditKey+id)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.AuditEvent, 0, len(ids))
+
+	for i, cmd := range cmds {
+		if len(cmd.Val()) == 0 {
+			continue
+		}
+
+		id, err := ksuid.Parse(ids[i])
+		if err != nil {
+			return nil, err
+		}
+
+		event, err := parseAuditEvent(id, cmd.Val())
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// Preparing storage for events, redis does not need it.
+func (r *AuditRepository) Prepare(context.Context, time.Time) error { return nil }
+
+// Deleting auth audit events created before the time in batches.
+func (r *AuditRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	// Events are ordered by id, so events created before the time have lower ids.
+	bound, err := ksuid.FromParts(before, make([]byte, 16))
+	if err != nil {
+		return err
+	}
+
+	rangeBy := &goredis.ZRangeBy{Min: "-", Max: "(" + bound.String(), Count: auditDeleteBatchSize}
+
+	for {
+		ids, err := r.client.ZRangeByLex(ctx, auditEventsKey, rangeBy).Result()
+		if err != nil {
+			return err
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		// Getting event subjects to remove ids from the subject index.
+		pipe := r.client.Pipeline()
+
+		cmds := make([]*goredis.StringCmd, len(ids))
+		for i, id := range ids {
+			cmds[i] = pipe.HGet(ctx, auditKey+id, "subject")
+		}
+
+		if _, err := pipe.Exec(ctx); err != nil && err != goredis.Nil {
+			return err
+		}
+
+		if _, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			for i, id := range ids {
+				pipe.Del(ctx, auditKey+id)
+				pipe.ZRem(ctx, auditEventsKey, id)
+
+				if subject, err := cmds[i].Result(); err == nil {
+					pipe.ZRem(ctx, subjectAuditKey+subject, id)
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// Parsing a stored auth audit event.
+func parseAuditEvent(id ksuid.KSUID, values map[string]string) (domain.AuditEvent, error) {
+	sessionId, err := parseKSUID(values["session_id"])
+	if err != nil {
+		return domain.AuditEvent{}, err
+	}
+
+	createdAt, err := parseTime(values["created_at"])
+	if err != nil {
+		return domain.AuditEvent{}, err
+	}
+
+	return domain.AuditEvent{
+		Id:        id,
+		Kind:      domain.AuditKind(values["kind"]),
+		Actor:     values["actor"],
+		Subject:   values["subject"],
+		SessionId: sessionId,
+		Ip:        values["ip"],
+		UserAgent: values["user_agent"],
+		Outcome:   domain.AuditOutcome(values["outcome"]),
+		Reason:    values["reason"],
+		CreatedAt: createdAt,
+	}, nil
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Testing auth audit events lifecycle.
+func TestAuditRepository(t *testing.T) {
+	repos, _ := newRepository(t)
+	ctx := context.Background()
+
+	now := time.Now().Truncate(time.Millisecond)
+	subject := ksuid.New().String()
+
+	// Creating an expired and a recent event.
+	events := make([]domain.AuditEvent, 2)
+	for i, createdAt := range []time.Time{now.Add(-time.Hour * 48), now} {
+		id, err := ksuid.NewRandomWithTime(createdAt)
+		if err != nil {
+			t.Fatalf("error generating event id: %s", err.Error())
+		}
+
+		events[i] = domain.AuditEvent{
+			Id:        id,
+			Kind:      domain.AuditSignIn,
+			Actor:     subject,
+			Subject:   subject,
+			SessionId: ksuid.New(),
+			Ip:        "127.0.0.1",
+			Outcome:   domain.AuditSuccess,
+			CreatedAt: createdAt,
+		}
+	}
+
+	if err := repos.Audit.Create(ctx, events...); err != nil {
+		t.Fatalf("error creating auth audit events: %s", err.Error())
+	}
+
+	first := int32(10)
+
+	got, _, err := repos.Audit.GetList(ctx, domain.AuditFilter{Subject: subject}, domain.SortOptions{First: &first})
+	if err != nil {
+		t.Fatalf("error getting auth audit events: %s", err.Error())
+	}
+
+	if len(got) != 2 || got[0].Id != events[0].Id || got[1].SessionId != events[1].SessionId ||
+		!got[1].CreatedAt.Equal(now) {
+		t.Fatalf("error auth audit events: got %v", got)
+	}
+
+	// Deleting the expired event.
+	if err := repos.Audit.DeleteBefore(ctx, now.Add(-time.Hour*24)); err != nil {
+		t.Fatalf("error deleting auth audit events: %s", err.Error())
+	}
+
+	got, _, err = repos.Audit.GetList(ctx, domain.AuditFilter{}, domain.SortOptions{First: &first})
+	if err != nil {
+		t.Fatalf("error getting auth audit events: %s", err.Error())
+	}
+
+	if len(got) != 1 || got[0].Id != events[1].Id {
+		t.Errorf("error auth audit events after retention: got %v", got)
+	}
+}
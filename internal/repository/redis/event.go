@@ -0,0 +1,152 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package redis
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/segmentio/ksuid"
+)
+
+// Auth domain event outbox redis repository structure. Due messages are kept in a sorted
+// set by next attempt time.
+type EventOutboxRepository struct{ client goredis.UniversalClient }
+
+// Creating a new auth domain event outbox redis repository.
+func NewEventOutboxRepository(client goredis.UniversalClient) *EventOutboxRepository {
+	return &EventOutboxRepository{client: client}
+}
+
+// Creating auth domain event outbox messages.
+func (r *EventOutboxRepository) Create(ctx context.Context, messages ...domain.EventMessage) error {
+	// Reserving sequence numbers of the messages.
+	last, err := reserveSequence(ctx, r.client, len(messages))
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		addMessages(ctx, pipe, messages, last)
+
+		return nil
+	})
+
+	return err
+}
+
+// Claiming due outbox messages ordered by sequence.
+func (r *EventOutboxRepository) Claim(ctx context.Context, limit int32, lease time.Duration) ([]domain.EventMessage, error) {
+	now := time.Now()
+
+	ids, err := claimScript.Run(ctx, r.client, []string{eventOutboxDueKey},
+		score(now), limit, score(now.Add(lease)), eventOutboxKey).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := r.client.Pipeline()
+
+	cmds := make([]*goredis.StringStringMapCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, eventOutboxKey+id)
+	}
+
+	if len(ids) != 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	messages := make([]domain.EventMessage, 0, len(ids))
+
+	for i, cmd := range cmds {
+		message, err := parseEventMessage(ids[i], cmd.Val())
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	// Claimed ids are ordered by the next attempt time.
+	sort.Slice(messages, func(i, j int) bool { return messages[i].Sequence < messages[j].Sequence })
+
+	return messages, nil
+}
+
+// Deleting a published outbox message.
+func (r *EventOutboxRepository) Delete(ctx context.Context, id ksuid.KSUID) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Del(ctx, eventOutboxKey+id.String())
+		pipe.ZRem(ctx, eventOutboxDueKey, id.String())
+
+		return nil
+	})
+
+	return err
+}
+
+// Scheduling the next outbox message publish attempt.
+func (r *EventOutboxRepository) Retry(ctx context.Context, id ksuid.KSUID, next time.Time, lastErr string) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, eventOutboxKey+id.String(), "last_error", lastErr)
+		pipe.ZAddXX(ctx, eventOutboxDueKey, &goredis.Z{Score: score(next), Member: id.String()})
+
+		return nil
+	})
+
+	return err
+}
+
+// Parsing a stored outbox message.
+func parseEventMessage(id string, values map[string]string) (domain.EventMessage, error) {
+	messageId, err := ksuid.Parse(id)
+	if err != nil {
+		return domain.EventMessage{}, err
+	}
+
+	sequence, err := strconv.ParseInt(values["seq"], 10, 64)
+	if err != nil {
+		return domain.EventMessage{}, err
+	}
+
+	attempts, err := strconv.ParseInt(values["attempts"], 10, 32)
+	if err != nil {
+		return domain.EventMessage{}, err
+	}
+
+	createdAt, err := parseTime(values["created_at"])
+	if err != nil {
+		return domain.EventMessage{}, err
+	}
+
+	return domain.EventMessage{
+		Id:        messageId,
+		Subject:   values["subject"],
+		Payload:   []byte(values["payload"]),
+		Sequence:  sequence,
+		Attempts:  int32(attempts),
+		CreatedAt: createdAt,
+	}, nil
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Testing auth domain event outbox messages lifecycle.
+func TestEventOutboxRepository(t *testing.T) {
+	repos, _ := newRepository(t)
+	ctx := context.Background()
+
+	// Message ids are generated in the reverse order of creation.
+	messages := []domain.EventMessage{
+		{Id: ksuid.New(), Subject: "durudex.auth.v1.session_created", Payload: []byte("created")},
+		{Id: ksuid.New(), Subject: "durudex.auth.v1.session_revoked", Payload: []byte("revoked")},
+	}
+	if ksuid.Compare(messages[0].Id, messages[1].Id) < 0 {
+		messages[0].Id, messages[1].Id = messages[1].Id, messages[0].Id
+	}
+
+	if err := repos.EventOutbox.Create(ctx, messages...); err != nil {
+		t.Fatalf("error creating outbox messages: %s", err.Error())
+	}
+
+	got, err := repos.EventOutbox.Claim(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("error claiming outbox messages: %s", err.Error())
+	}
+
+	if len(got) != 2 || got[0].Id != messages[0].Id || got[1].Id != messages[1].Id || got[0].Attempts != 1 ||
+		string(got[1].Payload) != "revoked" || got[0].Sequence >= got[1].Sequence {
+		t.Fatalf("error outbox messages: got %v", got)
+	}
+
+	// Claimed messages are leased.
+	if got, err := repos.EventOutbox.Claim(ctx, 10, time.Minute); err != nil || len(got) != 0 {
+		t.Fatalf("error claiming leased outbox messages: got %v, %v", got, err)
+	}
+
+	if err := repos.EventOutbox.Delete(ctx, messages[0].Id); err != nil {
+		t.Fatalf("error deleting outbox message: %s", err.Error())
+	}
+
+	if err := repos.EventOutbox.Retry(ctx, messages[1].Id, time.Now().Add(-time.Second), "error"); err != nil {
+		t.Fatalf("error retrying outbox message: %s", err.Error())
+	}
+
+	got, err = repos.EventOutbox.Claim(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("error claiming outbox messages: %s", err.Error())
+	}
+
+	if len(got) != 1 || got[0].Id != messages[1].Id || got[0].Attempts != 2 {
+		t.Errorf("error retried outbox messages: got %v", got)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package redis
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	goredis "github.com/go-redis/redis/v8"
+)
+
+// Reserving an idempotency key. Returns the stored fingerprint and response when the key is
+// already reserved.
+var reserveScript = goredis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return redis.call('HMGET', KEYS[1], 'fingerprint', 'response')
+end
+redis.call('HSET', KEYS[1], 'fingerprint', ARGV[1])
+redis.call('PEXPIREAT', KEYS[1], ARGV[2])
+return false
+`)
+
+// Releasing an idempotency key without response.
+var releaseScript = goredis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], 'response') == 0 then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// Idempotency key redis repository structure. Keys expire by redis TTL.
+type IdempotencyRepository struct{ client goredis.UniversalClient }
+
+// Creating a new idempotency key redis repository.
+func NewIdempotencyRepository(client goredis.UniversalClient) *IdempotencyRepository {
+	return &IdempotencyRepository{client: client}
+}
+
+// Reserving an idempotency key.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, key domain.IdempotencyKey) (domain.IdempotencyKey, bool, error) {
+	values, err := reserveScript.Run(ctx, r.client, []string{idempotencyKey + hex.EncodeToString(key.Id)},
+		key.Fingerprint, key.ExpiresAt.UnixMilli()).Slice()
+	if err == goredis.Nil {
+		return key, true, nil
+	} else if err != nil {
+		return domain.IdempotencyKey{}, false, err
+	}
+
+	stored := domain.IdempotencyKey{Id: key.Id}
+
+	if fingerprint, ok := values[0].(string); ok {
+		stored.Fingerprint = []byte(fingerprint)
+	}
+	if response, ok := values[1].(string); ok {
+		stored.Response = []byte(response)
+	}
+
+	return stored, false, nil
+}
+
+// Storing response of the completed request.
+func (r *IdempotencyRepository) Complete(ctx context.Context, id, response []byte, expiresAt time.Time) error {
+	key := idempotencyKey + hex.EncodeToString(id)
+
+	_, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, key, "response", response)
+		pipe.PExpireAt(ctx, key, expiresAt)
+
+		return nil
+	})
+
+	return err
+}
+
+// Releasing an idempotency key of the failed request.
+func (r *IdempotencyRepository) Release(ctx context.Context, id []byte) error {
+	return releaseScript.Run(ctx, r.client, []string{idempotencyKey + hex.EncodeToString(id)}).Err()
+}
+
+// Deleting expired idempotency keys. Keys are expired by redis, so nothing is deleted.
+func (r *IdempotencyRepository) DeleteExpired(context.Context, int32) (int64, error) {
+	return 0, nil
+}
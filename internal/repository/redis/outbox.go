@@ -0,0 +1,161 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/segmentio/ksuid"
+)
+
+// Claiming due sorted set members. Claimed members are moved to the lease time and their
+// hash attempts counter is incremented, when the hash key prefix is set.
+var claimScript = goredis.NewScript(`
+local ids = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, id in ipairs(ids) do
+	redis.call('ZADD', KEYS[1], ARGV[3], id)
+	if ARGV[4] ~= '' then
+		redis.call('HINCRBY', ARGV[4] .. id, 'attempts', 1)
+	end
+end
+return ids
+`)
+
+// Email outbox redis repository structure. Due emails are kept in a sorted set by next
+// attempt time.
+type OutboxRepository struct{ client goredis.UniversalClient }
+
+// Creating a new email outbox redis repository.
+func NewOutboxRepository(client goredis.UniversalClient) *OutboxRepository {
+	return &OutboxRepository{client: client}
+}
+
+// Claiming due outbox emails.
+func (r *OutboxRepository) Claim(ctx context.Context, limit int32, lease time.Duration) ([]domain.Email, error) {
+	now := time.Now()
+
+	ids, err := claimScript.Run(ctx, r.client, []string{outboxDueKey},
+		score(now), limit, score(now.Add(lease)), outboxKey).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := r.client.Pipeline()
+
+	cmds := make([]*goredis.StringStringMapCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, outboxKey+id)
+	}
+
+	if len(ids) != 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	emails := make([]domain.Email, 0, len(ids))
+
+	for i, cmd := range cmds {
+		email, err := parseEmail(ids[i], cmd.Val())
+		if err != nil {
+			return nil, err
+		}
+
+		emails = append(emails, email)
+	}
+
+	return emails, nil
+}
+
+// Deleting a delivered outbox email.
+func (r *OutboxRepository) Delete(ctx context.Context, id ksuid.KSUID) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Del(ctx, outboxKey+id.String())
+		pipe.ZRem(ctx, outboxDueKey, id.String())
+
+		return nil
+	})
+
+	return err
+}
+
+// Scheduling the next outbox email delivery attempt.
+func (r *OutboxRepository) Retry(ctx context.Context, id ksuid.KSUID, next time.Time, lastErr string) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, outboxKey+id.String(), "last_error", lastErr)
+		pipe.ZAddXX(ctx, outboxDueKey, &goredis.Z{Score: score(next), Member: id.String()})
+
+		return nil
+	})
+
+	return err
+}
+
+// Moving an outbox email to the dead letter.
+func (r *OutboxRepository) Dead(ctx context.Context, id ksuid.KSUID, lastErr string) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, outboxKey+id.String(), "last_error", lastErr)
+		pipe.ZRem(ctx, outboxDueKey, id.String())
+		pipe.SAdd(ctx, outboxDeadKey, id.String())
+
+		return nil
+	})
+
+	return err
+}
+
+// Parsing a stored outbox email.
+func parseEmail(id string, values map[string]string) (domain.Email, error) {
+	emailId, err := ksuid.Parse(id)
+	if err != nil {
+		return domain.Email{}, err
+	}
+
+	var p emailPayload
+
+	// Decoding email payload.
+	if err := json.Unmarshal([]byte(values["payload"]), &p); err != nil {
+		return domain.Email{}, err
+	}
+
+	attempts, err := strconv.ParseInt(values["attempts"], 10, 32)
+	if err != nil {
+		return domain.Email{}, err
+	}
+
+	createdAt, err := parseTime(values["created_at"])
+	if err != nil {
+		return domain.Email{}, err
+	}
+
+	return domain.Email{
+		Id:        emailId,
+		Kind:      domain.EmailKind(values["kind"]),
+		Email:     p.Email,
+		Username:  p.Username,
+		Ip:        p.Ip,
+		Attempts:  int32(attempts),
+		CreatedAt: createdAt,
+	}, nil
+}
@@ -0,0 +1,269 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/pkg/database/redis"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/ksuid"
+)
+
+// Redis keys.
+const (
+	// User session hash key prefix.
+	sessionKey string = "auth:session:"
+	// User session ids sorted set key prefix.
+	userSessionsKey string = "auth:user_sessions:"
+	// Outbox email hash key prefix.
+	outboxKey string = "auth:outbox:"
+	// Due outbox emails sorted set by next attempt time.
+	outboxDueKey string = "auth:outbox_due"
+	// Dead letter outbox emails set.
+	outboxDeadKey string = "auth:outbox_dead"
+	// Sign up saga hash key prefix.
+	sagaKey string = "auth:saga:"
+	// Sign up sagas in progress sorted set by update time.
+	sagaActiveKey string = "auth:saga_active"
+	// Idempotency key hash key prefix.
+	idempotencyKey string = "auth:idempotency:"
+	// Auth audit event hash key prefix.
+	auditKey string = "auth:audit:"
+	// Auth audit event ids sorted set.
+	auditEventsKey string = "auth:audit_events"
+	// Subject auth audit event ids sorted set key prefix.
+	subjectAuditKey string = "auth:subject_audit:"
+	// Auth domain event outbox hash key prefix.
+	eventOutboxKey string = "auth:event_outbox:"
+	// Due auth domain event outbox messages sorted set by next attempt time.
+	eventOutboxDueKey string = "auth:event_outbox_due"
+	// Last auth domain event outbox sequence number.
+	eventOutboxSeqKey string = "auth:event_outbox_seq"
+	// Webhook hash key prefix.
+	webhookKey string = "auth:webhook:"
+	// Webhook ids sorted set.
+	webhooksKey string = "auth:webhooks"
+	// Webhook delivery hash key prefix.
+	webhookDeliveryKey string = "auth:webhook_delivery:"
+	// Webhook delivery ids sorted set key prefix.
+	webhookDeliveriesKey string = "auth:webhook_deliveries:"
+	// Due webhook deliveries sorted set by next attempt time.
+	webhookDeliveryDueKey string = "auth:webhook_delivery_due"
+	// Staged auth domain event of the webhook deliveries hash key prefix.
+	webhookEventKey string = "auth:webhook_event:"
+	// Staged auth domain event ids of the webhook deliveries sorted set ordered by id.
+	webhookEventsKey string = "auth:webhook_events"
+	// Sign in risk assessment hash key prefix.
+	riskKey string = "auth:risk:"
+	// User risk assessment ids sorted set key prefix.
+	userRisksKey string = "auth:user_risks:"
+)
+
+// Redis repository structure.
+type RedisRepository struct {
+	Session         *SessionRepository
+	Outbox          *OutboxRepository
+	Saga            *SagaRepository
+	Idempotency     *IdempotencyRepository
+	Audit           *AuditRepository
+	EventOutbox     *EventOutboxRepository
+	Webhook         *WebhookRepository
+	WebhookDelivery *WebhookDeliveryRepository
+	Risk            *RiskRepository
+	client          goredis.UniversalClient
+}
+
+// Creating a new redis repository.
+func NewRedisRepository(cfg config.RedisConfig) (*RedisRepository, error) {
+	log.Debug().Msg("Creating a new redis repository...")
+
+	// Creating a new redis client.
+	client, err := redis.NewClient(&redis.RedisConfig{URL: cfg.URL, PoolSize: cfg.PoolSize})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRedisRepositoryWithClient(client), nil
+}
+
+// Creating a new redis repository with the client.
+func NewRedisRepositoryWithClient(client goredis.UniversalClient) *RedisRepository {
+	return &RedisRepository{
+		Session:         NewSessionRepository(client),
+		Outbox:          NewOutboxRepository(client),
+		Saga:            NewSagaRepository(client),
+		Idempotency:     NewIdempotencyRepository(client),
+		Audit:           NewAuditRepository(client),
+		EventOutbox:     NewEventOutboxRepository(client),
+		Webhook:         NewWebhookRepository(client),
+		WebhookDelivery: NewWebhookDeliveryRepository(client),
+		Risk:            NewRiskRepository(client),
+		client:          client,
+	}
+}
+
+// Closing redis client connections.
+func (r *RedisRepository) Close() {
+	if err := r.client.Close(); err != nil {
+		log.Error().Err(err).Msg("failed to close redis client")
+	}
+}
+
+// Email outbox payload structure.
+type emailPayload struct {
+	Email    string `json:"email"`
+	Username string `json:"username,omitempty"`
+	Ip       string `json:"ip,omitempty"`
+}
+
+// Adding a new user session to the pipeline. Session hash expires with the session.
+func addSession(ctx context.Context, pipe goredis.Pipeliner, session domain.UserSession) {
+	key := sessionKey + session.Id.String()
+
+	pipe.HSet(ctx, key,
+		"user_id", session.UserId.String(),
+		"payload", session.Payload,
+		"ip", session.Ip,
+		"expires_in", formatTime(session.ExpiresIn),
+		"device_type", session.DeviceType,
+		"device_name", session.DeviceName,
+		"country", session.Country,
+		"city", session.City,
+		"auth_level", session.AuthLevel,
+		"auth_methods", strings.Join(session.AuthMethods, ","),
+		"auth_time", formatTime(session.AuthTime),
+	)
+	pipe.PExpireAt(ctx, key, session.ExpiresIn)
+	pipe.ZAdd(ctx, userSessionsKey+session.UserId.String(), &goredis.Z{Member: session.Id.String()})
+}
+
+// Reserving sequence numbers of the outbox event messages, returns the last reserved number.
+func reserveSequence(ctx context.Context, client goredis.UniversalClient, n int) (int64, error) {
+	if n == 0 {
+		return 0, nil
+	}
+
+	return client.IncrBy(ctx, eventOutboxSeqKey, int64(n)).Result()
+}
+
+// Adding the outbox records of the state change to the pipeline. Event message sequence
+// numbers must be reserved before the transaction.
+func addOutbox(ctx context.Context, pipe goredis.Pipeliner, outbox domain.Outbox, last int64) error {
+	if err := addEmails(ctx, pipe, outbox.Emails); err != nil {
+		return err
+	}
+
+	addMessages(ctx, pipe, outbox.Events, last)
+	addWebhookEvents(ctx, pipe, outbox.Webhooks)
+
+	return nil
+}
+
+// Adding auth domain event messages to the pipeline, the last message gets the last reserved
+// sequence number.
+func addMessages(ctx context.Context, pipe goredis.Pipeliner, messages []domain.EventMessage, last int64) {
+	now := time.Now()
+
+	for i, message := range messages {
+		pipe.HSet(ctx, eventOutboxKey+message.Id.String(),
+			"subject", message.Subject,
+			"payload", message.Payload,
+			"seq", last-int64(len(messages)-1-i),
+			"attempts", 0,
+			"created_at", formatTime(now),
+		)
+		pipe.ZAdd(ctx, eventOutboxDueKey, &goredis.Z{Score: score(now), Member: message.Id.String()})
+	}
+}
+
+// Adding staged auth domain events of the webhook deliveries to the pipeline.
+func addWebhookEvents(ctx context.Context, pipe goredis.Pipeliner, events []domain.WebhookEvent) {
+	for _, e := range events {
+		pipe.HSet(ctx, webhookEventKey+e.Id.String(), "event_type", string(e.Type), "payload", e.Payload)
+		pipe.ZAdd(ctx, webhookEventsKey, &goredis.Z{Member: e.Id.String()})
+	}
+}
+
+// Adding notification emails to the pipeline.
+func addEmails(ctx context.Context, pipe goredis.Pipeliner, emails []domain.Email) error {
+	now := time.Now()
+
+	for _, email := range emails {
+		payload, err := json.Marshal(emailPayload{Email: email.Email, Username: email.Username, Ip: email.Ip})
+		if err != nil {
+			return err
+		}
+
+		pipe.HSet(ctx, outboxKey+email.Id.String(),
+			"kind", string(email.Kind),
+			"payload", payload,
+			"attempts", 0,
+			"created_at", formatTime(now),
+		)
+		pipe.ZAdd(ctx, outboxDueKey, &goredis.Z{Score: score(now), Member: email.Id.String()})
+	}
+
+	return nil
+}
+
+// Getting a sorted set score of the time.
+func score(t time.Time) float64 {
+	return float64(t.UnixMilli())
+}
+
+// Formatting a stored time.
+func formatTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixMilli(), 10)
+}
+
+// Parsing a stored time.
+func parseTime(value string) (time.Time, error) {
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.UnixMilli(ms), nil
+}
+
+// Formatting a stored ksuid, nil ksuid is formatted as empty value.
+func formatKSUID(id ksuid.KSUID) string {
+	if id == ksuid.Nil {
+		return ""
+	}
+
+	return id.String()
+}
+
+// Parsing a stored ksuid, empty value is parsed as nil ksuid.
+func parseKSUID(value string) (ksuid.KSUID, error) {
+	if value == "" {
+		return ksuid.Nil, nil
+	}
+
+	return ksuid.Parse(value)
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/segmentio/ksuid"
+)
+
+// Sign in risk assessment redis repository structure. User assessments log is kept in a
+// sorted set ordered by id.
+type RiskRepository struct{ client goredis.UniversalClient }
+
+// Creating a new sign in risk assessment redis repository.
+func NewRiskRepository(client goredis.UniversalClient) *RiskRepository {
+	return &RiskRepository{client: client}
+}
+
+// Creating a sign in risk assessment.
+func (r *RiskRepository) Create(ctx context.Context, assessment domain.RiskAssessment) error {
+	signals, err := json.Marshal(assessment.Signals)
+	if err != nil {
+		return err
+	}
+
+	id := assessment.Id.String()
+
+	_, err = r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, riskKey+id,
+			"user_id", assessment.UserId.String(),
+			"session_id", formatKSUID(assessment.SessionId),
+			"ip", assessment.Ip,
+			"user_agent", assessment.UserAgent,
+			"score", assessment.Score,
+			"decision", string(assessment.Decision),
+			"signals", signals,
+			"created_at", formatTime(assessment.CreatedAt),
+		)
+		pipe.ZAdd(ctx, userRisksKey+assessment.UserId.String(), &goredis.Z{Member: id})
+
+		return nil
+	})
+
+	return err
+}
+
+// Getting a user risk assessments list page ordered by id.
+func (r *RiskRepository) GetList(ctx context.Context, userId ksuid.KSUID, sort domain.SortOptions) ([]domain.RiskAssessment, domain.PageInfo, error) {
+	rangeBy := &goredis.ZRangeBy{Min: "-", Max: "+", Count: int64(sort.Limit())}
+
+	// Added after sort option.
+	if sort.After != ksuid.Nil {
+		rangeBy.Min = "(" + sort.After.String()
+	}
+	// Added before sort option.
+	if sort.Before != ksuid.Nil {
+		rangeBy.Max = "(" + sort.Before.String()
+	}
+
+	var (
+		ids []string
+		err error
+	)
+
+	key := userRisksKey + userId.String()
+
+	// One extra assessment is fetched to check the next page.
+	if sort.First != nil {
+		ids, err = r.client.ZRangeByLex(ctx, key, rangeBy).Result()
+	} else if sort.Last != nil {
+		ids, err = r.client.ZRevRangeByLex(ctx, key, rangeBy).Result()
+	}
+
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	pipe := r.client.Pipeline()
+
+	cmds := make([]*goredis.StringStringMapCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, riskKey+id)
+	}
+
+	if len(ids) != 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+	}
+
+	assessments := make([]domain.RiskAssessment, 0, len(ids))
+
+	for i, cmd := range cmds {
+		if len(cmd.Val()) == 0 {
+			continue
+		}
+
+		assessment, err := parseRiskAssessment(ids[i], cmd.Val())
+		if err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+
+		assessments = append(assessments, assessment)
+	}
+
+	assessments, info := domain.NewPage(assessments, sort, func(a domain.RiskAssessment) ksuid.KSUID { return a.Id })
+
+	return assessments, info, nil
+}
+
+// Parsing a stored sign in risk assessment.
+func parseRiskAssessment(id string, values map[string]string) (domain.RiskAssessment, error) {
+	assessmentId, err := ksuid.Parse(id)
+	if err != nil {
+		return domain.RiskAssessment{}, err
+	}
+
+	userId, err := ksuid.Parse(values["user_id"])
+	if err != nil {
+		return domain.RiskAssessment{}, err
+	}
+
+	sessionId, err := parseKSUID(values["session_id"])
+	if err != nil {
+		return domain.RiskAssessment{}, err
+	}
+
+	score, err := strconv.Atoi(values["score"])
+	if err != nil {
+		return domain.RiskAssessment{}, err
+	}
+
+	var signals []domain.RiskSignal
+
+	if err := json.Unmarshal([]byte(values["signals"]), &signals); err != nil {
+		return domain.RiskAssessment{}, err
+	}
+
+	createdAt, err := parseTime(values["created_at"])
+	if err != nil {
+		return domain.RiskAssessment{}, err
+	}
+
+	return domain.RiskAssessment{
+		Id:        assessmentId,
+		UserId:    userId,
+		SessionId: sessionId,
+		Ip:        values["ip"],
+		UserAgent: values["user_agent"],
+		Score:     score,
+		Decision:  domain.RiskDecision(values["decision"]),
+		Signals:   signals,
+		CreatedAt: createdAt,
+	}, nil
+}
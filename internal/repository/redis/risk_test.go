@@ -0,0 +1,79 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package redis_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Testing sign in risk assessments log.
+func TestRiskRepository(t *testing.T) {
+	repos, _ := newRepository(t)
+	ctx := context.Background()
+
+	userId := ksuid.New()
+
+	// Creating an allowed and a challenged assessment.
+	assessments := make([]domain.RiskAssessment, 2)
+	for i, decision := range []domain.RiskDecision{domain.RiskAllow, domain.RiskChallenge} {
+		id, err := ksuid.NewRandomWithTime(time.Now().Add(time.Duration(i-2) * time.Second))
+		if err != nil {
+			t.Fatalf("error generating assessment id: %s", err.Error())
+		}
+
+		assessments[i] = domain.RiskAssessment{
+			Id:        id,
+			UserId:    userId,
+			SessionId: ksuid.New(),
+			Ip:        "127.0.0.1",
+			Score:     i * 60,
+			Decision:  decision,
+			CreatedAt: time.Now().Truncate(time.Millisecond),
+		}
+	}
+
+	assessments[1].Signals = []domain.RiskSignal{{Name: "velocity", Score: 60, Detail: "5 failed attempts"}}
+
+	for _, assessment := range assessments {
+		if err := repos.Risk.Create(ctx, assessment); err != nil {
+			t.Fatalf("error creating risk assessment: %s", err.Error())
+		}
+	}
+
+	last := int32(1)
+
+	got, info, err := repos.Risk.GetList(ctx, userId, domain.SortOptions{Last: &last})
+	if err != nil {
+		t.Fatalf("error getting risk assessments: %s", err.Error())
+	}
+
+	if len(got) != 1 || got[0].Id != assessments[1].Id || !reflect.DeepEqual(got[0].Signals, assessments[1].Signals) ||
+		!got[0].CreatedAt.Equal(assessments[1].CreatedAt) {
+		t.Fatalf("error risk assessments: got %v", got)
+	}
+
+	if !info.HasPreviousPage {
+		t.Errorf("error page info: got %+v", info)
+	}
+}
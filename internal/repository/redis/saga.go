@@ -0,0 +1,185 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/segmentio/ksuid"
+)
+
+// Sign up saga redis repository structure. Sagas in progress are kept in a sorted set by
+// update time.
+type SagaRepository struct{ client goredis.UniversalClient }
+
+// Creating a new sign up saga redis repository.
+func NewSagaRepository(client goredis.UniversalClient) *SagaRepository {
+	return &SagaRepository{client: client}
+}
+
+// Creating a new sign up saga.
+func (r *SagaRepository) Create(ctx context.Context, saga domain.SignUpSaga) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, sagaKey+saga.Id.String(),
+			"email", saga.Email,
+			"username", saga.Username,
+			"ip", saga.Ip,
+		)
+		updateSaga(ctx, pipe, saga)
+
+		return nil
+	})
+
+	return err
+}
+
+// Updating a sign up saga step state.
+func (r *SagaRepository) Update(ctx context.Context, saga domain.SignUpSaga) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		updateSaga(ctx, pipe, saga)
+		return nil
+	})
+
+	return err
+}
+
+// Adding a sign up saga step state update to the pipeline.
+func updateSaga(ctx context.Context, pipe goredis.Pipeliner, saga domain.SignUpSaga) {
+	now := time.Now()
+
+	var userId string
+	if saga.UserId != ksuid.Nil {
+		userId = saga.UserId.String()
+	}
+
+	pipe.HSet(ctx, sagaKey+saga.Id.String(),
+		"step", string(saga.Step),
+		"user_id", userId,
+		"attempts", saga.Attempts,
+		"last_error", saga.LastError,
+		"updated_at", formatTime(now),
+	)
+
+	// Only sagas in progress are claimed by the recovery worker.
+	if saga.Done() {
+		pipe.ZRem(ctx, sagaActiveKey, saga.Id.String())
+	} else {
+		pipe.ZAdd(ctx, sagaActiveKey, &goredis.Z{Score: score(now), Member: saga.Id.String()})
+	}
+}
+
+// Finishing a sign up saga.
+func (r *SagaRepository) Finish(ctx context.Context, saga domain.SignUpSaga, session *domain.UserSession, outbox domain.Outbox) error {
+	last, err := reserveSequence(ctx, r.client, len(outbox.Events))
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		// Adding a new user session.
+		if session != nil {
+			addSession(ctx, pipe, *session)
+		}
+
+		if err := addOutbox(ctx, pipe, outbox, last); err != nil {
+			return err
+		}
+
+		updateSaga(ctx, pipe, saga)
+
+		return nil
+	})
+
+	return err
+}
+
+// Claiming stale sign up sagas in progress.
+func (r *SagaRepository) ClaimStale(ctx context.Context, before time.Time, limit int32, lease time.Duration) ([]domain.SignUpSaga, error) {
+	// Claimed sagas are stale again after the lease.
+	ids, err := claimScript.Run(ctx, r.client, []string{sagaActiveKey},
+		"("+strconv.FormatFloat(score(before), 'f', 0, 64), limit, score(before.Add(lease)), "").StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := r.client.Pipeline()
+
+	cmds := make([]*goredis.StringStringMapCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, sagaKey+id)
+	}
+
+	if len(ids) != 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	sagas := make([]domain.SignUpSaga, 0, len(ids))
+
+	for i, cmd := range cmds {
+		saga, err := parseSaga(ids[i], cmd.Val())
+		if err != nil {
+			return nil, err
+		}
+
+		sagas = append(sagas, saga)
+	}
+
+	return sagas, nil
+}
+
+// Parsing a stored sign up saga.
+func parseSaga(id string, values map[string]string) (domain.SignUpSaga, error) {
+	sagaId, err := ksuid.Parse(id)
+	if err != nil {
+		return domain.SignUpSaga{}, err
+	}
+
+	userId, err := parseKSUID(values["user_id"])
+	if err != nil {
+		return domain.SignUpSaga{}, err
+	}
+
+	attempts, err := strconv.ParseInt(values["attempts"], 10, 32)
+	if err != nil {
+		return domain.SignUpSaga{}, err
+	}
+
+	updatedAt, err := parseTime(values["updated_at"])
+	if err != nil {
+		return domain.SignUpSaga{}, err
+	}
+
+	return domain.SignUpSaga{
+		Id:        sagaId,
+		Step:      domain.SagaStep(values["step"]),
+		UserId:    userId,
+		Email:     values["email"],
+		Username:  values["username"],
+		Ip:        values["ip"],
+		Attempts:  int32(attempts),
+		LastError: values["last_error"],
+		UpdatedAt: updatedAt,
+	}, nil
+}
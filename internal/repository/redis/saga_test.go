@@ -0,0 +1,141 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Testing sign up saga recovery and outbox emails.
+func TestSagaRepository(t *testing.T) {
+	repos, _ := newRepository(t)
+	ctx := context.Background()
+
+	stale := domain.SignUpSaga{Id: ksuid.New(), Step: domain.SagaStarted, Email: "stale@durudex.com"}
+	done := domain.SignUpSaga{Id: ksuid.New(), Step: domain.SagaUserCreated, UserId: ksuid.New(), Email: "done@durudex.com"}
+
+	for _, saga := range []domain.SignUpSaga{stale, done} {
+		if err := repos.Saga.Create(ctx, saga); err != nil {
+			t.Fatalf("error creating a new sign up saga: %s", err.Error())
+		}
+	}
+
+	// Finishing a saga with session, register email and sign up event.
+	done.Step = domain.SagaCompleted
+	session := domain.UserSession{Id: ksuid.New(), UserId: done.UserId, ExpiresIn: time.Now().Add(time.Hour)}
+	email := domain.Email{Id: ksuid.New(), Kind: domain.EmailUserRegister, Email: done.Email}
+	message := domain.EventMessage{Id: ksuid.New(), Subject: "durudex.auth.v1.user_signed_up", Payload: []byte("user")}
+
+	outbox := domain.Outbox{Emails: []domain.Email{email}, Events: []domain.EventMessage{message}}
+
+	if err := repos.Saga.Finish(ctx, done, &session, outbox); err != nil {
+		t.Fatalf("error finishing a sign up saga: %s", err.Error())
+	}
+
+	if _, err := repos.Session.Get(ctx, done.UserId, session.Id); err != nil {
+		t.Errorf("error getting finished saga session: %s", err.Error())
+	}
+
+	// Claiming sign up event written with the saga.
+	messages, err := repos.EventOutbox.Claim(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("error claiming event outbox messages: %s", err.Error())
+	}
+
+	if len(messages) != 1 || messages[0].Id != message.Id || string(messages[0].Payload) != "user" {
+		t.Fatalf("error claimed event messages: got %v, want %v", messages, message)
+	}
+
+	// Claiming stale sagas, finished saga is not claimed.
+	sagas, err := repos.Saga.ClaimStale(ctx, time.Now().Add(time.Second), 10, time.Minute)
+	if err != nil {
+		t.Fatalf("error claiming stale sagas: %s", err.Error())
+	}
+
+	if len(sagas) != 1 || sagas[0].Id != stale.Id || sagas[0].Email != stale.Email {
+		t.Fatalf("error claimed sagas: got %v, want %s", sagas, stale.Id)
+	}
+
+	// Claimed saga is hidden for the lease.
+	if sagas, err := repos.Saga.ClaimStale(ctx, time.Now().Add(time.Second), 10, time.Minute); err != nil || len(sagas) != 0 {
+		t.Errorf("error claiming leased sagas: got %v, %v", sagas, err)
+	}
+
+	// Claiming register email.
+	emails, err := repos.Outbox.Claim(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("error claiming outbox emails: %s", err.Error())
+	}
+
+	if len(emails) != 1 || emails[0].Id != email.Id || emails[0].Email != email.Email || emails[0].Attempts != 1 {
+		t.Fatalf("error claimed emails: got %v, want %v", emails, email)
+	}
+
+	// Moving email to the dead letter.
+	if err := repos.Outbox.Dead(ctx, email.Id, "unavailable"); err != nil {
+		t.Fatalf("error moving email to the dead letter: %s", err.Error())
+	}
+
+	if emails, err := repos.Outbox.Claim(ctx, 10, 0); err != nil || len(emails) != 0 {
+		t.Errorf("error claiming dead emails: got %v, %v", emails, err)
+	}
+}
+
+// Testing idempotency key reservation.
+func TestIdempotencyRepository(t *testing.T) {
+	repos, server := newRepository(t)
+	ctx := context.Background()
+
+	key := domain.IdempotencyKey{Id: []byte{1, 2, 3}, Fingerprint: []byte{4, 5, 6}, ExpiresAt: time.Now().Add(time.Minute)}
+
+	if _, ok, err := repos.Idempotency.Reserve(ctx, key); err != nil || !ok {
+		t.Fatalf("error reserving a new key: got %t, %v", ok, err)
+	}
+
+	// Reserved key is in progress.
+	stored, ok, err := repos.Idempotency.Reserve(ctx, key)
+	if err != nil || ok || string(stored.Fingerprint) != string(key.Fingerprint) || stored.Response != nil {
+		t.Fatalf("error reserving an in progress key: got %v, %t, %v", stored, ok, err)
+	}
+
+	if err := repos.Idempotency.Complete(ctx, key.Id, []byte("response"), time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("error completing a key: %s", err.Error())
+	}
+
+	// Completed key is not released.
+	if err := repos.Idempotency.Release(ctx, key.Id); err != nil {
+		t.Fatalf("error releasing a key: %s", err.Error())
+	}
+
+	if stored, _, err := repos.Idempotency.Reserve(ctx, key); err != nil || string(stored.Response) != "response" {
+		t.Fatalf("error reserving a completed key: got %v, %v", stored, err)
+	}
+
+	// Expired key is reserved again.
+	server.FastForward(time.Hour + time.Minute)
+
+	if _, ok, err := repos.Idempotency.Reserve(ctx, key); err != nil || !ok {
+		t.Errorf("error reserving an expired key: got %t, %v", ok, err)
+	}
+}
@@ -0,0 +1,278 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package redis
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/segmentio/ksuid"
+)
+
+// User session redis repository structure. Sessions are stored in hashes expiring with the
+// session, user session ids are kept in a sorted set ordered by id.
+type SessionRepository struct{ client goredis.UniversalClient }
+
+// Creating a new user session redis repository.
+func NewSessionRepository(client goredis.UniversalClient) *SessionRepository {
+	return &SessionRepository{client: client}
+}
+
+// Creating a new user session.
+func (r *SessionRepository) Create(ctx context.Context, session domain.UserSession, outbox domain.Outbox) error {
+	last, err := reserveSequence(ctx, r.client, len(outbox.Events))
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		addSession(ctx, pipe, session)
+
+		return addOutbox(ctx, pipe, outbox, last)
+	})
+
+	return err
+}
+
+// Getting a user session.
+func (r *SessionRepository) Get(ctx context.Context, userId, id ksuid.KSUID) (domain.UserSession, error) {
+	values, err := r.client.HGetAll(ctx, sessionKey+id.String()).Result()
+	if err != nil {
+		return domain.UserSession{}, err
+	}
+
+	if len(values) == 0 || values["user_id"] != userId.String() {
+		return domain.UserSession{}, &domain.Error{Code: domain.CodeNotFound, Message: "Session not found"}
+	}
+
+	return parseSession(id, values)
+}
+
+// Getting a filtered user sessions list page ordered by id. Expired sessions are removed by
+// redis, so the expired state filter matches only sessions expiring during the query.
+func (r *SessionRepository) GetList(ctx context.Context, userId ksuid.KSUID, filter domain.SessionFilter, sort domain.SortOptions) ([]domain.UserSession, domain.PageInfo, error) {
+	rangeBy := &goredis.ZRangeBy{Min: "-", Max: "+"}
+
+	// Added after sort option.
+	if sort.After != ksuid.Nil {
+		rangeBy.Min = "(" + sort.After.String()
+	}
+	// Added before sort option.
+	if sort.Before != ksuid.Nil {
+		rangeBy.Max = "(" + sort.Before.String()
+	}
+
+	key := userSessionsKey + userId.String()
+
+	// One extra session is fetched to check the next page.
+	limit := int(sort.Limit())
+	rangeBy.Count = int64(limit)
+
+	sessions := make([]domain.UserSession, 0, limit)
+	now := time.Now()
+
+	// Fetching id batches until the page is filled with matched sessions.
+	for len(sessions) < limit {
+		var (
+			ids []string
+			err error
+		)
+
+		if sort.First != nil {
+			ids, err = r.client.ZRangeByLex(ctx, key, rangeBy).Result()
+		} else if sort.Last != nil {
+			ids, err = r.client.ZRevRangeByLex(ctx, key, rangeBy).Result()
+		}
+
+		if err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+
+		batch, err := r.getSessions(ctx, key, ids)
+		if err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+
+		for _, session := range batch {
+			if len(sessions) < limit && filter.Match(session, now) {
+				sessions = append(sessions, session)
+			}
+		}
+
+		if len(ids) < limit {
+			break
+		}
+
+		// Moving the range bound past the last fetched id.
+		if sort.First != nil {
+			rangeBy.Min = "(" + ids[len(ids)-1]
+		} else {
+			rangeBy.Max = "(" + ids[len(ids)-1]
+		}
+	}
+
+	sessions, info := domain.NewPage(sessions, sort, func(s domain.UserSession) ksuid.KSUID { return s.Id })
+
+	return sessions, info, nil
+}
+
+// Getting user sessions by ids. Ids of expired sessions are removed from the set.
+func (r *SessionRepository) getSessions(ctx context.Context, key string, ids []string) ([]domain.UserSession, error) {
+	if len(ids) == 0 {
+		return []domain.UserSession{}, nil
+	}
+
+	pipe := r.client.Pipeline()
+
+	cmds := make([]*goredis.StringStringMapCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, sessionKey+id)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	sessions := make([]domain.UserSession, 0, len(ids))
+
+	var expired []interface{}
+
+	for i, cmd := range cmds {
+		if len(cmd.Val()) == 0 {
+			expired = append(expired, ids[i])
+			continue
+		}
+
+		id, err := ksuid.Parse(ids[i])
+		if err != nil {
+			return nil, err
+		}
+
+		session, err := parseSession(id, cmd.Val())
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	// Removing expired session ids.
+	if len(expired) != 0 {
+		if err := r.client.ZRem(ctx, key, expired...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return sessions, nil
+}
+
+// Deleting a user session.
+func (r *SessionRepository) Delete(ctx context.Context, userId, id ksuid.KSUID, outbox domain.Outbox) error {
+	key := sessionKey + id.String()
+
+	last, err := reserveSequence(ctx, r.client, len(outbox.Events))
+	if err != nil {
+		return err
+	}
+
+	// Deleting the session only if it belongs to the user.
+	return r.client.Watch(ctx, func(tx *goredis.Tx) error {
+		owner, err := tx.HGet(ctx, key, "user_id").Result()
+		if err == goredis.Nil || (err == nil && owner != userId.String()) {
+			return &domain.Error{Code: domain.CodeNotFound, Message: "Session not found"}
+		} else if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			pipe.Del(ctx, key)
+			pipe.ZRem(ctx, userSessionsKey+userId.String(), id.String())
+
+			return addOutbox(ctx, pipe, outbox, last)
+		})
+
+		return err
+	}, key)
+}
+
+// Getting total user session count.
+func (r *SessionRepository) GetTotalCount(ctx context.Context, userId ksuid.KSUID) (int32, error) {
+	key := userSessionsKey + userId.String()
+
+	ids, err := r.client.ZRange(ctx, key, 0, -1).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// Getting sessions removes expired session ids.
+	sessions, err := r.getSessions(ctx, key, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(len(sessions)), nil
+}
+
+// Parsing a stored user session.
+func parseSession(id ksuid.KSUID, values map[string]string) (domain.UserSession, error) {
+	userId, err := ksuid.Parse(values["user_id"])
+	if err != nil {
+		return domain.UserSession{}, err
+	}
+
+	expiresIn, err := parseTime(values["expires_in"])
+	if err != nil {
+		return domain.UserSession{}, err
+	}
+
+	session := domain.UserSession{
+		Id:         id,
+		UserId:     userId,
+		Payload:    values["payload"],
+		Ip:         values["ip"],
+		ExpiresIn:  expiresIn,
+		DeviceType: values["device_type"],
+		DeviceName: values["device_name"],
+		Country:    values["country"],
+		City:       values["city"],
+		// Sessions stored without the authentication were authenticated when they were created.
+		AuthLevel: 1,
+		AuthTime:  id.Time(),
+	}
+
+	if value, ok := values["auth_level"]; ok {
+		if session.AuthLevel, err = strconv.Atoi(value); err != nil {
+			return domain.UserSession{}, err
+		}
+
+		if session.AuthTime, err = parseTime(values["auth_time"]); err != nil {
+			return domain.UserSession{}, err
+		}
+
+		if methods := values["auth_methods"]; methods != "" {
+			session.AuthMethods = strings.Split(methods, ",")
+		}
+	}
+
+	return session, nil
+}
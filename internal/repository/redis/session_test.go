@@ -0,0 +1,204 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/repository/redis"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/segmentio/ksuid"
+)
+
+// Creating a new redis repository on the local redis stand-in.
+func newRepository(t *testing.T) (*redis.RedisRepository, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+
+	repos := redis.NewRedisRepositoryWithClient(goredis.NewClient(&goredis.Options{Addr: server.Addr()}))
+	t.Cleanup(repos.Close)
+
+	return repos, server
+}
+
+// Testing user sessions lifecycle.
+func TestSessionRepository(t *testing.T) {
+	repos, server := newRepository(t)
+	ctx := context.Background()
+
+	userId := ksuid.New()
+
+	devices := []string{"mobile", "desktop", "mobile"}
+
+	sessions := make([]domain.UserSession, 3)
+	for i := range sessions {
+		sessions[i] = domain.UserSession{
+			Id:         ksuid.New(),
+			UserId:     userId,
+			Payload:    "payload",
+			Ip:         "127.0.0.1",
+			ExpiresIn:  time.Now().Add(time.Hour * time.Duration(i+1)).Truncate(time.Millisecond),
+			DeviceType: devices[i],
+			AuthLevel:  1,
+			AuthTime:   time.Now().Truncate(time.Millisecond),
+		}
+
+		if err := repos.Session.Create(ctx, sessions[i], domain.Outbox{}); err != nil {
+			t.Fatalf("error creating a new user session: %s", err.Error())
+		}
+	}
+
+	// Getting a user session.
+	got, err := repos.Session.Get(ctx, userId, sessions[0].Id)
+	if err != nil {
+		t.Fatalf("error getting a user session: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(got, sessions[0]) {
+		t.Errorf("error user session: got %v, want %v", got, sessions[0])
+	}
+
+	// Getting a session of another user.
+	if _, err := repos.Session.Get(ctx, ksuid.New(), sessions[0].Id); err == nil {
+		t.Error("error getting another user session: got nil error")
+	}
+
+	// Testing args.
+	type args struct {
+		filter domain.SessionFilter
+		sort   domain.SortOptions
+	}
+
+	first, last, one := int32(2), int32(2), int32(1)
+
+	// Tests structures.
+	tests := []struct {
+		name     string
+		args     args
+		want     []domain.UserSession
+		wantNext bool
+		wantPrev bool
+	}{
+		{
+			name:     "First",
+			args:     args{sort: domain.SortOptions{First: &first}},
+			want:     sortedSessions(sessions)[:2],
+			wantNext: true,
+		},
+		{
+			name:     "Last",
+			args:     args{sort: domain.SortOptions{Last: &last}},
+			want:     sortedSessions(sessions)[1:],
+			wantPrev: true,
+		},
+		{
+			name:     "First after",
+			args:     args{sort: domain.SortOptions{First: &first, After: sortedSessions(sessions)[1].Id}},
+			want:     sortedSessions(sessions)[2:],
+			wantPrev: true,
+		},
+		{
+			name: "Filtered",
+			args: args{
+				filter: domain.SessionFilter{DeviceType: "desktop"},
+				sort:   domain.SortOptions{First: &one},
+			},
+			want: sessions[1:2],
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, info, err := repos.Session.GetList(ctx, userId, tt.args.filter, tt.args.sort)
+			if err != nil {
+				t.Fatalf("error getting user sessions: %s", err.Error())
+			}
+
+			if info.HasNextPage != tt.wantNext || info.HasPreviousPage != tt.wantPrev {
+				t.Errorf("error page info: got %+v, want next %t, previous %t", info, tt.wantNext, tt.wantPrev)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("error user sessions: got %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i].Id != tt.want[i].Id {
+					t.Errorf("error user session %d: got %s, want %s", i, got[i].Id, tt.want[i].Id)
+				}
+			}
+		})
+	}
+
+	// Deleting a session of another user.
+	if err := repos.Session.Delete(ctx, ksuid.New(), sessions[1].Id, domain.Outbox{}); !isNotFound(err) {
+		t.Fatalf("error deleting a session of another user: got %v", err)
+	}
+
+	// Deleting a user session.
+	if err := repos.Session.Delete(ctx, userId, sessions[1].Id, domain.Outbox{}); err != nil {
+		t.Fatalf("error deleting a user session: %s", err.Error())
+	}
+
+	// Deleting a missing user session.
+	if err := repos.Session.Delete(ctx, userId, sessions[1].Id, domain.Outbox{}); !isNotFound(err) {
+		t.Fatalf("error deleting a missing user session: got %v", err)
+	}
+
+	// Expiring the first session.
+	server.FastForward(time.Hour + time.Minute)
+
+	count, err := repos.Session.GetTotalCount(ctx, userId)
+	if err != nil {
+		t.Fatalf("error getting total user session count: %s", err.Error())
+	}
+
+	if count != 1 {
+		t.Errorf("error total user session count: got %d, want %d", count, 1)
+	}
+}
+
+// Getting sessions sorted by id.
+func sortedSessions(sessions []domain.UserSession) []domain.UserSession {
+	sorted := append([]domain.UserSession(nil), sessions...)
+
+	for i := range sorted {
+		for j := i + 1; j < len(sorted); j++ {
+			if ksuid.Compare(sorted[j].Id, sorted[i].Id) < 0 {
+				sorted[i], sorted[j] = sorted[j], sorted[i]
+			}
+		}
+	}
+
+	return sorted
+}
+
+// Checking for a not found domain error.
+func isNotFound(err error) bool {
+	var domainErr *domain.Error
+	return errors.As(err, &domainErr) && domainErr.Code == domain.CodeNotFound
+}
@@ -0,0 +1,573 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package redis
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/ksuid"
+)
+
+// Webhook not found error.
+var errWebhookNotFound = &domain.Error{Code: domain.CodeNotFound, Message: "Webhook not found"}
+
+// Counting a failed delivery attempt of the enabled webhook hash. The webhook is disabled when
+// failures reach the limit, returns 1 when it is disabled by this failure.
+var webhookFailScript = goredis.NewScript(`
+if redis.call('HGET', KEYS[1], 'enabled') ~= '1' then
+	return 0
+end
+local failures = redis.call('HINCRBY', KEYS[1], 'failures', 1)
+if failures >= tonumber(ARGV[1]) then
+	redis.call('HSET', KEYS[1], 'enabled', '0', 'disabled_at', ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// Setting fields of an existing hash, returns 0 when the hash does not exist.
+var hashUpdateScript = goredis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[1], unpack(ARGV))
+return 1
+`)
+
+// Webhook redis repository structure. Webhook ids are kept in a sorted set ordered by id.
+type WebhookRepository struct{ client goredis.UniversalClient }
+
+// Creating a new webhook redis repository.
+func NewWebhookRepository(client goredis.UniversalClient) *WebhookRepository {
+	return &WebhookRepository{client: client}
+}
+
+// Creating a new webhook.
+func (r *WebhookRepository) Create(ctx context.Context, webhook domain.Webhook) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, webhookKey+webhook.Id.String(),
+			"url", webhook.URL,
+			"event_types", formatEventTypes(webhook.EventTypes),
+			"secret", webhook.Secret,
+			"enabled", formatBool(webhook.Enabled),
+			"failures", 0,
+			"created_at", formatTime(webhook.CreatedAt),
+			"disabled_at", "",
+		)
+		pipe.ZAdd(ctx, webhooksKey, &goredis.Z{Member: webhook.Id.String()})
+
+		return nil
+	})
+
+	return err
+}
+
+// Getting a webhook by id.
+func (r *WebhookRepository) Get(ctx context.Context, id ksuid.KSUID) (domain.Webhook, error) {
+	values, err := r.client.HGetAll(ctx, webhookKey+id.String()).Result()
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	if len(values) == 0 {
+		return domain.Webhook{}, errWebhookNotFound
+	}
+
+	return parseWebhook(id, values)
+}
+
+// Getting all webhooks ordered by id.
+func (r *WebhookRepository) GetList(ctx context.Context) ([]domain.Webhook, error) {
+	return r.list(ctx, func(domain.Webhook) bool { return true })
+}
+
+// Deleting a webhook with its deliveries.
+func (r *WebhookRepository) Delete(ctx context.Context, id ksuid.KSUID) error {
+	key := webhookDeliveriesKey + id.String()
+
+	ids, err := r.client.ZRange(ctx, key, 0, -1).Result()
+	if err != nil {
+		return err
+	}
+
+	cmds, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Del(ctx, webhookKey+id.String())
+		pipe.ZRem(ctx, webhooksKey, id.String())
+		pipe.Del(ctx, key)
+
+		for _, deliveryId := range ids {
+			pipe.Del(ctx, webhookDeliveryKey+deliveryId)
+			pipe.ZRem(ctx, webhookDeliveryDueKey, deliveryId)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if cmds[0].(*goredis.IntCmd).Val() == 0 {
+		return errWebhookNotFound
+	}
+
+	return nil
+}
+
+// Enabling a webhook and resetting its consecutive failures.
+func (r *WebhookRepository) Enable(ctx context.Context, id ksuid.KSUID) error {
+	ok, err := hashUpdateScript.Run(ctx, r.client, []string{webhookKey + id.String()},
+		"enabled", "1", "failures", 0, "disabled_at", "").Bool()
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errWebhookNotFound
+	}
+
+	return nil
+}
+
+// Resetting consecutive failures of the webhook after a successful delivery.
+func (r *WebhookRepository) Succeed(ctx context.Context, id ksuid.KSUID) error {
+	return hashUpdateScript.Run(ctx, r.client, []string{webhookKey + id.String()}, "failures", 0).Err()
+}
+
+// Counting a failed delivery attempt of the enabled webhook.
+func (r *WebhookRepository) Fail(ctx context.Context, id ksuid.KSUID, limit int32) (bool, error) {
+	return webhookFailScript.Run(ctx, r.client, []string{webhookKey + id.String()},
+		limit, formatTime(time.Now())).Bool()
+}
+
+// Getting matched webhooks ordered by id.
+func (r *WebhookRepository) list(ctx context.Context, match func(domain.Webhook) bool) ([]domain.Webhook, error) {
+	ids, err := r.client.ZRange(ctx, webhooksKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := r.client.Pipeline()
+
+	cmds := make([]*goredis.StringStringMapCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, webhookKey+id)
+	}
+
+	if len(ids) != 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var webhooks []domain.Webhook
+
+	for i, cmd := range cmds {
+		if len(cmd.Val()) == 0 {
+			continue
+		}
+
+		id, err := ksuid.Parse(ids[i])
+		if err != nil {
+			return nil, err
+		}
+
+		webhook, err := parseWebhook(id, cmd.Val())
+		if err != nil {
+			return nil, err
+		}
+
+		if match(webhook) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	return webhooks, nil
+}
+
+// Webhook delivery redis repository structure. Due pending deliveries are kept in a sorted
+// set by next attempt time, webhook deliveries log in a sorted set ordered by id.
+type WebhookDeliveryRepository struct{ client goredis.UniversalClient }
+
+// Creating a new webhook delivery redis repository.
+func NewWebhookDeliveryRepository(client goredis.UniversalClient) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{client: client}
+}
+
+// Creating pending webhook deliveries.
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, deliveries ...domain.WebhookDelivery) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		addDeliveries(ctx, pipe, deliveries)
+		return nil
+	})
+
+	return err
+}
+
+// Adding pending webhook deliveries to the pipeline.
+func addDeliveries(ctx context.Context, pipe goredis.Pipeliner, deliveries []domain.WebhookDelivery) {
+	now := time.Now()
+
+	for _, delivery := range deliveries {
+		id := delivery.Id.String()
+
+		pipe.HSet(ctx, webhookDeliveryKey+id,
+			"webhook_id", delivery.WebhookId.String(),
+			"event_id", delivery.EventId.String(),
+			"event_type", string(delivery.EventType),
+			"payload", delivery.Payload,
+			"status", string(domain.WebhookDeliveryPending),
+			"attempts", 0,
+			"response_code", 0,
+			"last_error", "",
+			"created_at", formatTime(now),
+			"updated_at", formatTime(now),
+		)
+		pipe.ZAdd(ctx, webhookDeliveryDueKey, &goredis.Z{Score: score(now), Member: id})
+		pipe.ZAdd(ctx, webhookDeliveriesKey+delivery.WebhookId.String(), &goredis.Z{Member: id})
+	}
+}
+
+// Staging auth domain events of the webhook deliveries.
+func (r *WebhookDeliveryRepository) CreateEvents(ctx context.Context, events ...domain.WebhookEvent) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		addWebhookEvents(ctx, pipe, events)
+		return nil
+	})
+
+	return err
+}
+
+// Creating pending deliveries of the staged auth domain events. Events are popped from the
+// staged set, so other dispatchers skip them, and put back when the fan out fails.
+func (r *WebhookDeliveryRepository) Fanout(ctx context.Context, limit int32) error {
+	popped, err := r.client.ZPopMin(ctx, webhookEventsKey, int64(limit)).Result()
+	if err != nil || len(popped) == 0 {
+		return err
+	}
+
+	if err := r.fanout(ctx, popped); err != nil {
+		if err := r.client.ZAdd(ctx, webhookEventsKey, zPointers(popped)...).Err(); err != nil {
+			log.Error().Err(err).Msg("failed to restore staged webhook events")
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Creating pending deliveries of the popped staged auth domain events.
+func (r *WebhookDeliveryRepository) fanout(ctx context.Context, popped []goredis.Z) error {
+	pipe := r.client.Pipeline()
+
+	cmds := make([]*goredis.StringStringMapCmd, len(popped))
+	for i, z := range popped {
+		cmds[i] = pipe.HGetAll(ctx, webhookEventKey+z.Member.(string))
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	webhooks, err := (&WebhookRepository{client: r.client}).list(ctx, func(w domain.Webhook) bool { return w.Enabled })
+	if err != nil {
+		return err
+	}
+
+	var deliveries []domain.WebhookDelivery
+
+	for i, cmd := range cmds {
+		if len(cmd.Val()) == 0 {
+			continue
+		}
+
+		eventId, err := ksuid.Parse(popped[i].Member.(string))
+		if err != nil {
+			return err
+		}
+
+		typ := domain.EventType(cmd.Val()["event_type"])
+
+		for _, webhook := range webhooks {
+			if webhook.Subscribed(typ) {
+				deliveries = append(deliveries, domain.WebhookDelivery{
+					Id:        ksuid.New(),
+					WebhookId: webhook.Id,
+					EventId:   eventId,
+					EventType: typ,
+					Payload:   []byte(cmd.Val()["payload"]),
+				})
+			}
+		}
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		addDeliveries(ctx, pipe, deliveries)
+
+		for _, z := range popped {
+			pipe.Del(ctx, webhookEventKey+z.Member.(string))
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// Getting pointers of the sorted set members.
+func zPointers(members []goredis.Z) []*goredis.Z {
+	pointers := make([]*goredis.Z, len(members))
+	for i := range members {
+		pointers[i] = &members[i]
+	}
+
+	return pointers
+}
+
+// Claiming due pending deliveries.
+func (r *WebhookDeliveryRepository) Claim(ctx context.Context, limit int32, lease time.Duration) ([]domain.WebhookDelivery, error) {
+	now := time.Now()
+
+	ids, err := claimScript.Run(ctx, r.client, []string{webhookDeliveryDueKey},
+		score(now), limit, score(now.Add(lease)), webhookDeliveryKey).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.getDeliveries(ctx, ids)
+}
+
+// Updating the delivery attempt result.
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, delivery domain.WebhookDelivery, next time.Time) error {
+	id := delivery.Id.String()
+
+	if err := hashUpdateScript.Run(ctx, r.client, []string{webhookDeliveryKey + id},
+		"status", string(delivery.Status),
+		"response_code", delivery.ResponseCode,
+		"last_error", delivery.LastError,
+		"updated_at", formatTime(time.Now()),
+	).Err(); err != nil {
+		return err
+	}
+
+	// Finished deliveries are removed from the due set.
+	if delivery.Status != domain.WebhookDeliveryPending {
+		return r.client.ZRem(ctx, webhookDeliveryDueKey, id).Err()
+	}
+
+	return r.client.ZAddXX(ctx, webhookDeliveryDueKey, &goredis.Z{Score: score(next), Member: id}).Err()
+}
+
+// Getting a webhook deliveries list page ordered by id.
+func (r *WebhookDeliveryRepository) GetList(ctx context.Context, webhookId ksuid.KSUID, sort domain.SortOptions) ([]domain.WebhookDelivery, domain.PageInfo, error) {
+	rangeBy := &goredis.ZRangeBy{Min: "-", Max: "+", Count: int64(sort.Limit())}
+
+	// Added after sort option.
+	if sort.After != ksuid.Nil {
+		rangeBy.Min = "(" + sort.After.String()
+	}
+	// Added before sort option.
+	if sort.Before != ksuid.Nil {
+		rangeBy.Max = "(" + sort.Before.String()
+	}
+
+	var (
+		ids []string
+		err error
+	)
+
+	key := webhookDeliveriesKey + webhookId.String()
+
+	// One extra delivery is fetched to check the next page.
+	if sort.First != nil {
+		ids, err = r.client.ZRangeByLex(ctx, key, rangeBy).Result()
+	} else if sort.Last != nil {
+		ids, err = r.client.ZRevRangeByLex(ctx, key, rangeBy).Result()
+	}
+
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	deliveries, err := r.getDeliveries(ctx, ids)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	deliveries, info := domain.NewPage(deliveries, sort, func(d domain.WebhookDelivery) ksuid.KSUID { return d.Id })
+
+	return deliveries, info, nil
+}
+
+// Getting webhook deliveries by ids in the ids order.
+func (r *WebhookDeliveryRepository) getDeliveries(ctx context.Context, ids []string) ([]domain.WebhookDelivery, error) {
+	pipe := r.client.Pipeline()
+
+	cmds := make([]*goredis.StringStringMapCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, webhookDeliveryKey+id)
+	}
+
+	if len(ids) != 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	deliveries := make([]domain.WebhookDelivery, 0, len(ids))
+
+	for i, cmd := range cmds {
+		if len(cmd.Val()) == 0 {
+			continue
+		}
+
+		delivery, err := parseWebhookDelivery(ids[i], cmd.Val())
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+// Parsing a stored webhook.
+func parseWebhook(id ksuid.KSUID, values map[string]string) (domain.Webhook, error) {
+	failures, err := strconv.ParseInt(values["failures"], 10, 32)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	createdAt, err := parseTime(values["created_at"])
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	var disabledAt time.Time
+
+	if values["disabled_at"] != "" {
+		if disabledAt, err = parseTime(values["disabled_at"]); err != nil {
+			return domain.Webhook{}, err
+		}
+	}
+
+	return domain.Webhook{
+		Id:         id,
+		URL:        values["url"],
+		EventTypes: parseEventTypes(values["event_types"]),
+		Secret:     values["secret"],
+		Enabled:    values["enabled"] == "1",
+		Failures:   int32(failures),
+		CreatedAt:  createdAt,
+		DisabledAt: disabledAt,
+	}, nil
+}
+
+// Parsing a stored webhook delivery.
+func parseWebhookDelivery(id string, values map[string]string) (domain.WebhookDelivery, error) {
+	deliveryId, err := ksuid.Parse(id)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	webhookId, err := ksuid.Parse(values["webhook_id"])
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	eventId, err := ksuid.Parse(values["event_id"])
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	attempts, err := strconv.ParseInt(values["attempts"], 10, 32)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	responseCode, err := strconv.ParseInt(values["response_code"], 10, 32)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	createdAt, err := parseTime(values["created_at"])
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	updatedAt, err := parseTime(values["updated_at"])
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	return domain.WebhookDelivery{
+		Id:           deliveryId,
+		WebhookId:    webhookId,
+		EventId:      eventId,
+		EventType:    domain.EventType(values["event_type"]),
+		Payload:      []byte(values["payload"]),
+		Status:       domain.WebhookDeliveryStatus(values["status"]),
+		Attempts:     int32(attempts),
+		ResponseCode: int32(responseCode),
+		LastError:    values["last_error"],
+		CreatedAt:    createdAt,
+		UpdatedAt:    updatedAt,
+	}, nil
+}
+
+// Formatting stored event types.
+func formatEventTypes(types []domain.EventType) string {
+	values := make([]string, len(types))
+	for i, typ := range types {
+		values[i] = string(typ)
+	}
+
+	return strings.Join(values, ",")
+}
+
+// Parsing stored event types.
+func parseEventTypes(value string) []domain.EventType {
+	if value == "" {
+		return nil
+	}
+
+	values := strings.Split(value, ",")
+
+	types := make([]domain.EventType, len(values))
+	for i, v := range values {
+		types[i] = domain.EventType(v)
+	}
+
+	return types
+}
+
+// Formatting a stored bool.
+func formatBool(value bool) string {
+	if value {
+		return "1"
+	}
+
+	return "0"
+}
@@ -18,14 +18,98 @@
 package repository
 
 import (
+	"fmt"
+
 	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/repository/memory"
 	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	"github.com/durudex/durudex-auth-service/internal/repository/redis"
+)
+
+// Storage driver names.
+const (
+	DriverPostgres string = "postgres"
+	DriverMemory   string = "memory"
+	DriverRedis    string = "redis"
 )
 
-// Repository structure.
-type Repository struct{ Postgres *postgres.PostgresRepository }
+// Repository structure. Repositories are provided by the storage driver, every driver
+// satisfies the postgres repository interfaces.
+type Repository struct {
+	Session         postgres.Session
+	Outbox          postgres.Outbox
+	Saga            postgres.Saga
+	Idempotency     postgres.Idempotency
+	Audit           postgres.Audit
+	EventOutbox     postgres.EventOutbox
+	Webhook         postgres.Webhook
+	WebhookDelivery postgres.WebhookDelivery
+	Risk            postgres.Risk
+	// Closing storage driver connections, nil for in-memory storage.
+	close func()
+}
+
+// Creating a new repository with the configured storage driver.
+func NewRepository(cfg config.DatabaseConfig) (*Repository, error) {
+	switch cfg.Driver {
+	case DriverPostgres, "":
+		repos, err := postgres.NewPostgresRepository(cfg.Postgres)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Repository{
+			Session:         repos.Session,
+			Outbox:          repos.Outbox,
+			Saga:            repos.Saga,
+			Idempotency:     repos.Idempotency,
+			Audit:           repos.Audit,
+			EventOutbox:     repos.EventOutbox,
+			Webhook:         repos.Webhook,
+			WebhookDelivery: repos.WebhookDelivery,
+			Risk:            repos.Risk,
+			close:           repos.Close,
+		}, nil
+	case DriverMemory:
+		repos := memory.NewMemoryRepository()
+
+		return &Repository{
+			Session:         repos.Session,
+			Outbox:          repos.Outbox,
+			Saga:            repos.Saga,
+			Idempotency:     repos.Idempotency,
+			Audit:           repos.Audit,
+			EventOutbox:     repos.EventOutbox,
+			Webhook:         repos.Webhook,
+			WebhookDelivery: repos.WebhookDelivery,
+			Risk:            repos.Risk,
+		}, nil
+	case DriverRedis:
+		repos, err := redis.NewRedisRepository(cfg.Redis)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Repository{
+			Session:         repos.Session,
+			Outbox:          repos.Outbox,
+			Saga:            repos.Saga,
+			Idempotency:     repos.Idempotency,
+			Audit:           repos.Audit,
+			EventOutbox:     repos.EventOutbox,
+			Webhook:         repos.Webhook,
+			WebhookDelivery: repos.WebhookDelivery,
+			Risk:            repos.Risk,
+			close:           repos.Close,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown database driver: %s", cfg.Driver)
+	}
+}
 
-// Creating a new repository.
-func NewRepository(config config.DatabaseConfig) *Repository {
-	return &Repository{Postgres: postgres.NewPostgresRepository(config.Postgres)}
+// Closing storage driver connections.
+func (r *Repository) Close() {
+	if r.close != nil {
+		r.close()
+	}
 }
@@ -0,0 +1,71 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/repository"
+)
+
+// Testing storage driver selection.
+func TestNewRepository(t *testing.T) {
+	// Testing args.
+	type args struct{ cfg config.DatabaseConfig }
+
+	// Tests structures.
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Memory",
+			args: args{cfg: config.DatabaseConfig{Driver: repository.DriverMemory}},
+		},
+		{
+			name:    "Redis unavailable",
+			args:    args{cfg: config.DatabaseConfig{Driver: repository.DriverRedis, Redis: config.RedisConfig{URL: "invalid"}}},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown",
+			args:    args{cfg: config.DatabaseConfig{Driver: "unknown"}},
+			wantErr: true,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos, err := repository.NewRepository(tt.args.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error creating repository: %v, want error %t", err, tt.wantErr)
+			}
+
+			if err == nil {
+				defer repos.Close()
+
+				if repos.Session == nil || repos.Outbox == nil || repos.Saga == nil || repos.Idempotency == nil {
+					t.Error("error repository: got nil repositories")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package risk
+
+import (
+	"context"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/metrics"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	"github.com/durudex/durudex-auth-service/pkg/geoip"
+
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/ksuid"
+)
+
+// Sign in risk assessor interface.
+type Assessor interface {
+	// Assessing the sign in attempt risk.
+	Assess(ctx context.Context, attempt domain.LoginAttempt) domain.RiskAssessment
+}
+
+// Sign in risk signal interface.
+type Signal interface {
+	// Evaluating the sign in attempt, not triggered signal has zero score.
+	Evaluate(ctx context.Context, attempt domain.LoginAttempt) (domain.RiskSignal, error)
+}
+
+// Sign in risk engine. Scores of the triggered signals are summed and compared with the
+// config thresholds, every assessment is recorded for the thresholds tuning.
+type Engine struct {
+	repos   postgres.Risk
+	cfg     config.RiskConfig
+	signals []Signal
+}
+
+// Creating a new sign in risk engine with the signals.
+func NewEngine(repos postgres.Risk, cfg config.RiskConfig, signals ...Signal) *Engine {
+	return &Engine{repos: repos, cfg: cfg, signals: signals}
+}
+
+// Creating a new sign in risk assessor with the configured signals. Every sign in is allowed
+// when the engine is disabled.
+func New(repos postgres.Risk, audit postgres.Audit, locator geoip.Locator, cfg config.RiskConfig) (Assessor, error) {
+	if !cfg.Enable {
+		return Nop{}, nil
+	}
+
+	var signals []Signal
+
+	// Adding signals with non-zero score.
+	if cfg.Velocity.Score != 0 {
+		signals = append(signals, NewVelocity(audit, cfg.Velocity))
+	}
+	if cfg.NewDevice.Score != 0 {
+		signals = append(signals, NewDevice(cfg.NewDevice))
+	}
+	if cfg.Travel.Score != 0 {
+		signals = append(signals, NewTravel(locator, cfg.Travel))
+	}
+	if cfg.Reputation.Score != 0 {
+		blocklist, err := LoadBlocklist(cfg.Reputation.Blocklist)
+		if err != nil {
+			return nil, err
+		}
+
+		signals = append(signals, NewReputation(blocklist, cfg.Reputation))
+	}
+	if cfg.TimeOfDay.Score != 0 {
+		signals = append(signals, NewTimeOfDay(cfg.TimeOfDay))
+	}
+
+	return NewEngine(repos, cfg, signals...), nil
+}
+
+// Assessing the sign in attempt risk. Failed signals and assessment records are logged
+// without failing the sign in.
+func (e *Engine) Assess(ctx context.Context, attempt domain.LoginAttempt) domain.RiskAssessment {
+	assessment := domain.RiskAssessment{
+		Id:        ksuid.New(),
+		UserId:    attempt.Session.UserId,
+		SessionId: attempt.Session.Id,
+		Ip:        attempt.Session.Ip,
+		UserAgent: attempt.UserAgent,
+		Signals:   []domain.RiskSignal{},
+		CreatedAt: attempt.Time,
+	}
+
+	for _, signal := range e.signals {
+		result, err := signal.Evaluate(ctx, attempt)
+		if err != nil {
+			log.Error().Err(err).Str("user", attempt.Session.UserId.String()).Msg("failed to evaluate risk signal")
+			continue
+		}
+
+		// Not triggered signals are not recorded.
+		if result.Score == 0 {
+			continue
+		}
+
+		assessment.Score += result.Score
+		assessment.Signals = append(assessment.Signals, result)
+
+		metrics.RiskSignalsTotal.WithLabelValues(result.Name).Inc()
+	}
+
+	assessment.Decision = e.decide(assessment.Score)
+
+	metrics.RiskDecisionsTotal.WithLabelValues(string(assessment.Decision)).Inc()
+
+	if assessment.Decision != domain.RiskAllow {
+		log.Info().Str("user", assessment.UserId.String()).Str("ip", assessment.Ip).Int("score", assessment.Score).
+			Str("decision", string(assessment.Decision)).Msg("risky sign in")
+	}
+
+	// Recording the assessment.
+	if err := e.repos.Create(ctx, assessment); err != nil {
+		log.Error().Err(err).Str("user", assessment.UserId.String()).Msg("failed to record risk assessment")
+	}
+
+	return assessment
+}
+
+// Getting the sign in decision by the total score.
+func (e *Engine) decide(score int) domain.RiskDecision {
+	switch {
+	case e.cfg.BlockScore > 0 && score >= e.cfg.BlockScore:
+		return domain.RiskBlock
+	case e.cfg.ChallengeScore > 0 && score >= e.cfg.ChallengeScore:
+		return domain.RiskChallenge
+	default:
+		return domain.RiskAllow
+	}
+}
+
+// Sign in risk assessor allowing every sign in without recording.
+type Nop struct{}
+
+// Allowing the sign in attempt.
+func (Nop) Assess(_ context.Context, attempt domain.LoginAttempt) domain.RiskAssessment {
+	return domain.RiskAssessment{
+		UserId:    attempt.Session.UserId,
+		SessionId: attempt.Session.Id,
+		Decision:  domain.RiskAllow,
+		CreatedAt: attempt.Time,
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package risk_test
+
+import (
+	"context"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/repository/memory"
+	"github.com/durudex/durudex-auth-service/internal/risk"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Static risk signal.
+type static domain.RiskSignal
+
+// Evaluating the static signal.
+func (s static) Evaluate(context.Context, domain.LoginAttempt) (domain.RiskSignal, error) {
+	return domain.RiskSignal(s), nil
+}
+
+// Testing sign in risk decisions by the total score.
+func TestEngine_Assess(t *testing.T) {
+	cfg := config.RiskConfig{Enable: true, ChallengeScore: 50, BlockScore: 100}
+
+	// Tests structures.
+	tests := []struct {
+		name    string
+		signals []risk.Signal
+		want    domain.RiskDecision
+	}{
+		{name: "No signals", want: domain.RiskAllow},
+		{
+			name:    "Below challenge score",
+			signals: []risk.Signal{static{Name: "a", Score: 30}, static{Name: "b"}},
+			want:    domain.RiskAllow,
+		},
+		{
+			name:    "Challenge score",
+			signals: []risk.Signal{static{Name: "a", Score: 30}, static{Name: "b", Score: 20}},
+			want:    domain.RiskChallenge,
+		},
+		{
+			name:    "Block score",
+			signals: []risk.Signal{static{Name: "a", Score: 60}, static{Name: "b", Score: 60}},
+			want:    domain.RiskBlock,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := memory.NewMemoryRepository()
+			userId := ksuid.New()
+
+			got := risk.NewEngine(repos.Risk, cfg, tt.signals...).Assess(context.Background(), domain.LoginAttempt{
+				Session: domain.UserSession{Id: ksuid.New(), UserId: userId, Ip: "127.0.0.1"},
+				Time:    time.Now(),
+			})
+
+			if got.Decision != tt.want {
+				t.Errorf("error decision: got %s, want %s", got.Decision, tt.want)
+			}
+
+			// Not triggered signals are not recorded.
+			for _, signal := range got.Signals {
+				if signal.Score == 0 {
+					t.Errorf("error not triggered signal is recorded: %v", signal)
+				}
+			}
+
+			last := int32(1)
+
+			recorded, _, err := repos.Risk.GetList(context.Background(), userId, domain.SortOptions{Last: &last})
+			if err != nil {
+				t.Fatalf("error getting risk assessments: %s", err.Error())
+			}
+
+			if len(recorded) != 1 || !reflect.DeepEqual(recorded[0], got) {
+				t.Errorf("error recorded assessment: got %v, want %v", recorded, got)
+			}
+		})
+	}
+}
+
+// Testing loading an ip blocklist file.
+func TestLoadBlocklist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocklist.txt")
+
+	data := "# Blocked networks.\n203.0.113.0/24\n\n198.51.100.7 # Single address.\n2001:db8::1/32\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("error writing blocklist: %s", err.Error())
+	}
+
+	got, err := risk.LoadBlocklist(path)
+	if err != nil {
+		t.Fatalf("error loading blocklist: %s", err.Error())
+	}
+
+	want := []netip.Prefix{
+		netip.MustParsePrefix("203.0.113.0/24"),
+		netip.MustParsePrefix("198.51.100.7/32"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("error blocklist: got %v, want %v", got, want)
+	}
+
+	// Invalid lines are rejected.
+	if err := os.WriteFile(path, []byte("203.0.113.0/33\n"), 0o600); err != nil {
+		t.Fatalf("error writing blocklist: %s", err.Error())
+	}
+
+	if _, err := risk.LoadBlocklist(path); err == nil {
+		t.Error("error expected invalid blocklist error")
+	}
+}
+
+// Testing the time of day signal.
+func TestTimeOfDay_Evaluate(t *testing.T) {
+	signal := risk.NewTimeOfDay(config.RiskTimeOfDayConfig{Window: 2, MinSessions: 2, Score: 20})
+
+	day := time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	// Creating recent sessions at the hours of the day.
+	sessions := func(hours ...int) []domain.UserSession {
+		recent := make([]domain.UserSession, len(hours))
+		for i, hour := range hours {
+			recent[i].Id, _ = ksuid.NewRandomWithTime(day.Add(time.Duration(hour) * time.Hour))
+		}
+
+		return recent
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name   string
+		recent []domain.UserSession
+		hour   int
+		want   int
+	}{
+		{name: "Not enough sessions", recent: sessions(10), hour: 22},
+		{name: "Usual hour", recent: sessions(9, 10), hour: 12},
+		{name: "Usual hour across midnight", recent: sessions(23, 22), hour: 1},
+		{name: "Unusual hour", recent: sessions(9, 10), hour: 3, want: 20},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := signal.Evaluate(context.Background(), domain.LoginAttempt{
+				Recent: tt.recent,
+				Time:   day.Add(time.Duration(24+tt.hour) * time.Hour),
+			})
+			if err != nil {
+				t.Fatalf("error evaluating signal: %s", err.Error())
+			}
+
+			if got.Score != tt.want {
+				t.Errorf("error score: got %d, want %d", got.Score, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package risk
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/netip"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	"github.com/durudex/durudex-auth-service/pkg/geoip"
+)
+
+// Sign in risk signal names.
+const (
+	SignalVelocity   string = "velocity"
+	SignalNewDevice  string = "new_device"
+	SignalTravel     string = "travel"
+	SignalReputation string = "reputation"
+	SignalTimeOfDay  string = "time_of_day"
+)
+
+// Minimum travel distance in km, closer locations are within the GeoIP database accuracy.
+const minTravelDistance float64 = 100
+
+// Failed sign in attempts velocity signal. Failed attempts are read from the auth audit log
+// by the username.
+type Velocity struct {
+	audit postgres.Audit
+	cfg   config.RiskVelocityConfig
+}
+
+// Creating a new failed sign in attempts velocity signal.
+func NewVelocity(audit postgres.Audit, cfg config.RiskVelocityConfig) *Velocity {
+	return &Velocity{audit: audit, cfg: cfg}
+}
+
+// Evaluating failed sign in attempts within the window.
+func (v *Velocity) Evaluate(ctx context.Context, attempt domain.LoginAttempt) (domain.RiskSignal, error) {
+	signal := domain.RiskSignal{Name: SignalVelocity}
+
+	last := v.cfg.MaxFailures
+	if last <= 0 {
+		return signal, nil
+	}
+
+	// Getting the most recent failed sign in attempts.
+	events, _, err := v.audit.GetList(ctx,
+		domain.AuditFilter{Subject: attempt.Username, Kind: domain.AuditSignIn, Outcome: domain.AuditFailure},
+		domain.SortOptions{Last: &last})
+	if err != nil {
+		return signal, err
+	}
+
+	since := attempt.Time.Add(-v.cfg.Window)
+
+	var failures int32
+
+	for _, event := range events {
+		if event.CreatedAt.After(since) {
+			failures++
+		}
+	}
+
+	if failures >= v.cfg.MaxFailures {
+		signal.Score = v.cfg.Score
+		signal.Detail = fmt.Sprintf("%d failed attempts within %s", failures, v.cfg.Window)
+	}
+
+	return signal, nil
+}
+
+// New device signal. Device is new when no recent session has the same device fingerprint.
+type Device struct{ cfg config.RiskNewDeviceConfig }
+
+// Creating a new device signal.
+func NewDevice(cfg config.RiskNewDeviceConfig) *Device {
+	return &Device{cfg: cfg}
+}
+
+// Evaluating the sign in device.
+func (d *Device) Evaluate(_ context.Context, attempt domain.LoginAttempt) (domain.RiskSignal, error) {
+	signal := domain.RiskSignal{Name: SignalNewDevice}
+
+	if domain.CheckLogin(attempt.Session, attempt.Recent, 0, 0).NewDevice {
+		signal.Score = d.cfg.Score
+		signal.Detail = attempt.Session.Fingerprint()
+	}
+
+	return signal, nil
+}
+
+// Impossible travel signal. Speed is the distance between the recent session and sign in
+// locations divided by the time since the session was created.
+type Travel struct {
+	geo geoip.Locator
+	cfg config.RiskTravelConfig
+}
+
+// Creating a new impossible travel signal.
+func NewTravel(locator geoip.Locator, cfg config.RiskTravelConfig) *Travel {
+	return &Travel{geo: locator, cfg: cfg}
+}
+
+// Evaluating the travel speed from the recent sessions.
+func (t *Travel) Evaluate(_ context.Context, attempt domain.LoginAttempt) (domain.RiskSignal, error) {
+	signal := domain.RiskSignal{Name: SignalTravel}
+
+	to, err := t.geo.Locate(attempt.Session.Ip)
+	if err != nil {
+		return signal, err
+	}
+
+	// Locations without coordinates are not assessed.
+	if !to.HasCoordinates() {
+		return signal, nil
+	}
+
+	checked := map[string]bool{attempt.Session.Ip: true}
+
+	for _, session := range attempt.Recent {
+		if checked[session.Ip] {
+			continue
+		}
+
+		checked[session.Ip] = true
+
+		from, err := t.geo.Locate(session.Ip)
+		if err != nil {
+			return signal, err
+		}
+
+		if !from.HasCoordinates() {
+			continue
+		}
+
+		distance := geoip.Distance(from, to)
+		if distance < minTravelDistance {
+			continue
+		}
+
+		// Speed of the sign in within the same second is infinite.
+		createdAt := session.Id.Time()
+		speed := distance / attempt.Time.Sub(createdAt).Hours()
+
+		if speed < 0 || speed > t.cfg.MaxSpeed {
+			signal.Score = t.cfg.Score
+			signal.Detail = fmt.Sprintf("%.0f km from %s %s since %s", distance, from.Country, from.City,
+				createdAt.UTC().Format(time.RFC3339))
+
+			break
+		}
+	}
+
+	return signal, nil
+}
+
+// Ip reputation signal. Sign in ip address is checked against the local blocklist.
+type Reputation struct {
+	blocklist []netip.Prefix
+	cfg       config.RiskReputationConfig
+}
+
+// Creating a new ip reputation signal with the blocklist.
+func NewReputation(blocklist []netip.Prefix, cfg config.RiskReputationConfig) *Reputation {
+	return &Reputation{blocklist: blocklist, cfg: cfg}
+}
+
+// Evaluating the sign in ip address reputation.
+func (r *Reputation) Evaluate(_ context.Context, attempt domain.LoginAttempt) (domain.RiskSignal, error) {
+	signal := domain.RiskSignal{Name: SignalReputation}
+
+	addr, err := netip.ParseAddr(attempt.Session.Ip)
+	if err != nil {
+		return signal, nil
+	}
+
+	addr = addr.Unmap()
+
+	for _, prefix := range r.blocklist {
+		if prefix.Contains(addr) {
+			signal.Score = r.cfg.Score
+			signal.Detail = "blocklisted " + prefix.String()
+
+			break
+		}
+	}
+
+	return signal, nil
+}
+
+// Loading an ip blocklist file. Every line is an ip address or network, text after "#" is a
+// comment. Empty path is an empty blocklist.
+func LoadBlocklist(path string) ([]netip.Prefix, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var blocklist []netip.Prefix
+
+	scanner := bufio.NewScanner(file)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+
+		// Removing the line comment.
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		prefix, err := parsePrefix(line)
+		if err != nil {
+			return nil, fmt.Errorf("blocklist %s line %d: %w", path, n, err)
+		}
+
+		blocklist = append(blocklist, prefix)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return blocklist, nil
+}
+
+// Parsing an ip address or network prefix.
+func parsePrefix(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	addr = addr.Unmap()
+
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// Time of day signal. Sign in hour is compared with the creation hours of the recent sessions
+// in UTC.
+type TimeOfDay struct{ cfg config.RiskTimeOfDayConfig }
+
+// Creating a new time of day signal.
+func NewTimeOfDay(cfg config.RiskTimeOfDayConfig) *TimeOfDay {
+	return &TimeOfDay{cfg: cfg}
+}
+
+// Evaluating the sign in hour.
+func (d *TimeOfDay) Evaluate(_ context.Context, attempt domain.LoginAttempt) (domain.RiskSignal, error) {
+	signal := domain.RiskSignal{Name: SignalTimeOfDay}
+
+	// Users without enough sessions have no usual sign in hours.
+	if len(attempt.Recent) < d.cfg.MinSessions || len(attempt.Recent) == 0 {
+		return signal, nil
+	}
+
+	hour := attempt.Time.UTC().Hour()
+
+	for _, session := range attempt.Recent {
+		diff := hour - session.Id.Time().UTC().Hour()
+		if diff < 0 {
+			diff = -diff
+		}
+
+		// Hours are compared on the 24 hour circle.
+		if diff > 12 {
+			diff = 24 - diff
+		}
+
+		if diff <= d.cfg.Window {
+			return signal, nil
+		}
+	}
+
+	signal.Score = d.cfg.Score
+	signal.Detail = fmt.Sprintf("sign in at %02d:00 UTC", hour)
+
+	return signal, nil
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package saga
+
+import (
+	"context"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/recovery"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	"github.com/durudex/durudex-auth-service/internal/service"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Sign up saga recovery worker. Claims sagas left in progress by crashed or timed out calls
+// and finishes or compensates them.
+type Recoverer struct {
+	repos  postgres.Saga
+	signUp service.SignUp
+	cfg    config.SagaConfig
+	done   chan struct{}
+	stop   chan struct{}
+}
+
+// Creating a new sign up saga recovery worker.
+func NewRecoverer(repos postgres.Saga, signUp service.SignUp, cfg config.SagaConfig) *Recoverer {
+	return &Recoverer{
+		repos:  repos,
+		signUp: signUp,
+		cfg:    cfg,
+		done:   make(chan struct{}),
+		stop:   make(chan struct{}),
+	}
+}
+
+// Running sign up saga recovery worker.
+func (r *Recoverer) Run() {
+	log.Info().Msg("Running sign up saga recoverer...")
+
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			recovery.Do("sign up saga recoverer", func() {
+				if err := r.Recover(context.Background()); err != nil {
+					log.Error().Err(err).Msg("failed to recover sign up sagas")
+				}
+			})
+		}
+	}
+}
+
+// Stopping sign up saga recovery worker. Waits for the current batch.
+func (r *Recoverer) Stop() {
+	log.Info().Msg("Stopping sign up saga recoverer...")
+
+	close(r.stop)
+	<-r.done
+}
+
+// Recovering a batch of stale sign up sagas.
+func (r *Recoverer) Recover(ctx context.Context) error {
+	sagas, err := r.repos.ClaimStale(ctx, time.Now().Add(-r.cfg.StaleAfter), r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		return err
+	}
+
+	for _, saga := range sagas {
+		// Failed saga is claimed again after the lease.
+		if err := r.signUp.Recover(ctx, saga); err != nil {
+			log.Error().Err(err).Str("saga", saga.Id.String()).Msg("failed to recover sign up saga")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/durudex/durudex-auth-service/internal/audit"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+
+	"google.golang.org/grpc/status"
+)
+
+// Auth audit service interface.
+type Audit interface {
+	audit.Recorder
+	// Getting filtered auth audit events list page ordered by id.
+	GetList(ctx context.Context, filter domain.AuditFilter, sort domain.SortOptions) ([]domain.AuditEvent, domain.PageInfo, error)
+}
+
+// Auth audit service structure.
+type AuditService struct {
+	audit.Recorder
+	repos postgres.Audit
+	// Maximum number of events in the list page.
+	maxPageSize int32
+}
+
+// Creating a new auth audit service.
+func NewAuditService(repos postgres.Audit, recorder audit.Recorder, maxPageSize int32) *AuditService {
+	// Set default maximum page size.
+	if maxPageSize <= 0 {
+		maxPageSize = domain.DefaultMaxPageSize
+	}
+
+	return &AuditService{Recorder: recorder, repos: repos, maxPageSize: maxPageSize}
+}
+
+// Getting filtered auth audit events list page ordered by id.
+func (s *AuditService) GetList(ctx context.Context, filter domain.AuditFilter, sort domain.SortOptions) ([]domain.AuditEvent, domain.PageInfo, error) {
+	if err := checkSortOptions(sort, s.maxPageSize); err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	return s.repos.GetList(ctx, filter, sort)
+}
+
+// Setting the auth audit event outcome by the action error.
+func auditOutcome(event domain.AuditEvent, err error) domain.AuditEvent {
+	if err == nil {
+		event.Outcome = domain.AuditSuccess
+		return event
+	}
+
+	event.Outcome = domain.AuditFailure
+
+	var domainErr *domain.Error
+
+	// Getting the failure reason without internal error codes.
+	if errors.As(err, &domainErr) {
+		event.Reason = domainErr.Message
+	} else if st, ok := status.FromError(err); ok {
+		event.Reason = st.Code().String() + ": " + st.Message()
+	} else {
+		event.Reason = err.Error()
+	}
+
+	return event
+}
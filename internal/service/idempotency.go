@@ -0,0 +1,157 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+)
+
+// Idempotency key service interface.
+type Idempotency interface {
+	// Checking is the method accepting idempotency keys.
+	Supports(method string) bool
+	// Beginning an idempotent request. Returns the stored response when the request with the
+	// same key is already completed.
+	Begin(ctx context.Context, key, method string, fingerprint []byte) ([]byte, error)
+	// Storing response of the completed request.
+	Complete(ctx context.Context, key, method string, response []byte) error
+	// Releasing the key of the failed request, so the request can be retried.
+	Release(ctx context.Context, key, method string) error
+}
+
+// Idempotency key service structure. Client keys are stored only as hashes and responses are
+// encrypted with a key derived from the client key, so stored tokens cannot be read without it.
+type IdempotencyService struct {
+	repos   postgres.Idempotency
+	methods map[string]struct{}
+	cfg     config.IdempotencyConfig
+}
+
+// Creating a new idempotency key service.
+func NewIdempotencyService(repos postgres.Idempotency, cfg config.IdempotencyConfig) *IdempotencyService {
+	methods := make(map[string]struct{}, len(cfg.Methods))
+
+	for _, method := range cfg.Methods {
+		methods[method] = struct{}{}
+	}
+
+	return &IdempotencyService{repos: repos, methods: methods, cfg: cfg}
+}
+
+// Checking is the method accepting idempotency keys.
+func (s *IdempotencyService) Supports(method string) bool {
+	_, ok := s.methods[method]
+	return ok
+}
+
+// Beginning an idempotent request.
+func (s *IdempotencyService) Begin(ctx context.Context, key, method string, fingerprint []byte) ([]byte, error) {
+	// Reserving an idempotency key, in progress reservation expires after the lease.
+	stored, ok, err := s.repos.Reserve(ctx, domain.IdempotencyKey{
+		Id:          idempotencyHash(key, "id", method),
+		Fingerprint: fingerprint,
+		ExpiresAt:   time.Now().Add(s.cfg.Lease),
+	})
+	if err != nil {
+		return nil, err
+	} else if ok {
+		return nil, nil
+	}
+
+	if !bytes.Equal(stored.Fingerprint, fingerprint) {
+		return nil, domain.ErrIdempotencyKeyMismatch
+	} else if stored.Response == nil {
+		return nil, domain.ErrIdempotencyKeyInProgress
+	}
+
+	return decryptResponse(idempotencyHash(key, "response", method), stored.Response)
+}
+
+// Storing response of the completed request.
+func (s *IdempotencyService) Complete(ctx context.Context, key, method string, response []byte) error {
+	encrypted, err := encryptResponse(idempotencyHash(key, "response", method), response)
+	if err != nil {
+		return err
+	}
+
+	return s.repos.Complete(ctx, idempotencyHash(key, "id", method), encrypted, time.Now().Add(s.cfg.TTL))
+}
+
+// Releasing the key of the failed request.
+func (s *IdempotencyService) Release(ctx context.Context, key, method string) error {
+	return s.repos.Release(ctx, idempotencyHash(key, "id", method))
+}
+
+// Getting a keyed hash of the idempotency key for the purpose and method.
+func idempotencyHash(key, purpose, method string) []byte {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(purpose + ":" + method))
+
+	return mac.Sum(nil)
+}
+
+// Encrypting a response with AES-GCM. Nonce is prepended to the ciphertext.
+func encryptResponse(key, response []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, response, nil), nil
+}
+
+// Decrypting a response encrypted with AES-GCM.
+func decryptResponse(key, encrypted []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(encrypted) < gcm.NonceSize() {
+		return nil, errors.New("invalid encrypted response")
+	}
+
+	return gcm.Open(nil, encrypted[:gcm.NonceSize()], encrypted[gcm.NonceSize():], nil)
+}
+
+// Creating a new AES-GCM cipher.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -18,23 +18,82 @@
 package service
 
 import (
+	"context"
+
+	"github.com/durudex/durudex-auth-service/internal/audit"
 	"github.com/durudex/durudex-auth-service/internal/client"
 	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/event"
 	"github.com/durudex/durudex-auth-service/internal/repository"
+	"github.com/durudex/durudex-auth-service/internal/risk"
+	"github.com/durudex/durudex-auth-service/pkg/geoip"
+
+	"github.com/rs/zerolog/log"
 )
 
 // Service structure.
 type Service struct {
-	User    User
-	Session Session
+	User        User
+	Session     Session
+	SignUp      SignUp
+	Idempotency Idempotency
+	Audit       Audit
+	Webhook     Webhook
 }
 
 // Creating a new service.
-func NewService(repos *repository.Repository, client *client.Client, cfg *config.Config) *Service {
-	sessionService := NewSessionService(repos.Postgres.Session)
+func NewService(repos *repository.Repository, client *client.Client, recorder audit.Recorder, publisher event.Publisher, locator geoip.Locator, assessor risk.Assessor, cfg *config.Config) *Service {
+	sessionService := NewSessionService(repos.Session, recorder, publisher, cfg.GRPC.Validation.MaxPageSize)
+	signUpService := NewSignUpService(repos.Saga, client.User, client.Code, cfg.Saga)
 
 	return &Service{
-		User:    NewUserService(sessionService, client, &cfg.Auth),
-		Session: sessionService,
+		User:        NewUserService(sessionService, signUpService, client.User, client.Code, recorder, publisher, locator, assessor, &cfg.Auth, cfg.GRPC.Validation.MaxPageSize),
+		Session:     sessionService,
+		SignUp:      signUpService,
+		Idempotency: NewIdempotencyService(repos.Idempotency, cfg.Idempotency),
+		Audit:       NewAuditService(repos.Audit, recorder, cfg.GRPC.Validation.MaxPageSize),
+		Webhook:     NewWebhookService(repos.Webhook, repos.WebhookDelivery, cfg.GRPC.Validation.MaxPageSize),
+	}
+}
+
+// Writing a state change with the auth domain events. Events are staged in the outbox written
+// in the state change transaction, events not stored in the outbox are published after the
+// write succeeds.
+func transact(ctx context.Context, publisher event.Publisher, outbox domain.Outbox, events []domain.Event, write func(outbox domain.Outbox) error) error {
+	pending, events, err := stage(ctx, publisher, &outbox, events...)
+	if err != nil {
+		return err
+	}
+
+	if err := write(outbox); err != nil {
+		return err
+	}
+
+	publish(ctx, pending, events...)
+
+	return nil
+}
+
+// Staging auth domain events in the outbox. Returns the publisher and the prepared events to
+// publish after the state change is committed.
+func stage(ctx context.Context, publisher event.Publisher, outbox *domain.Outbox, events ...domain.Event) (event.Publisher, []domain.Event, error) {
+	prepared, err := event.PrepareAll(events...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pending, err := publisher.Stage(ctx, outbox, prepared...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pending, prepared, nil
+}
+
+// Publishing auth domain events. Failed events are logged without failing the action.
+func publish(ctx context.Context, publisher event.Publisher, events ...domain.Event) {
+	if err := publisher.Publish(ctx, events...); err != nil {
+		log.Error().Err(err).Int("events", len(events)).Msg("failed to publish auth domain events")
 	}
 }
@@ -19,8 +19,11 @@ package service
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/durudex/durudex-auth-service/internal/audit"
 	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/event"
 	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
 
 	"github.com/segmentio/ksuid"
@@ -28,12 +31,12 @@ import (
 
 // User session service.
 type Session interface {
-	// Creating a new user session.
-	Create(ctx context.Context, session domain.UserSession) error
+	// Creating a new user session with outbox notification emails.
+	Create(ctx context.Context, session domain.UserSession, emails ...domain.Email) error
 	// Getting user session.
 	Get(ctx context.Context, userId, id ksuid.KSUID) (domain.UserSession, error)
-	// Getting user sessions list.
-	GetList(ctx context.Context, userId ksuid.KSUID, sort domain.SortOptions) ([]domain.UserSession, error)
+	// Getting filtered user sessions list page ordered by id.
+	GetList(ctx context.Context, userId ksuid.KSUID, filter domain.SessionFilter, sort domain.SortOptions) ([]domain.UserSession, domain.PageInfo, error)
 	// Deleting user session.
 	Delete(ctx context.Context, userId, id ksuid.KSUID) error
 	// Getting total user session count.
@@ -41,16 +44,44 @@ type Session interface {
 }
 
 // User session service structure.
-type SessionService struct{ repos postgres.Session }
+type SessionService struct {
+	repos postgres.Session
+	audit audit.Recorder
+	// Auth domain events publisher.
+	events event.Publisher
+	// Maximum number of sessions in the list page.
+	maxPageSize int32
+}
 
 // Creating a new user session service.
-func NewSessionService(repos postgres.Session) *SessionService {
-	return &SessionService{repos: repos}
+func NewSessionService(repos postgres.Session, recorder audit.Recorder, publisher event.Publisher, maxPageSize int32) *SessionService {
+	// Set default maximum page size.
+	if maxPageSize <= 0 {
+		maxPageSize = domain.DefaultMaxPageSize
+	}
+
+	return &SessionService{repos: repos, audit: recorder, events: publisher, maxPageSize: maxPageSize}
 }
 
 // Creating a new user session.
-func (s *SessionService) Create(ctx context.Context, session domain.UserSession) error {
-	return s.repos.Create(ctx, session)
+func (s *SessionService) Create(ctx context.Context, session domain.UserSession, emails ...domain.Email) error {
+	events := []domain.Event{sessionCreated(session)}
+
+	return transact(ctx, s.events, domain.Outbox{Emails: emails}, events, func(outbox domain.Outbox) error {
+		return s.repos.Create(ctx, session, outbox)
+	})
+}
+
+// Getting a session created event.
+func sessionCreated(session domain.UserSession) domain.Event {
+	return domain.Event{
+		Type:       domain.EventSessionCreated,
+		UserId:     session.UserId,
+		SessionId:  session.Id,
+		Ip:         session.Ip,
+		DeviceType: session.DeviceType,
+		DeviceName: session.DeviceName,
+	}
 }
 
 // Getting user session.
@@ -58,22 +89,57 @@ func (s *SessionService) Get(ctx context.Context, userId, id ksuid.KSUID) (domai
 	return s.repos.Get(ctx, userId, id)
 }
 
-// Getting user sessions list.
-func (s *SessionService) GetList(ctx context.Context, userId ksuid.KSUID, sort domain.SortOptions) ([]domain.UserSession, error) {
-	// Checking is first and last are set.
-	if sort.First == nil && sort.Last == nil {
-		return nil, &domain.Error{Message: "Must be `first` or `last`", Code: domain.CodeInvalidArgument}
+// Getting filtered user sessions list page ordered by id.
+func (s *SessionService) GetList(ctx context.Context, userId ksuid.KSUID, filter domain.SessionFilter, sort domain.SortOptions) ([]domain.UserSession, domain.PageInfo, error) {
+	if err := checkSortOptions(sort, s.maxPageSize); err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	// Checking filter.
+	if err := filter.Validate(); err != nil {
+		return nil, domain.PageInfo{}, err
 	}
 
-	return s.repos.GetList(ctx, userId, sort)
+	return s.repos.GetList(ctx, userId, filter, sort)
 }
 
 // Deleting user session.
 func (s *SessionService) Delete(ctx context.Context, userId, id ksuid.KSUID) error {
-	return s.repos.Delete(ctx, userId, id)
+	events := []domain.Event{{Type: domain.EventSessionRevoked, UserId: userId, SessionId: id}}
+
+	err := transact(ctx, s.events, domain.Outbox{}, events, func(outbox domain.Outbox) error {
+		return s.repos.Delete(ctx, userId, id, outbox)
+	})
+
+	s.audit.Record(ctx, auditOutcome(domain.AuditEvent{
+		Kind:      domain.AuditSessionRevoke,
+		Actor:     userId.String(),
+		Subject:   userId.String(),
+		SessionId: id,
+	}, err))
+
+	return err
 }
 
 // Getting total user session count.
 func (s *SessionService) GetTotalCount(ctx context.Context, userId ksuid.KSUID) (int32, error) {
 	return s.repos.GetTotalCount(ctx, userId)
 }
+
+// Checking list page sort options.
+func checkSortOptions(sort domain.SortOptions, maxPageSize int32) error {
+	// Checking is first and last are set.
+	if (sort.First == nil) == (sort.Last == nil) {
+		return &domain.Error{Message: "Must be `first` or `last`", Code: domain.CodeInvalidArgument}
+	}
+
+	// Checking page size.
+	if n := sort.Limit() - 1; n < 1 || n > maxPageSize {
+		return &domain.Error{
+			Message: fmt.Sprintf("Page size must be between 1 and %d", maxPageSize),
+			Code:    domain.CodeInvalidArgument,
+		}
+	}
+
+	return nil
+}
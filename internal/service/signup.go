@@ -0,0 +1,259 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/client"
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/metrics"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Function building a new user session with tokens for the created user. Sign up events are
+// staged in the outbox written with the session.
+type sessionFunc func(userId ksuid.KSUID, outbox *domain.Outbox) (domain.UserSession, domain.UserTokens, error)
+
+// Sign up saga service interface.
+type SignUp interface {
+	// Running sign up saga steps.
+	Run(ctx context.Context, input domain.UserSignUpInput, newSession sessionFunc) (domain.UserTokens, error)
+	// Finishing or compensating a sign up saga left in progress.
+	Recover(ctx context.Context, saga domain.SignUpSaga) error
+}
+
+// Sign up saga service structure. Every step is persisted before the next one, so sagas
+// interrupted by a crash are finished or compensated by the recovery worker.
+type SignUpService struct {
+	repos postgres.Saga
+	user  client.User
+	code  client.Code
+	cfg   config.SagaConfig
+}
+
+// Creating a new sign up saga service.
+func NewSignUpService(repos postgres.Saga, user client.User, code client.Code, cfg config.SagaConfig) *SignUpService {
+	return &SignUpService{repos: repos, user: user, code: code, cfg: cfg}
+}
+
+// Running sign up saga steps.
+func (s *SignUpService) Run(ctx context.Context, input domain.UserSignUpInput, newSession sessionFunc) (domain.UserTokens, error) {
+	saga := domain.SignUpSaga{
+		Id:       ksuid.New(),
+		Step:     domain.SagaStarted,
+		Email:    input.Email,
+		Username: input.Username,
+		Ip:       input.Ip,
+	}
+
+	// Creating a new sign up saga.
+	if err := s.repos.Create(ctx, saga); err != nil {
+		return domain.UserTokens{}, err
+	}
+
+	// Verifying user email code.
+	emailResponse, err := s.code.VerifyUserEmailCode(ctx, &v1.VerifyUserEmailCodeRequest{
+		Email: input.Email,
+		Code:  input.Code,
+	})
+	if err == nil && !emailResponse.Status {
+		err = &domain.Error{Code: domain.CodeInvalidArgument, Message: "Invalid verification code"}
+	}
+
+	if err != nil {
+		return domain.UserTokens{}, s.abort(ctx, saga, err)
+	}
+
+	saga.Step = domain.SagaCodeVerified
+
+	if err := s.repos.Update(ctx, saga); err != nil {
+		return domain.UserTokens{}, err
+	}
+
+	// Creating a new user.
+	userId, err := s.createUser(ctx, input)
+	if err != nil {
+		return domain.UserTokens{}, s.abort(ctx, saga, err)
+	}
+
+	saga.Step, saga.UserId = domain.SagaUserCreated, userId
+
+	if err := s.repos.Update(ctx, saga); err != nil {
+		return domain.UserTokens{}, err
+	}
+
+	// Creating a new user session with register email. Session creation is retried, sagas
+	// that are still not completed are compensated by the recovery worker.
+	for {
+		tokens, err := s.complete(ctx, saga, newSession)
+		if err == nil {
+			metrics.SignUpSagasTotal.WithLabelValues(string(domain.SagaCompleted)).Inc()
+			return tokens, nil
+		}
+
+		saga.Attempts++
+		saga.LastError = err.Error()
+
+		if saga.Attempts >= s.cfg.SessionAttempts {
+			if err := s.repos.Update(ctx, saga); err != nil {
+				log.Error().Err(err).Str("saga", saga.Id.String()).Msg("failed to update sign up saga")
+			}
+
+			return domain.UserTokens{}, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return domain.UserTokens{}, ctx.Err()
+		case <-time.After(s.cfg.RetryBackoff * time.Duration(saga.Attempts)):
+		}
+	}
+}
+
+// Completing sign up saga with a new user session and register email.
+func (s *SignUpService) complete(ctx context.Context, saga domain.SignUpSaga, newSession sessionFunc) (domain.UserTokens, error) {
+	outbox := domain.Outbox{Emails: []domain.Email{registerEmail(saga)}}
+
+	session, tokens, err := newSession(saga.UserId, &outbox)
+	if err != nil {
+		return domain.UserTokens{}, err
+	}
+
+	saga.Step = domain.SagaCompleted
+
+	if err := s.repos.Finish(ctx, saga, &session, outbox); err != nil {
+		return domain.UserTokens{}, err
+	}
+
+	return tokens, nil
+}
+
+// Creating a new user. A failed call may still create the user, so unless the user service
+// rejected the user, the outcome is resolved by the user credentials.
+func (s *SignUpService) createUser(ctx context.Context, input domain.UserSignUpInput) (ksuid.KSUID, error) {
+	response, err := s.user.CreateUser(ctx, &v1.CreateUserRequest{
+		Username: input.Username,
+		Email:    input.Email,
+		Password: input.Password,
+	})
+	if err == nil {
+		return ksuid.FromBytesOrNil(response.Id), nil
+	}
+
+	switch status.Code(err) {
+	case codes.AlreadyExists, codes.InvalidArgument:
+		return ksuid.Nil, err
+	}
+
+	user, credsErr := s.user.GetUserByCreds(ctx, &v1.GetUserByCredsRequest{
+		Username: input.Username,
+		Password: input.Password,
+	})
+	if credsErr != nil || user.Email != input.Email {
+		return ksuid.Nil, err
+	}
+
+	log.Warn().Err(err).Str("username", input.Username).Msg("user created by failed call, continuing sign up")
+
+	return ksuid.FromBytesOrNil(user.Id), nil
+}
+
+// Marking sign up saga as failed before the user is created. Returns the step error, the
+// saga update error is logged.
+func (s *SignUpService) abort(ctx context.Context, saga domain.SignUpSaga, stepErr error) error {
+	if err := s.fail(ctx, saga, stepErr.Error()); err != nil {
+		log.Error().Err(err).Str("saga", saga.Id.String()).Msg("failed to update sign up saga")
+	}
+
+	return stepErr
+}
+
+// Marking sign up saga as failed before the user is created.
+func (s *SignUpService) fail(ctx context.Context, saga domain.SignUpSaga, reason string) error {
+	saga.Step, saga.LastError = domain.SagaFailed, reason
+
+	if err := s.repos.Update(ctx, saga); err != nil {
+		return err
+	}
+
+	metrics.SignUpSagasTotal.WithLabelValues(string(domain.SagaFailed)).Inc()
+
+	return nil
+}
+
+// Finishing or compensating a sign up saga left in progress.
+func (s *SignUpService) Recover(ctx context.Context, saga domain.SignUpSaga) error {
+	logger := log.With().Str("saga", saga.Id.String()).Str("step", string(saga.Step)).Logger()
+
+	switch saga.Step {
+	case domain.SagaStarted:
+		// The user is not created, code verified step is stored before the user creation.
+		logger.Warn().Msg("sign up saga interrupted before user creation, marking as failed")
+
+		return s.fail(ctx, saga, "Sign up interrupted")
+	case domain.SagaCodeVerified:
+		// The user creation outcome is unknown. The password is not stored, so the user can not
+		// be looked up and no register email is sent, a created user can sign in.
+		logger.Error().Str("username", saga.Username).Msg("sign up saga interrupted during user creation")
+
+		saga.Step, saga.LastError = domain.SagaUnresolved, "User creation outcome is unknown"
+
+		if err := s.repos.Update(ctx, saga); err != nil {
+			return err
+		}
+
+		metrics.SignUpSagasTotal.WithLabelValues(string(domain.SagaUnresolved)).Inc()
+
+		return nil
+	case domain.SagaUserCreated:
+		// The client never received tokens, so the session is not created. The user is notified
+		// with register email and can sign in.
+		logger.Warn().Msg("sign up saga interrupted after user creation, compensating")
+
+		saga.Step = domain.SagaCompensated
+
+		if err := s.repos.Finish(ctx, saga, nil, domain.Outbox{Emails: []domain.Email{registerEmail(saga)}}); err != nil {
+			return err
+		}
+
+		metrics.SignUpSagasTotal.WithLabelValues(string(domain.SagaCompensated)).Inc()
+
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Getting a register email of the sign up saga.
+func registerEmail(saga domain.SignUpSaga) domain.Email {
+	return domain.Email{
+		Id:       ksuid.New(),
+		Kind:     domain.EmailUserRegister,
+		Email:    saga.Email,
+		Username: saga.Username,
+	}
+}
@@ -0,0 +1,291 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/client"
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/service"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// In-memory sign up saga repository.
+type sagaRepository struct {
+	steps   []domain.SagaStep
+	session *domain.UserSession
+	outbox  domain.Outbox
+	// Number of failed finish calls.
+	failures int
+	// Update error.
+	updateErr error
+}
+
+// Creating a new sign up saga.
+func (r *sagaRepository) Create(_ context.Context, saga domain.SignUpSaga) error {
+	r.steps = append(r.steps, saga.Step)
+	return nil
+}
+
+// Updating a sign up saga step state.
+func (r *sagaRepository) Update(_ context.Context, saga domain.SignUpSaga) error {
+	if r.updateErr != nil {
+		return r.updateErr
+	}
+
+	r.steps = append(r.steps, saga.Step)
+
+	return nil
+}
+
+// Finishing a sign up saga, fails with configured number of failures.
+func (r *sagaRepository) Finish(_ context.Context, saga domain.SignUpSaga, session *domain.UserSession, outbox domain.Outbox) error {
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("database unavailable")
+	}
+
+	r.steps = append(r.steps, saga.Step)
+	r.session, r.outbox = session, outbox
+
+	return nil
+}
+
+// Claiming stale sign up sagas.
+func (r *sagaRepository) ClaimStale(context.Context, time.Time, int32, time.Duration) ([]domain.SignUpSaga, error) {
+	return nil, nil
+}
+
+// User service creating the user and failing the call.
+type unavailableUser struct{ *client.FakeUser }
+
+// Creating a new user, the response is lost.
+func (u unavailableUser) CreateUser(ctx context.Context, in *v1.CreateUserRequest, opts ...grpc.CallOption) (*v1.CreateUserResponse, error) {
+	if _, err := u.FakeUser.CreateUser(ctx, in, opts...); err != nil {
+		return nil, err
+	}
+
+	return nil, status.Error(codes.Unavailable, "connection reset")
+}
+
+// Testing running sign up saga steps.
+func TestSignUpService_Run(t *testing.T) {
+	// Testing args.
+	type args struct {
+		status   bool
+		failures int
+		// User service creating the user and failing the call.
+		unavailable bool
+		// Existing user with the same username.
+		exists bool
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name        string
+		args        args
+		wantSteps   []domain.SagaStep
+		wantSession bool
+		wantErr     bool
+	}{
+		{
+			name: "OK",
+			args: args{status: true},
+			wantSteps: []domain.SagaStep{
+				domain.SagaStarted, domain.SagaCodeVerified, domain.SagaUserCreated, domain.SagaCompleted,
+			},
+			wantSession: true,
+		},
+		{
+			name: "Session created after retry",
+			args: args{status: true, failures: 2},
+			wantSteps: []domain.SagaStep{
+				domain.SagaStarted, domain.SagaCodeVerified, domain.SagaUserCreated, domain.SagaCompleted,
+			},
+			wantSession: true,
+		},
+		{
+			name: "Session attempts exhausted",
+			args: args{status: true, failures: 3},
+			wantSteps: []domain.SagaStep{
+				domain.SagaStarted, domain.SagaCodeVerified, domain.SagaUserCreated, domain.SagaUserCreated,
+			},
+			wantErr: true,
+		},
+		{
+			name: "User created by failed call",
+			args: args{status: true, unavailable: true},
+			wantSteps: []domain.SagaStep{
+				domain.SagaStarted, domain.SagaCodeVerified, domain.SagaUserCreated, domain.SagaCompleted,
+			},
+			wantSession: true,
+		},
+		{
+			name:      "User already exists",
+			args:      args{status: true, exists: true},
+			wantSteps: []domain.SagaStep{domain.SagaStarted, domain.SagaCodeVerified, domain.SagaFailed},
+			wantErr:   true,
+		},
+		{
+			name:      "Invalid verification code",
+			args:      args{status: false},
+			wantSteps: []domain.SagaStep{domain.SagaStarted, domain.SagaFailed},
+			wantErr:   true,
+		},
+	}
+
+	cfg := config.SagaConfig{SessionAttempts: 3, RetryBackoff: time.Millisecond}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := &sagaRepository{failures: tt.args.failures}
+
+			fakeUser := client.NewFakeUser()
+			if tt.args.exists {
+				if _, err := fakeUser.CreateUser(context.Background(), &v1.CreateUserRequest{
+					Username: "example",
+					Email:    "other@durudex.com",
+					Password: "Password123",
+				}); err != nil {
+					t.Fatalf("error creating an existing user: %s", err.Error())
+				}
+			}
+
+			var user client.User = fakeUser
+			if tt.args.unavailable {
+				user = unavailableUser{fakeUser}
+			}
+
+			// Setting a valid verification code.
+			code := client.NewFakeCode()
+			if tt.args.status {
+				code.SetCode("example@durudex.com", 123456)
+			}
+
+			_, err := service.NewSignUpService(repos, user, code, cfg).Run(context.Background(), domain.UserSignUpInput{
+				Username: "example",
+				Email:    "example@durudex.com",
+				Password: "Password123",
+				Code:     123456,
+			}, func(id ksuid.KSUID, outbox *domain.Outbox) (domain.UserSession, domain.UserTokens, error) {
+				outbox.Events = append(outbox.Events, domain.EventMessage{Id: ksuid.New()})
+				return domain.UserSession{UserId: id}, domain.UserTokens{}, nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error running sign up saga: %v", err)
+			}
+
+			// Check for similarity of saga steps.
+			if len(repos.steps) != len(tt.wantSteps) {
+				t.Fatalf("error saga steps: got %v, want %v", repos.steps, tt.wantSteps)
+			}
+
+			for i := range tt.wantSteps {
+				if repos.steps[i] != tt.wantSteps[i] {
+					t.Fatalf("error saga steps: got %v, want %v", repos.steps, tt.wantSteps)
+				}
+			}
+
+			// Check created session, register email and staged sign up event.
+			if got := repos.session != nil; got != tt.wantSession {
+				t.Fatalf("error session created: got %t, want %t", got, tt.wantSession)
+			}
+
+			if tt.wantSession && (repos.session.UserId.IsNil() || len(repos.outbox.Emails) != 1 || len(repos.outbox.Events) != 1) {
+				t.Errorf("error finished saga: got user %s with %d emails and %d events", repos.session.UserId,
+					len(repos.outbox.Emails), len(repos.outbox.Events))
+			}
+		})
+	}
+}
+
+// Testing recovering sign up sagas left in progress.
+func TestSignUpService_Recover(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name      string
+		step      domain.SagaStep
+		updateErr error
+		wantStep  domain.SagaStep
+		wantEmail bool
+		wantErr   bool
+	}{
+		{name: "Started", step: domain.SagaStarted, wantStep: domain.SagaFailed},
+		{name: "Code verified", step: domain.SagaCodeVerified, wantStep: domain.SagaUnresolved},
+		{name: "User created", step: domain.SagaUserCreated, wantStep: domain.SagaCompensated, wantEmail: true},
+		{
+			name:      "Started update error",
+			step:      domain.SagaStarted,
+			updateErr: errors.New("database unavailable"),
+			wantErr:   true,
+		},
+		{
+			name:      "Code verified update error",
+			step:      domain.SagaCodeVerified,
+			updateErr: errors.New("database unavailable"),
+			wantErr:   true,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := &sagaRepository{updateErr: tt.updateErr}
+
+			saga := domain.SignUpSaga{Id: ksuid.New(), Step: tt.step, Email: "example@durudex.com"}
+
+			err := service.NewSignUpService(repos, client.NewFakeUser(), client.NewFakeCode(), config.SagaConfig{}).Recover(context.Background(), saga)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error recovering sign up saga: %v", err)
+			}
+
+			if tt.wantErr {
+				// Saga is claimed again after the lease.
+				if len(repos.steps) != 0 {
+					t.Errorf("error saga steps: got %v, want none", repos.steps)
+				}
+
+				return
+			}
+
+			// Check for similarity of saga step.
+			if len(repos.steps) != 1 || repos.steps[0] != tt.wantStep {
+				t.Fatalf("error saga steps: got %v, want %s", repos.steps, tt.wantStep)
+			}
+
+			if repos.session != nil {
+				t.Errorf("error compensated saga session: got %v, want nil", repos.session)
+			}
+
+			if got := len(repos.outbox.Emails) == 1; got != tt.wantEmail {
+				t.Errorf("error register email: got %t, want %t", got, tt.wantEmail)
+			}
+		})
+	}
+}
@@ -18,18 +18,27 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"time"
 
+	"github.com/durudex/durudex-auth-service/internal/audit"
 	"github.com/durudex/durudex-auth-service/internal/client"
 	"github.com/durudex/durudex-auth-service/internal/config"
 	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/event"
+	"github.com/durudex/durudex-auth-service/internal/risk"
 	"github.com/durudex/durudex-auth-service/pkg/auth"
+	"github.com/durudex/durudex-auth-service/pkg/geoip"
 	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+	"github.com/durudex/durudex-auth-service/pkg/useragent"
 
 	"github.com/durudex/go-refresh"
+	"github.com/rs/zerolog/log"
 	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // User auth service interface.
@@ -42,155 +51,513 @@ type User interface {
 	CreateSession(ctx context.Context, userId ksuid.KSUID, ip, secret string) (domain.UserTokens, error)
 	// Refresh user token.
 	RefreshToken(ctx context.Context, token, secret string) (string, error)
+	// Re-authenticating the user session.
+	StepUp(ctx context.Context, input domain.UserStepUpInput) (string, error)
+	// User Sign Out, deleting the refresh token session.
+	SignOut(ctx context.Context, token, secret string) error
 }
 
 // User service structure.
 type UserService struct {
 	session Session
-	// Service client.
-	client *client.Client
+	signUp  SignUp
+	// User service client.
+	user client.User
+	// Code service client.
+	code client.Code
+	// Auth audit events recorder.
+	audit audit.Recorder
+	// Auth domain events publisher.
+	events event.Publisher
+	// Session ip address locator.
+	geo geoip.Locator
+	// Sign in risk assessor.
+	risk risk.Assessor
 	// Auth config variables.
 	cfg *config.AuthConfig
+	// Maximum number of recent sessions of the login alert.
+	maxPageSize int32
 }
 
 // Creating a new user service.
-func NewUserService(session Session, client *client.Client, cfg *config.AuthConfig) *UserService {
-	return &UserService{session: session, client: client, cfg: cfg}
+func NewUserService(session Session, signUp SignUp, user client.User, code client.Code, recorder audit.Recorder, publisher event.Publisher, locator geoip.Locator, assessor risk.Assessor, cfg *config.AuthConfig, maxPageSize int32) *UserService {
+	// Set default maximum page size.
+	if maxPageSize <= 0 {
+		maxPageSize = domain.DefaultMaxPageSize
+	}
+
+	return &UserService{
+		session:     session,
+		signUp:      signUp,
+		user:        user,
+		code:        code,
+		audit:       recorder,
+		events:      publisher,
+		geo:         locator,
+		risk:        assessor,
+		cfg:         cfg,
+		maxPageSize: maxPageSize,
+	}
 }
 
-// User SignUp.
+// User SignUp. Sign up steps are run as a saga.
 func (s *UserService) SignUp(ctx context.Context, input domain.UserSignUpInput) (domain.UserTokens, error) {
-	// Verifying user email code.
-	emailResponse, err := s.client.Code.VerifyUserEmailCode(ctx, &v1.VerifyUserEmailCodeRequest{
-		Email: input.Email,
-		Code:  input.Code,
+	// Subject is the username until the user is created.
+	record := domain.AuditEvent{
+		Kind:      domain.AuditSignUp,
+		Actor:     input.Username,
+		Subject:   input.Username,
+		Ip:        input.Ip,
+		UserAgent: input.UserAgent,
+	}
+
+	var (
+		pending event.Publisher
+		events  []domain.Event
+	)
+
+	tokens, err := s.signUp.Run(ctx, input, func(userId ksuid.KSUID, outbox *domain.Outbox) (domain.UserSession, domain.UserTokens, error) {
+		// Sign up verifies both the password and the email code.
+		authn := auth.Authenticated(auth.MethodPassword, auth.MethodOTP)
+
+		session, tokens, err := s.newSession(userId, input.Ip, input.UserAgent, input.Secret, authn)
+		if err != nil {
+			return domain.UserSession{}, domain.UserTokens{}, err
+		}
+
+		record.Actor, record.Subject, record.SessionId = userId.String(), userId.String(), session.Id
+
+		signedUp := domain.Event{
+			Type:      domain.EventUserSignedUp,
+			UserId:    session.UserId,
+			SessionId: session.Id,
+			Ip:        input.Ip,
+			UserAgent: input.UserAgent,
+		}
+
+		// Staging sign up events in the outbox written with the session.
+		pending, events, err = stage(ctx, s.events, outbox, signedUp, sessionCreated(session))
+
+		return session, tokens, err
 	})
-	if err != nil || !emailResponse.Status {
+
+	s.audit.Record(ctx, auditOutcome(record, err))
+
+	if err != nil {
 		return domain.UserTokens{}, err
 	}
 
-	// Creating a new user.
-	userResponse, err := s.client.User.CreateUser(ctx, &v1.CreateUserRequest{
+	publish(ctx, pending, events...)
+
+	return tokens, nil
+}
+
+// User SignIn.
+func (s *UserService) SignIn(ctx context.Context, input domain.UserSignInInput) (domain.UserTokens, error) {
+	// Subject is the username until the user is found.
+	record := domain.AuditEvent{
+		Kind:      domain.AuditSignIn,
+		Actor:     input.Username,
+		Subject:   input.Username,
+		Ip:        input.Ip,
+		UserAgent: input.UserAgent,
+	}
+
+	// Getting a user by credentials.
+	userResponse, err := s.user.GetUserByCreds(ctx, &v1.GetUserByCredsRequest{
 		Username: input.Username,
-		Email:    input.Email,
 		Password: input.Password,
 	})
 	if err != nil {
+		s.audit.Record(ctx, auditOutcome(record, err))
 		return domain.UserTokens{}, err
 	}
 
-	// Creating a new user session.
-	tokens, err := s.CreateSession(ctx, ksuid.FromBytesOrNil(userResponse.Id), input.Ip, input.Secret)
-	if err != nil {
-		return domain.UserTokens{}, err
+	userId := ksuid.FromBytesOrNil(userResponse.Id)
+	record.Actor, record.Subject = userId.String(), userId.String()
+
+	session, tokens, err := s.newSession(userId, input.Ip, input.UserAgent, input.Secret, auth.Authenticated(auth.MethodPassword))
+	if err == nil {
+		record.SessionId = session.Id
+
+		err = s.login(ctx, input, session, &tokens, userResponse.Email)
 	}
 
-	// Sending an email to a user with register.
-	if _, err := s.client.Email.SendEmailUserRegister(ctx, &v1.SendEmailUserRegisterRequest{
-		Email:    input.Email,
-		Username: input.Username,
-	}); err != nil {
+	s.audit.Record(ctx, auditOutcome(record, err))
+
+	if err != nil {
 		return domain.UserTokens{}, err
 	}
 
 	return tokens, nil
 }
 
-// User SignIn.
-func (s *UserService) SignIn(ctx context.Context, input domain.UserSignInInput) (domain.UserTokens, error) {
-	// Getting a user by credentials.
-	userResponse, err := s.client.User.GetUserByCreds(ctx, &v1.GetUserByCredsRequest{
-		Username: input.Username,
-		Password: input.Password,
+// Creating a new sign in session. Sign in risk is assessed against the recent user sessions,
+// logged in email is sent only for unusual sign ins.
+func (s *UserService) login(ctx context.Context, input domain.UserSignInInput, session domain.UserSession, tokens *domain.UserTokens, email string) error {
+	cfg := s.cfg.LoginAlert
+
+	// Getting the most recent user sessions, sign in is unusual when they are not available.
+	recent, err := s.recentSessions(ctx, session.UserId)
+	if err != nil {
+		log.Error().Err(err).Str("user", session.UserId.String()).Msg("failed to get recent user sessions")
+	}
+
+	unusual := err != nil || domain.CheckLogin(session, recent, cfg.IPv4Prefix, cfg.IPv6Prefix).Unusual()
+
+	// Assessing the sign in risk.
+	verified, err := s.checkRisk(ctx, input, session, recent, email)
+	if err != nil {
+		return err
+	}
+
+	// Verified challenge code is the second sign in factor.
+	if verified {
+		authn := auth.Authenticated(auth.MethodPassword, auth.MethodOTP)
+
+		tokens.Access, err = auth.GenerateAccessToken(session.UserId.String(), s.cfg.JWT.SigningKey, s.cfg.JWT.TTL, authn)
+		if err != nil {
+			return err
+		}
+
+		setAuthentication(&session, authn)
+	}
+
+	var emails []domain.Email
+
+	if unusual {
+		emails = append(emails, domain.Email{
+			Id:    ksuid.New(),
+			Kind:  domain.EmailUserLoggedIn,
+			Email: email,
+			Ip:    input.Ip,
+		})
+	}
+
+	// Creating a new user session with logged in email.
+	return s.session.Create(ctx, session, emails...)
+}
+
+// Getting the most recent user sessions created within the login alert lookback.
+func (s *UserService) recentSessions(ctx context.Context, userId ksuid.KSUID) ([]domain.UserSession, error) {
+	cfg := s.cfg.LoginAlert
+
+	// Set default number of recent sessions, limited by the maximum page size.
+	last := cfg.Sessions
+	if last <= 0 || last > s.maxPageSize {
+		last = s.maxPageSize
+	}
+
+	var filter domain.SessionFilter
+	if cfg.Lookback > 0 {
+		filter.CreatedAfter = time.Now().Add(-cfg.Lookback)
+	}
+
+	recent, _, err := s.session.GetList(ctx, userId, filter, domain.SortOptions{Last: &last})
+
+	return recent, err
+}
+
+// Checking the sign in risk. Blocked sign ins are rejected, challenged sign ins require the
+// user email verification code. Returns whether the code has been verified.
+func (s *UserService) checkRisk(ctx context.Context, input domain.UserSignInInput, session domain.UserSession, recent []domain.UserSession, email string) (bool, error) {
+	assessment := s.risk.Assess(ctx, domain.LoginAttempt{
+		Username:  input.Username,
+		Session:   session,
+		Recent:    recent,
+		UserAgent: input.UserAgent,
+		Time:      time.Now(),
 	})
+
+	switch assessment.Decision {
+	case domain.RiskBlock:
+		return false, &domain.Error{Code: domain.CodePermissionDenied, Message: "Sign in is blocked"}
+	case domain.RiskChallenge:
+		if input.Code == 0 {
+			return false, &domain.Error{Code: domain.CodePermissionDenied, Message: "Email verification code is required"}
+		}
+
+		if err := s.verifyCode(ctx, email, input.Code); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// Verifying user email code.
+func (s *UserService) verifyCode(ctx context.Context, email string, code uint64) error {
+	response, err := s.code.VerifyUserEmailCode(ctx, &v1.VerifyUserEmailCodeRequest{Email: email, Code: code})
 	if err != nil {
-		return domain.UserTokens{}, err
+		return err
 	}
 
-	// Creating a new user session.
-	tokens, err := s.CreateSession(ctx, ksuid.FromBytesOrNil(userResponse.Id), input.Ip, input.Secret)
+	if !response.Status {
+		return &domain.Error{Code: domain.CodeInvalidArgument, Message: "Invalid verification code"}
+	}
+
+	return nil
+}
+
+// Creating a new user session.
+func (s *UserService) CreateSession(ctx context.Context, userId ksuid.KSUID, ip, secret string) (domain.UserTokens, error) {
+	return s.createSession(ctx, userId, ip, "", secret)
+}
+
+// Creating a new user session. Notification emails are delivered through the outbox.
+func (s *UserService) createSession(ctx context.Context, userId ksuid.KSUID, ip, userAgent, secret string, emails ...domain.Email) (domain.UserTokens, error) {
+	// Session is created without user authentication.
+	session, tokens, err := s.newSession(userId, ip, userAgent, secret, auth.Authentication{Time: time.Now()})
 	if err != nil {
 		return domain.UserTokens{}, err
 	}
 
-	// Sending an email to a user with logged in.
-	if _, err := s.client.Email.SendEmailUserLoggedIn(ctx, &v1.SendEmailUserLoggedInRequest{
-		Email: userResponse.Email,
-		Ip:    input.Ip,
-	}); err != nil {
+	// Creating a new user session.
+	if err := s.session.Create(ctx, session, emails...); err != nil {
 		return domain.UserTokens{}, err
 	}
 
 	return tokens, nil
 }
 
-// Creating a new user session.
-func (s *UserService) CreateSession(ctx context.Context, userId ksuid.KSUID, ip, secret string) (domain.UserTokens, error) {
+// Building a new user session with tokens. Access token carries the session authentication.
+func (s *UserService) newSession(userId ksuid.KSUID, ip, userAgent, secret string, authn auth.Authentication) (domain.UserSession, domain.UserTokens, error) {
 	// Generating a new refresh token.
 	r, err := refresh.New()
 	if err != nil {
-		return domain.UserTokens{}, err
+		return domain.UserSession{}, domain.UserTokens{}, err
 	}
 
 	// Generate a new session id.
 	sessionId := ksuid.New()
 
-	// Creating a new user session.
-	if err := s.session.Create(ctx, domain.UserSession{
-		Id:        sessionId,
-		UserId:    userId,
-		Payload:   fmt.Sprintf("%x", r.Hash([]byte(secret))),
-		Ip:        ip,
-		ExpiresIn: time.Now().Add(s.cfg.Session.TTL),
-	}); err != nil {
-		return domain.UserTokens{}, err
+	// Generating a new jwt access token.
+	access, err := auth.GenerateAccessToken(userId.String(), s.cfg.JWT.SigningKey, s.cfg.JWT.TTL, authn)
+	if err != nil {
+		return domain.UserSession{}, domain.UserTokens{}, err
 	}
 
-	// Generating a new jwt access token.
-	access, err := auth.GenerateAccessToken(userId.String(), s.cfg.JWT.SigningKey, s.cfg.JWT.TTL)
+	// Parsing the client device.
+	device := useragent.Parse(userAgent)
+
+	// Looking up the ip address location, session is created without the location on failure.
+	location, err := s.geo.Locate(ip)
 	if err != nil {
-		return domain.UserTokens{}, err
+		log.Error().Err(err).Str("ip", ip).Msg("failed to look up ip address location")
+	}
+
+	session := domain.UserSession{
+		Id:         sessionId,
+		UserId:     userId,
+		Payload:    fmt.Sprintf("%x", r.Hash([]byte(secret))),
+		Ip:         ip,
+		ExpiresIn:  time.Now().Add(s.cfg.Session.TTL),
+		DeviceType: device.Type,
+		DeviceName: device.Name,
+		Country:    location.Country,
+		City:       location.City,
 	}
 
-	return domain.UserTokens{Refresh: r.Token(sessionId.String(), userId.String()), Access: access}, nil
+	setAuthentication(&session, authn)
+
+	return session, domain.UserTokens{Refresh: r.Token(sessionId.String(), userId.String()), Access: access}, nil
 }
 
 // Refresh user token.
 func (s *UserService) RefreshToken(ctx context.Context, token, secret string) (string, error) {
+	refreshed := domain.Event{Type: domain.EventSessionRefreshed}
+
+	access, err := s.refreshToken(ctx, token, secret, &refreshed)
+
+	record := domain.AuditEvent{Kind: domain.AuditTokenRefresh, SessionId: refreshed.SessionId}
+
+	// Token user is unknown when the token is not parsed.
+	if refreshed.UserId != ksuid.Nil {
+		record.Actor, record.Subject = refreshed.UserId.String(), refreshed.UserId.String()
+	}
+
+	s.audit.Record(ctx, auditOutcome(record, err))
+
+	if err != nil {
+		return "", err
+	}
+
+	publish(ctx, s.events, refreshed)
+
+	return access, nil
+}
+
+// Refresh user token. Token user and session are set to the refreshed event.
+func (s *UserService) refreshToken(ctx context.Context, token, secret string, refreshed *domain.Event) (string, error) {
+	session, err := s.tokenSession(ctx, token, secret, refreshed)
+	if err != nil {
+		return "", err
+	}
+
+	// Generating a new jwt access token with the sign in authentication of the session, stepped
+	// up authentication is not kept by refreshed tokens.
+	access, err := auth.GenerateAccessToken(session.UserId.String(), s.cfg.JWT.SigningKey, s.cfg.JWT.TTL,
+		sessionAuthentication(session))
+	if err != nil {
+		return "", err
+	}
+
+	return access, nil
+}
+
+// Getting the refresh token user session. Token user and session are set to the event.
+func (s *UserService) tokenSession(ctx context.Context, token, secret string, ids *domain.Event) (domain.UserSession, error) {
 	// Parsing refresh token string.
 	r, err := refresh.Parse(token)
 	if err != nil {
-		return "", err
+		return domain.UserSession{}, err
 	}
 
 	// Parsing session id string.
 	id, err := ksuid.Parse(r.Session)
 	if err != nil {
-		return "", err
+		return domain.UserSession{}, err
 	}
 
 	// Parsing user id string.
 	userId, err := ksuid.Parse(r.Object)
 	if err != nil {
-		return "", err
+		return domain.UserSession{}, err
 	}
 
+	ids.UserId, ids.SessionId = userId, id
+
 	// Getting a user session.
 	session, err := s.session.Get(ctx, userId, id)
 	if err != nil {
-		return "", err
+		return domain.UserSession{}, err
 	}
 
 	// Checking user session payload for similar input payload.
 	if session.Payload != fmt.Sprintf("%x", r.Payload.Hash([]byte(secret))) {
-		return "", &domain.Error{Code: domain.CodeInvalidArgument, Message: "Session payload is not similar"}
+		return domain.UserSession{}, &domain.Error{Code: domain.CodeInvalidArgument, Message: "Session payload is not similar"}
 	}
 
-	// Generating a new jwt access token.
-	access, err := auth.GenerateAccessToken(session.UserId.String(), s.cfg.JWT.SigningKey, s.cfg.JWT.TTL)
+	// Checking user session lifetime.
+	if !session.ExpiresIn.After(time.Now()) {
+		return domain.UserSession{}, &domain.Error{Code: domain.CodePermissionDenied, Message: "Session is expired"}
+	}
+
+	return session, nil
+}
+
+// User Sign Out, deleting the refresh token session.
+func (s *UserService) SignOut(ctx context.Context, token, secret string) error {
+	var ids domain.Event
+
+	// Verifying refresh token with the client secret.
+	if _, err := s.tokenSession(ctx, token, secret, &ids); err != nil {
+		record := domain.AuditEvent{Kind: domain.AuditSessionRevoke, SessionId: ids.SessionId}
+
+		// Token user is unknown when the token is not parsed.
+		if ids.UserId != ksuid.Nil {
+			record.Actor, record.Subject = ids.UserId.String(), ids.UserId.String()
+		}
+
+		s.audit.Record(ctx, auditOutcome(record, err))
+
+		return err
+	}
+
+	return s.session.Delete(ctx, ids.UserId, ids.SessionId)
+}
+
+// Re-authenticating the user session. Session user password and the optional email code are
+// verified again, the elevated access token is short-lived and is not stored in the session.
+func (s *UserService) StepUp(ctx context.Context, input domain.UserStepUpInput) (string, error) {
+	var ids domain.Event
+
+	access, err := s.stepUp(ctx, input, &ids)
+
+	record := domain.AuditEvent{
+		Kind:      domain.AuditStepUp,
+		SessionId: ids.SessionId,
+		Ip:        input.Ip,
+		UserAgent: input.UserAgent,
+	}
+
+	// Token user is unknown when the token is not parsed.
+	if ids.UserId != ksuid.Nil {
+		record.Actor, record.Subject = ids.UserId.String(), ids.UserId.String()
+	}
+
+	s.audit.Record(ctx, auditOutcome(record, err))
+
 	if err != nil {
 		return "", err
 	}
 
 	return access, nil
 }
+
+// Re-authenticating the user session. Token user and session are set to the event.
+func (s *UserService) stepUp(ctx context.Context, input domain.UserStepUpInput, ids *domain.Event) (string, error) {
+	session, err := s.tokenSession(ctx, input.Refresh, input.Secret, ids)
+	if err != nil {
+		return "", err
+	}
+
+	// Getting the session user.
+	user, err := s.user.GetUserById(ctx, &v1.GetUserByIdRequest{Id: session.UserId.Bytes()})
+	if err != nil {
+		return "", err
+	}
+
+	// Verifying the session user password.
+	creds, err := s.user.GetUserByCreds(ctx, &v1.GetUserByCredsRequest{
+		Username: user.Username,
+		Password: input.Password,
+	})
+	if status.Code(err) == codes.NotFound || (err == nil && !bytes.Equal(creds.Id, session.UserId.Bytes())) {
+		return "", &domain.Error{Code: domain.CodeInvalidArgument, Message: "Invalid password"}
+	} else if err != nil {
+		return "", err
+	}
+
+	methods := []string{auth.MethodPassword}
+
+	// Email code is the optional second factor.
+	if input.Code != 0 {
+		if err := s.verifyCode(ctx, creds.Email, input.Code); err != nil {
+			return "", err
+		}
+
+		methods = append(methods, auth.MethodOTP)
+	}
+
+	authn := auth.Authenticated(methods...)
+
+	// Set default step up token ttl.
+	ttl := s.cfg.JWT.StepUpTTL
+	if ttl <= 0 {
+		ttl = s.cfg.JWT.TTL
+	}
+
+	access, err := auth.GenerateAccessToken(session.UserId.String(), s.cfg.JWT.SigningKey, ttl, authn)
+	if err != nil {
+		return "", err
+	}
+
+	return access, nil
+}
+
+// Setting the user session authentication.
+func setAuthentication(session *domain.UserSession, authn auth.Authentication) {
+	session.AuthLevel, session.AuthMethods, session.AuthTime = int(authn.Level), authn.Methods, authn.Time
+}
+
+// Getting the user session authentication.
+func sessionAuthentication(session domain.UserSession) auth.Authentication {
+	return auth.Authentication{
+		Level:   auth.Level(session.AuthLevel),
+		Methods: session.AuthMethods,
+		Time:    session.AuthTime,
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Auth webhook service interface.
+type Webhook interface {
+	// Creating a new enabled webhook.
+	Create(ctx context.Context, url string, types []domain.EventType, secret string) (ksuid.KSUID, error)
+	// Getting a webhook by id.
+	Get(ctx context.Context, id ksuid.KSUID) (domain.Webhook, error)
+	// Getting all webhooks ordered by id.
+	GetList(ctx context.Context) ([]domain.Webhook, error)
+	// Deleting a webhook with its deliveries.
+	Delete(ctx context.Context, id ksuid.KSUID) error
+	// Enabling a disabled webhook.
+	Enable(ctx context.Context, id ksuid.KSUID) error
+	// Getting a webhook deliveries list page ordered by id.
+	GetDeliveries(ctx context.Context, webhookId ksuid.KSUID, sort domain.SortOptions) ([]domain.WebhookDelivery, domain.PageInfo, error)
+}
+
+// Auth webhook service structure.
+type WebhookService struct {
+	webhooks   postgres.Webhook
+	deliveries postgres.WebhookDelivery
+	// Maximum number of deliveries in the list page.
+	maxPageSize int32
+}
+
+// Creating a new auth webhook service.
+func NewWebhookService(webhooks postgres.Webhook, deliveries postgres.WebhookDelivery, maxPageSize int32) *WebhookService {
+	// Set default maximum page size.
+	if maxPageSize <= 0 {
+		maxPageSize = domain.DefaultMaxPageSize
+	}
+
+	return &WebhookService{webhooks: webhooks, deliveries: deliveries, maxPageSize: maxPageSize}
+}
+
+// Creating a new enabled webhook.
+func (s *WebhookService) Create(ctx context.Context, url string, types []domain.EventType, secret string) (ksuid.KSUID, error) {
+	webhook := domain.Webhook{
+		Id:        ksuid.New(),
+		URL:       url,
+		Secret:    secret,
+		Enabled:   true,
+		CreatedAt: time.Now(),
+	}
+
+	// Checking and deduplicating event types.
+	for _, typ := range types {
+		if !typ.Valid() {
+			return ksuid.Nil, &domain.Error{Code: domain.CodeInvalidArgument, Message: "Unknown event type: " + string(typ)}
+		}
+
+		if !webhook.Subscribed(typ) {
+			webhook.EventTypes = append(webhook.EventTypes, typ)
+		}
+	}
+
+	if len(webhook.EventTypes) == 0 {
+		return ksuid.Nil, &domain.Error{Code: domain.CodeInvalidArgument, Message: "Event types must be set"}
+	}
+
+	if err := s.webhooks.Create(ctx, webhook); err != nil {
+		return ksuid.Nil, err
+	}
+
+	return webhook.Id, nil
+}
+
+// Getting a webhook by id.
+func (s *WebhookService) Get(ctx context.Context, id ksuid.KSUID) (domain.Webhook, error) {
+	return s.webhooks.Get(ctx, id)
+}
+
+// Getting all webhooks ordered by id.
+func (s *WebhookService) GetList(ctx context.Context) ([]domain.Webhook, error) {
+	return s.webhooks.GetList(ctx)
+}
+
+// Deleting a webhook with its deliveries.
+func (s *WebhookService) Delete(ctx context.Context, id ksuid.KSUID) error {
+	return s.webhooks.Delete(ctx, id)
+}
+
+// Enabling a disabled webhook.
+func (s *WebhookService) Enable(ctx context.Context, id ksuid.KSUID) error {
+	return s.webhooks.Enable(ctx, id)
+}
+
+// Getting a webhook deliveries list page ordered by id.
+func (s *WebhookService) GetDeliveries(ctx context.Context, webhookId ksuid.KSUID, sort domain.SortOptions) ([]domain.WebhookDelivery, domain.PageInfo, error) {
+	if err := checkSortOptions(sort, s.maxPageSize); err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	// Checking is webhook exists.
+	if _, err := s.webhooks.Get(ctx, webhookId); err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	return s.deliveries.GetList(ctx, webhookId, sort)
+}
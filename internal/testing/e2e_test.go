@@ -0,0 +1,1108 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package testing_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/risk"
+	harness "github.com/durudex/durudex-auth-service/internal/testing"
+	"github.com/durudex/durudex-auth-service/pkg/auth"
+	"github.com/durudex/durudex-auth-service/pkg/geoip"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+	sig "github.com/durudex/durudex-auth-service/pkg/webhook"
+	"github.com/durudex/go-protobuf-type/pbtype"
+
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Signing up a new user, returns user id and tokens.
+func signUp(t *testing.T, h *harness.Harness) (ksuid.KSUID, *v1.UserSignUpResponse) {
+	t.Helper()
+
+	h.Code.SetCode("example@durudex.com", 123456)
+
+	tokens, err := h.AuthClient().UserSignUp(context.Background(), &v1.UserSignUpRequest{
+		Username: "example",
+		Email:    "example@durudex.com",
+		Password: "Password123",
+		Secret:   "secret",
+		Code:     123456,
+		Ip:       "127.0.0.1",
+	})
+	if err != nil {
+		t.Fatalf("error signing up: %s", err.Error())
+	}
+
+	subject, err := auth.ValidateAccessToken(tokens.Access, h.Config.Auth.JWT.SigningKey)
+	if err != nil {
+		t.Fatalf("error validating access token: %s", err.Error())
+	}
+
+	userId, err := ksuid.Parse(subject)
+	if err != nil {
+		t.Fatalf("error parsing user id: %s", err.Error())
+	}
+
+	return userId, tokens
+}
+
+// Testing user sign up, sign in and token refresh.
+func TestAuth(t *testing.T) {
+	h := harness.New(t)
+	ctx := context.Background()
+
+	userId, tokens := signUp(t, h)
+
+	// Repeated sign up with the used code is rejected.
+	if _, err := h.AuthClient().UserSignUp(ctx, &v1.UserSignUpRequest{
+		Username: "example",
+		Email:    "example@durudex.com",
+		Password: "Password123",
+		Secret:   "secret",
+		Code:     123456,
+		Ip:       "127.0.0.1",
+	}); err == nil {
+		t.Error("error repeated sign up: got nil error")
+	}
+
+	// Signing in from a new network.
+	signIn, err := h.AuthClient().UserSignIn(ctx, &v1.UserSignInRequest{
+		Username: "example",
+		Password: "Password123",
+		Secret:   "secret",
+		Ip:       "10.0.0.1",
+	})
+	if err != nil {
+		t.Fatalf("error signing in: %s", err.Error())
+	}
+
+	// Refreshing access token of the sign in session.
+	refreshed, err := h.AuthClient().RefreshUserToken(ctx, &v1.RefreshUserTokenRequest{
+		Refresh: signIn.Refresh,
+		Secret:  "secret",
+	})
+	if err != nil {
+		t.Fatalf("error refreshing token: %s", err.Error())
+	}
+
+	subject, err := auth.ValidateAccessToken(refreshed.Access, h.Config.Auth.JWT.SigningKey)
+	if err != nil || subject != userId.String() {
+		t.Errorf("error refreshed access token subject: got %s, want %s", subject, userId)
+	}
+
+	// Refreshing with invalid secret.
+	if _, err := h.AuthClient().RefreshUserToken(ctx, &v1.RefreshUserTokenRequest{
+		Refresh: tokens.Refresh,
+		Secret:  "invalid",
+	}); err == nil {
+		t.Error("error refreshing with invalid secret: got nil error")
+	}
+
+	// Delivering register and logged in emails.
+	if err := h.DispatchEmails(ctx); err != nil {
+		t.Fatalf("error dispatching emails: %s", err.Error())
+	}
+
+	kinds := map[domain.EmailKind]bool{}
+	for _, email := range h.Email.Sent() {
+		kinds[email.Kind] = true
+	}
+
+	if !kinds[domain.EmailUserRegister] || !kinds[domain.EmailUserLoggedIn] {
+		t.Errorf("error sent emails: got %v", h.Email.Sent())
+	}
+}
+
+// Testing user sign out of the refresh token session.
+func TestSignOut(t *testing.T) {
+	h := harness.New(t)
+	ctx := context.Background()
+
+	_, tokens := signUp(t, h)
+
+	// Signing out with invalid secret.
+	if _, err := h.AuthClient().UserSignOut(ctx, &v1.UserSignOutRequest{
+		Refresh: tokens.Refresh,
+		Secret:  "invalid",
+	}); err == nil {
+		t.Error("error signing out with invalid secret: got nil error")
+	}
+
+	if _, err := h.AuthClient().UserSignOut(ctx, &v1.UserSignOutRequest{
+		Refresh: tokens.Refresh,
+		Secret:  "secret",
+	}); err != nil {
+		t.Fatalf("error signing out: %s", err.Error())
+	}
+
+	// Refresh token of the deleted session is rejected.
+	if _, err := h.AuthClient().RefreshUserToken(ctx, &v1.RefreshUserTokenRequest{
+		Refresh: tokens.Refresh,
+		Secret:  "secret",
+	}); !strings.HasSuffix(status.Convert(err).Message(), "Session not found") {
+		t.Errorf("error refreshing signed out session: got %v", err)
+	}
+
+	if err := h.RelayEvents(ctx); err != nil {
+		t.Fatalf("error relaying events: %s", err.Error())
+	}
+
+	// Sign out does not refresh the session.
+	var got []string
+	for _, message := range h.Bus.Sent() {
+		got = append(got, message.Subject)
+	}
+
+	want := []string{
+		"durudex.auth.v1." + string(domain.EventUserSignedUp),
+		"durudex.auth.v1." + string(domain.EventSessionCreated),
+		"durudex.auth.v1." + string(domain.EventSessionRevoked),
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("error sent events: got %v, want %v", got, want)
+	}
+}
+
+// Testing idempotent sign in retries.
+func TestAuth_Idempotency(t *testing.T) {
+	h := harness.New(t)
+
+	userId, _ := signUp(t, h)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "idempotency-key", ksuid.New().String())
+
+	request := &v1.UserSignInRequest{Username: "example", Password: "Password123", Secret: "secret", Ip: "127.0.0.1"}
+
+	first, err := h.AuthClient().UserSignIn(ctx, request)
+	if err != nil {
+		t.Fatalf("error signing in: %s", err.Error())
+	}
+
+	// Retried sign in returns the original tokens.
+	retried, err := h.AuthClient().UserSignIn(ctx, request)
+	if err != nil {
+		t.Fatalf("error retrying sign in: %s", err.Error())
+	}
+
+	if retried.Refresh != first.Refresh {
+		t.Error("error retried sign in: got new refresh token")
+	}
+
+	count, err := h.SessionClient().GetTotalUserSessionCount(context.Background(), &v1.GetTotalUserSessionCountRequest{
+		UserId: userId.Bytes(),
+	})
+	if err != nil {
+		t.Fatalf("error getting session count: %s", err.Error())
+	}
+
+	// Sign up and one sign in sessions.
+	if count.Count != 2 {
+		t.Errorf("error session count: got %d, want %d", count.Count, 2)
+	}
+
+	// Reusing the key with different parameters.
+	request.Ip = "127.0.0.2"
+
+	if _, err := h.AuthClient().UserSignIn(ctx, request); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("error status code: got %s, want %s", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+// Testing logged in emails of sign ins from new networks and devices.
+func TestLoginAlerts(t *testing.T) {
+	// Number of recent sessions is limited by the maximum page size.
+	cfg := harness.DefaultConfig()
+	cfg.Auth.LoginAlert.Sessions = cfg.GRPC.Validation.MaxPageSize + 1
+
+	h := harness.NewWithConfig(t, cfg)
+	ctx := context.Background()
+
+	userId, _ := signUp(t, h)
+
+	h.Geo["81.2.69.142"] = geoip.Location{Country: "GB", City: "London"}
+
+	const iPhone = "Mozilla/5.0 (iPhone; CPU iPhone OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.6 Mobile/15E148 Safari/604.1"
+
+	// Tests structures.
+	tests := []struct {
+		name      string
+		ip        string
+		userAgent string
+		want      bool
+	}{
+		{name: "Sign up network and device", ip: "127.0.0.2"},
+		{name: "New network", ip: "81.2.69.142", want: true},
+		{name: "New device", ip: "81.2.69.200", userAgent: iPhone, want: true},
+		{name: "Known network and device", ip: "81.2.69.142", userAgent: iPhone},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		if _, err := h.AuthClient().UserSignIn(ctx, &v1.UserSignInRequest{
+			Username:  "example",
+			Password:  "Password123",
+			Secret:    "secret",
+			Ip:        tt.ip,
+			UserAgent: tt.userAgent,
+		}); err != nil {
+			t.Fatalf("error signing in: %s", err.Error())
+		}
+
+		before := len(h.Email.Sent())
+
+		if err := h.DispatchEmails(ctx); err != nil {
+			t.Fatalf("error dispatching emails: %s", err.Error())
+		}
+
+		var got []domain.Email
+		for _, email := range h.Email.Sent()[before:] {
+			if email.Kind == domain.EmailUserLoggedIn {
+				got = append(got, email)
+			}
+		}
+
+		if (len(got) == 1) != tt.want || len(got) > 1 || (tt.want && got[0].Ip != tt.ip) {
+			t.Errorf("%s: error logged in emails: got %v", tt.name, got)
+		}
+	}
+
+	// Sign in location is added to the session.
+
+	first := int32(10)
+
+	network, _ := domain.ParseNetwork("81.2.69.142")
+
+	sessions, _, err := h.Repos.Session.GetList(ctx, userId, domain.SessionFilter{Network: network}, domain.SortOptions{First: &first})
+	if err != nil {
+		t.Fatalf("error getting sessions: %s", err.Error())
+	}
+
+	for _, session := range sessions {
+		if session.Country != "GB" || session.City != "London" {
+			t.Errorf("error session location: got %+v", session)
+		}
+	}
+}
+
+// Testing sign in risk decisions and recorded assessments.
+func TestRisk(t *testing.T) {
+	// Blocklist of the ip reputation signal.
+	blocklist := filepath.Join(t.TempDir(), "blocklist.txt")
+	if err := os.WriteFile(blocklist, []byte("# Test networks.\n203.0.113.0/24\n"), 0o600); err != nil {
+		t.Fatalf("error writing blocklist: %s", err.Error())
+	}
+
+	cfg := harness.DefaultConfig()
+	cfg.Risk.Reputation = config.RiskReputationConfig{Blocklist: blocklist, Score: 100}
+
+	h := harness.NewWithConfig(t, cfg)
+	ctx := context.Background()
+
+	userId, _ := signUp(t, h)
+
+	h.Geo["81.2.69.142"] = geoip.Location{Country: "GB", City: "London", Latitude: 51.5142, Longitude: -0.0931}
+	h.Geo["1.0.16.1"] = geoip.Location{Country: "JP", City: "Tokyo", Latitude: 35.6895, Longitude: 139.6917}
+
+	signIn := func(password, ip string, code uint64) error {
+		_, err := h.AuthClient().UserSignIn(ctx, &v1.UserSignInRequest{
+			Username: "example",
+			Password: password,
+			Secret:   "secret",
+			Ip:       ip,
+			Code:     code,
+		})
+
+		return err
+	}
+
+	// Sign in from the sign up network and device is allowed.
+	if err := signIn("Password123", "127.0.0.1", 0); err != nil {
+		t.Fatalf("error signing in: %s", err.Error())
+	}
+
+	// Sign in from the blocklisted network is blocked even with the valid password.
+	if err := signIn("Password123", "203.0.113.7", 0); !strings.HasSuffix(status.Convert(err).Message(), "Sign in is blocked") {
+		t.Errorf("error blocked sign in: got %v", err)
+	}
+
+	// Sign in from London and then from Tokyo is the impossible travel.
+	if err := signIn("Password123", "81.2.69.142", 0); err != nil {
+		t.Fatalf("error signing in: %s", err.Error())
+	}
+
+	if err := signIn("Password123", "1.0.16.1", 0); !strings.HasSuffix(status.Convert(err).Message(), "Email verification code is required") {
+		t.Errorf("error challenged sign in: got %v", err)
+	}
+
+	h.Code.SetCode("example@durudex.com", 654321)
+
+	if err := signIn("Password123", "1.0.16.1", 123456); !strings.HasSuffix(status.Convert(err).Message(), "Invalid verification code") {
+		t.Errorf("error challenged sign in with invalid code: got %v", err)
+	}
+
+	// Challenged sign in is allowed with the email verification code.
+	if err := signIn("Password123", "1.0.16.1", 654321); err != nil {
+		t.Fatalf("error signing in with verification code: %s", err.Error())
+	}
+
+	// Failed attempts velocity challenges the sign in from the known network.
+	for i := int32(0); i < cfg.Risk.Velocity.MaxFailures; i++ {
+		if err := signIn("wrong", "127.0.0.1", 0); err == nil {
+			t.Fatal("error signing in with invalid password")
+		}
+	}
+
+	if err := signIn("Password123", "127.0.0.1", 0); !strings.HasSuffix(status.Convert(err).Message(), "Email verification code is required") {
+		t.Errorf("error challenged sign in after failed attempts: got %v", err)
+	}
+
+	last := int32(10)
+
+	assessments, _, err := h.Repos.Risk.GetList(ctx, userId, domain.SortOptions{Last: &last})
+	if err != nil {
+		t.Fatalf("error getting risk assessments: %s", err.Error())
+	}
+
+	// Assessments within the same second are not ordered.
+	decisions := make(map[domain.RiskDecision]int)
+	signals := make(map[domain.RiskDecision]map[string]bool)
+
+	for _, assessment := range assessments {
+		decisions[assessment.Decision]++
+
+		if signals[assessment.Decision] == nil {
+			signals[assessment.Decision] = make(map[string]bool)
+		}
+
+		for _, signal := range assessment.Signals {
+			signals[assessment.Decision][signal.Name] = true
+		}
+	}
+
+	want := map[domain.RiskDecision]int{domain.RiskAllow: 2, domain.RiskBlock: 1, domain.RiskChallenge: 4}
+	if !reflect.DeepEqual(decisions, want) {
+		t.Errorf("error risk decisions: got %v, want %v", decisions, want)
+	}
+
+	// Contributing signals are recorded with the decision.
+	if !signals[domain.RiskBlock][risk.SignalReputation] || !signals[domain.RiskChallenge][risk.SignalTravel] ||
+		!signals[domain.RiskChallenge][risk.SignalVelocity] {
+		t.Errorf("error risk signals: got %v", signals)
+	}
+}
+
+// Testing user sessions listing and revocation.
+func TestSessions(t *testing.T) {
+	h := harness.New(t)
+	ctx := context.Background()
+
+	userId, tokens := signUp(t, h)
+
+	first := int32(10)
+
+	sessions, err := h.SessionClient().GetUserSessions(ctx, &v1.GetUserSessionsRequest{
+		UserId:      userId.Bytes(),
+		SortOptions: &pbtype.SortOptions{First: &first},
+	})
+	if err != nil {
+		t.Fatalf("error getting sessions: %s", err.Error())
+	}
+
+	if len(sessions.Edges) != 1 || sessions.Edges[0].Node.Ip != "127.0.0.1" {
+		t.Fatalf("error sessions: got %v", sessions.Edges)
+	}
+
+	id := sessions.Edges[0].Node.Id
+
+	// Revoking the session.
+	if _, err := h.SessionClient().DeleteUserSession(ctx, &v1.DeleteUserSessionRequest{
+		Id:     id,
+		UserId: userId.Bytes(),
+	}); err != nil {
+		t.Fatalf("error deleting session: %s", err.Error())
+	}
+
+	if _, err := h.SessionClient().GetUserSession(ctx, &v1.GetUserSessionRequest{
+		Id:     id,
+		UserId: userId.Bytes(),
+	}); err == nil {
+		t.Error("error getting revoked session: got nil error")
+	}
+
+	// Revoked session refresh token can not be used.
+	if _, err := h.AuthClient().RefreshUserToken(ctx, &v1.RefreshUserTokenRequest{
+		Refresh: tokens.Refresh,
+		Secret:  "secret",
+	}); err == nil {
+		t.Error("error refreshing revoked session: got nil error")
+	}
+}
+
+// Testing user sessions cursor pagination.
+func TestSessions_Pagination(t *testing.T) {
+	h := harness.New(t)
+	ctx := context.Background()
+
+	userId, _ := signUp(t, h)
+
+	// Creating more user sessions.
+	for i := 0; i < 4; i++ {
+		if _, err := h.AuthClient().UserSignIn(ctx, &v1.UserSignInRequest{
+			Username: "example",
+			Password: "Password123",
+			Secret:   "secret",
+			Ip:       "127.0.0.1",
+		}); err != nil {
+			t.Fatalf("error signing in: %s", err.Error())
+		}
+	}
+
+	size := int32(2)
+
+	// Paging forward.
+	var (
+		forward [][]byte
+		ids     []ksuid.KSUID
+		after   []byte
+	)
+
+	for {
+		page, err := h.SessionClient().GetUserSessions(ctx, &v1.GetUserSessionsRequest{
+			UserId:      userId.Bytes(),
+			SortOptions: &pbtype.SortOptions{First: &size, After: after},
+		})
+		if err != nil {
+			t.Fatalf("error getting sessions: %s", err.Error())
+		}
+
+		for _, edge := range page.Edges {
+			forward = append(forward, edge.Cursor)
+			ids = append(ids, ksuid.FromBytesOrNil(edge.Node.Id))
+		}
+
+		if page.PageInfo.HasPreviousPage != (after != nil) {
+			t.Errorf("error has previous page: got %t", page.PageInfo.HasPreviousPage)
+		}
+
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+
+		after = page.PageInfo.EndCursor
+	}
+
+	if len(forward) != 5 {
+		t.Fatalf("error number of sessions: got %d, want %d", len(forward), 5)
+	}
+
+	for i := 1; i < len(ids); i++ {
+		if ksuid.Compare(ids[i-1], ids[i]) >= 0 {
+			t.Fatalf("error sessions order at %d", i)
+		}
+	}
+
+	// Paging backward returns pages in the same order.
+	page, err := h.SessionClient().GetUserSessions(ctx, &v1.GetUserSessionsRequest{
+		UserId:      userId.Bytes(),
+		SortOptions: &pbtype.SortOptions{Last: &size, Before: forward[4]},
+	})
+	if err != nil {
+		t.Fatalf("error getting sessions: %s", err.Error())
+	}
+
+	if len(page.Edges) != 2 || string(page.Edges[0].Cursor) != string(forward[2]) ||
+		string(page.Edges[1].Cursor) != string(forward[3]) {
+		t.Errorf("error last page: got %v", page.Edges)
+	}
+
+	if !page.PageInfo.HasPreviousPage || !page.PageInfo.HasNextPage {
+		t.Errorf("error last page info: got %v", page.PageInfo)
+	}
+
+	// Page size is capped.
+	size = h.Config.GRPC.Validation.MaxPageSize + 1
+
+	if _, err := h.SessionClient().GetUserSessions(ctx, &v1.GetUserSessionsRequest{
+		UserId:      userId.Bytes(),
+		SortOptions: &pbtype.SortOptions{First: &size},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("error status code: got %s, want %s", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+// Testing user sessions filtering.
+func TestSessions_Filter(t *testing.T) {
+	h := harness.New(t)
+	ctx := context.Background()
+
+	userId, _ := signUp(t, h)
+
+	// Creating user sessions from different devices and networks.
+	for _, input := range []struct{ ip, userAgent string }{
+		{ip: "10.0.0.1", userAgent: "Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0 Mobile Safari/537.36"},
+		{ip: "10.0.0.2", userAgent: "Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/117.0"},
+		{ip: "192.168.0.1", userAgent: "Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0 Mobile Safari/537.36"},
+	} {
+		if _, err := h.AuthClient().UserSignIn(ctx, &v1.UserSignInRequest{
+			Username:  "example",
+			Password:  "Password123",
+			Secret:    "secret",
+			Ip:        input.ip,
+			UserAgent: input.userAgent,
+		}); err != nil {
+			t.Fatalf("error signing in: %s", err.Error())
+		}
+	}
+
+	size := int32(1)
+
+	// Paging through the filtered sessions.
+	var (
+		sessions []*v1.UserSession
+		after    []byte
+	)
+
+	for {
+		page, err := h.SessionClient().GetUserSessions(ctx, &v1.GetUserSessionsRequest{
+			UserId:      userId.Bytes(),
+			SortOptions: &pbtype.SortOptions{First: &size, After: after},
+			Filter: &v1.UserSessionFilter{
+				Ip:         proto.String("10.0.0.0/8"),
+				State:      v1.UserSessionState_USER_SESSION_STATE_ACTIVE,
+				DeviceType: proto.String("mobile"),
+				DeviceName: proto.String("android"),
+			},
+		})
+		if err != nil {
+			t.Fatalf("error getting sessions: %s", err.Error())
+		}
+
+		sessions = append(sessions, page.Sessions...)
+
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+
+		after = page.PageInfo.EndCursor
+	}
+
+	if len(sessions) != 1 {
+		t.Fatalf("error number of sessions: got %d, want %d", len(sessions), 1)
+	}
+
+	if sessions[0].Ip != "10.0.0.1" || sessions[0].DeviceName != "Chrome on Android" {
+		t.Errorf("error filtered session: got %v", sessions[0])
+	}
+
+	// Expired sessions are not found.
+	page, err := h.SessionClient().GetUserSessions(ctx, &v1.GetUserSessionsRequest{
+		UserId:      userId.Bytes(),
+		SortOptions: &pbtype.SortOptions{First: &size},
+		Filter:      &v1.UserSessionFilter{State: v1.UserSessionState_USER_SESSION_STATE_EXPIRED},
+	})
+	if err != nil {
+		t.Fatalf("error getting sessions: %s", err.Error())
+	}
+
+	if len(page.Edges) != 0 {
+		t.Errorf("error expired sessions: got %v", page.Edges)
+	}
+
+	// Unknown device type is rejected.
+	if _, err := h.SessionClient().GetUserSessions(ctx, &v1.GetUserSessionsRequest{
+		UserId:      userId.Bytes(),
+		SortOptions: &pbtype.SortOptions{First: &size},
+		Filter:      &v1.UserSessionFilter{DeviceType: proto.String("toaster")},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("error status code: got %s, want %s", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+// Testing user session step up and access token authentication levels.
+func TestStepUp(t *testing.T) {
+	h := harness.New(t)
+	ctx := context.Background()
+	key := h.Config.Auth.JWT.SigningKey
+
+	userId, _ := signUp(t, h)
+
+	signIn, err := h.AuthClient().UserSignIn(ctx, &v1.UserSignInRequest{
+		Username: "example",
+		Password: "Password123",
+		Secret:   "secret",
+		Ip:       "127.0.0.1",
+	})
+	if err != nil {
+		t.Fatalf("error signing in: %s", err.Error())
+	}
+
+	// Sign in access token is single factor.
+	if _, err := auth.ValidateAccessToken(signIn.Access, key, auth.WithMinLevel(auth.LevelMultiFactor)); !errors.Is(err, auth.ErrInsufficientLevel) {
+		t.Errorf("error validating sign in access token level: got %v, want %v", err, auth.ErrInsufficientLevel)
+	}
+
+	// Stepping up with invalid password.
+	if _, err := h.AuthClient().UserStepUp(ctx, &v1.UserStepUpRequest{
+		Refresh:  signIn.Refresh,
+		Secret:   "secret",
+		Password: "Invalid123",
+		Ip:       "127.0.0.1",
+	}); err == nil || !strings.HasSuffix(status.Convert(err).Message(), "Invalid password") {
+		t.Errorf("error stepping up with invalid password: got %v", err)
+	}
+
+	// Stepping up with invalid code.
+	if _, err := h.AuthClient().UserStepUp(ctx, &v1.UserStepUpRequest{
+		Refresh:  signIn.Refresh,
+		Secret:   "secret",
+		Password: "Password123",
+		Code:     654321,
+		Ip:       "127.0.0.1",
+	}); err == nil || !strings.HasSuffix(status.Convert(err).Message(), "Invalid verification code") {
+		t.Errorf("error stepping up with invalid code: got %v", err)
+	}
+
+	h.Code.SetCode("example@durudex.com", 654321)
+
+	stepUp, err := h.AuthClient().UserStepUp(ctx, &v1.UserStepUpRequest{
+		Refresh:  signIn.Refresh,
+		Secret:   "secret",
+		Password: "Password123",
+		Code:     654321,
+		Ip:       "127.0.0.1",
+	})
+	if err != nil {
+		t.Fatalf("error stepping up: %s", err.Error())
+	}
+
+	subject, err := auth.ValidateAccessToken(stepUp.Access, key, auth.WithMinLevel(auth.LevelMultiFactor), auth.WithMaxAge(time.Minute))
+	if err != nil {
+		t.Fatalf("error validating step up access token: %s", err.Error())
+	}
+
+	if subject != userId.String() {
+		t.Errorf("error step up token subject: got %s, want %s", subject, userId.String())
+	}
+
+	claims, err := auth.ParseAccessToken(stepUp.Access, key)
+	if err != nil {
+		t.Fatalf("error parsing step up access token: %s", err.Error())
+	}
+
+	// Step up access token lifetime is the step up ttl.
+	if ttl := time.Duration(claims.ExpiresAt-claims.AuthTime) * time.Second; ttl < h.Config.Auth.JWT.StepUpTTL || ttl > h.Config.Auth.JWT.StepUpTTL+time.Second {
+		t.Errorf("error step up access token ttl: got %s, want %s", ttl, h.Config.Auth.JWT.StepUpTTL)
+	}
+
+	signInClaims, err := auth.ParseAccessToken(signIn.Access, key)
+	if err != nil {
+		t.Fatalf("error parsing sign in access token: %s", err.Error())
+	}
+
+	// Refreshed access token after step up keeps the sign in authentication.
+	refreshed, err := h.AuthClient().RefreshUserToken(ctx, &v1.RefreshUserTokenRequest{
+		Refresh: signIn.Refresh,
+		Secret:  "secret",
+	})
+	if err != nil {
+		t.Fatalf("error refreshing token: %s", err.Error())
+	}
+
+	refreshedClaims, err := auth.ParseAccessToken(refreshed.Access, key)
+	if err != nil {
+		t.Fatalf("error parsing refreshed access token: %s", err.Error())
+	}
+
+	authn := refreshedClaims.Authentication()
+	if authn.Level != auth.LevelSingleFactor || !reflect.DeepEqual(authn.Methods, signInClaims.AMR) ||
+		refreshedClaims.AuthTime != signInClaims.AuthTime {
+		t.Errorf("error refreshed token authentication: got %v, want %v", refreshedClaims, signInClaims)
+	}
+
+	if _, err := auth.ValidateAccessToken(refreshed.Access, key, auth.WithMinLevel(auth.LevelMultiFactor)); !errors.Is(err, auth.ErrInsufficientLevel) {
+		t.Errorf("error validating refreshed access token level: got %v, want %v", err, auth.ErrInsufficientLevel)
+	}
+
+	size := int32(10)
+
+	// Getting step up events.
+	page, err := h.AuditClient().GetAuthEvents(ctx, &v1.GetAuthEventsRequest{
+		SortOptions: &pbtype.SortOptions{First: &size},
+		Filter: &v1.AuthEventFilter{
+			Subject: proto.String(userId.String()),
+			Kind:    v1.AuthEventKind_AUTH_EVENT_KIND_STEP_UP,
+		},
+	})
+	if err != nil {
+		t.Fatalf("error getting auth events: %s", err.Error())
+	}
+
+	outcomes := map[v1.AuthEventOutcome]int{}
+	for _, edge := range page.Edges {
+		outcomes[edge.Node.Outcome]++
+	}
+
+	if outcomes[v1.AuthEventOutcome_AUTH_EVENT_OUTCOME_SUCCESS] != 1 || outcomes[v1.AuthEventOutcome_AUTH_EVENT_OUTCOME_FAILURE] != 2 {
+		t.Errorf("error step up events: got %v", page.Edges)
+	}
+}
+
+// Testing step up and token refresh of an expired user session.
+func TestStepUp_ExpiredSession(t *testing.T) {
+	cfg := harness.DefaultConfig()
+	cfg.Auth.Session.TTL = -time.Minute
+
+	h := harness.NewWithConfig(t, cfg)
+	ctx := context.Background()
+
+	_, tokens := signUp(t, h)
+
+	if _, err := h.AuthClient().UserStepUp(ctx, &v1.UserStepUpRequest{
+		Refresh:  tokens.Refresh,
+		Secret:   "secret",
+		Password: "Password123",
+		Ip:       "127.0.0.1",
+	}); err == nil || !strings.HasSuffix(status.Convert(err).Message(), "Session is expired") {
+		t.Errorf("error stepping up expired session: got %v", err)
+	}
+
+	if _, err := h.AuthClient().RefreshUserToken(ctx, &v1.RefreshUserTokenRequest{
+		Refresh: tokens.Refresh,
+		Secret:  "secret",
+	}); err == nil || !strings.HasSuffix(status.Convert(err).Message(), "Session is expired") {
+		t.Errorf("error refreshing expired session token: got %v", err)
+	}
+}
+
+// Testing auth audit events of sign up, sign in and token refresh.
+func TestAudit(t *testing.T) {
+	h := harness.New(t)
+	ctx := context.Background()
+
+	userId, tokens := signUp(t, h)
+
+	// Signing in with invalid password.
+	if _, err := h.AuthClient().UserSignIn(ctx, &v1.UserSignInRequest{
+		Username: "example",
+		Password: "invalid",
+		Secret:   "secret",
+		Ip:       "127.0.0.2",
+	}); err == nil {
+		t.Fatal("error signing in with invalid password: got nil error")
+	}
+
+	// Refreshing access token.
+	if _, err := h.AuthClient().RefreshUserToken(ctx, &v1.RefreshUserTokenRequest{
+		Refresh: tokens.Refresh,
+		Secret:  "secret",
+	}); err != nil {
+		t.Fatalf("error refreshing token: %s", err.Error())
+	}
+
+	size := int32(10)
+
+	// Getting user events.
+	page, err := h.AuditClient().GetAuthEvents(ctx, &v1.GetAuthEventsRequest{
+		SortOptions: &pbtype.SortOptions{First: &size},
+		Filter:      &v1.AuthEventFilter{Subject: proto.String(userId.String())},
+	})
+	if err != nil {
+		t.Fatalf("error getting auth events: %s", err.Error())
+	}
+
+	kinds := map[v1.AuthEventKind]bool{}
+	for _, edge := range page.Edges {
+		if edge.Node.Outcome != v1.AuthEventOutcome_AUTH_EVENT_OUTCOME_SUCCESS {
+			t.Errorf("error auth event outcome: got %v", edge.Node)
+		}
+
+		kinds[edge.Node.Kind] = true
+	}
+
+	if len(page.Edges) != 2 || !kinds[v1.AuthEventKind_AUTH_EVENT_KIND_SIGN_UP] || !kinds[v1.AuthEventKind_AUTH_EVENT_KIND_TOKEN_REFRESH] {
+		t.Errorf("error auth events: got %v", page.Edges)
+	}
+
+	// Failed sign in is recorded with the username subject.
+	page, err = h.AuditClient().GetAuthEvents(ctx, &v1.GetAuthEventsRequest{
+		SortOptions: &pbtype.SortOptions{First: &size},
+		Filter: &v1.AuthEventFilter{
+			Subject: proto.String("example"),
+			Kind:    v1.AuthEventKind_AUTH_EVENT_KIND_SIGN_IN,
+			Outcome: v1.AuthEventOutcome_AUTH_EVENT_OUTCOME_FAILURE,
+		},
+	})
+	if err != nil {
+		t.Fatalf("error getting auth events: %s", err.Error())
+	}
+
+	if len(page.Edges) != 1 || page.Edges[0].Node.Ip != "127.0.0.2" || page.Edges[0].Node.Reason == "" {
+		t.Errorf("error failed sign in events: got %v", page.Edges)
+	}
+}
+
+// Testing auth domain events relayed from the outbox.
+func TestEvents(t *testing.T) {
+	h := harness.New(t)
+	ctx := context.Background()
+
+	userId, tokens := signUp(t, h)
+
+	// Refreshing access token.
+	if _, err := h.AuthClient().RefreshUserToken(ctx, &v1.RefreshUserTokenRequest{
+		Refresh: tokens.Refresh,
+		Secret:  "secret",
+	}); err != nil {
+		t.Fatalf("error refreshing token: %s", err.Error())
+	}
+
+	first := int32(10)
+
+	sessions, err := h.SessionClient().GetUserSessions(ctx, &v1.GetUserSessionsRequest{
+		UserId:      userId.Bytes(),
+		SortOptions: &pbtype.SortOptions{First: &first},
+	})
+	if err != nil || len(sessions.Edges) != 1 {
+		t.Fatalf("error getting sessions: %v", err)
+	}
+
+	// Revoking the session of another user is not found and publishes no event.
+	if _, err := h.SessionClient().DeleteUserSession(ctx, &v1.DeleteUserSessionRequest{
+		Id:     sessions.Edges[0].Node.Id,
+		UserId: ksuid.New().Bytes(),
+	}); !strings.HasSuffix(status.Convert(err).Message(), "Session not found") {
+		t.Fatalf("error deleting foreign session: got %v", err)
+	}
+
+	// Revoking the session.
+	if _, err := h.SessionClient().DeleteUserSession(ctx, &v1.DeleteUserSessionRequest{
+		Id:     sessions.Edges[0].Node.Id,
+		UserId: userId.Bytes(),
+	}); err != nil {
+		t.Fatalf("error deleting session: %s", err.Error())
+	}
+
+	// Revoking the deleted session is not found.
+	if _, err := h.SessionClient().DeleteUserSession(ctx, &v1.DeleteUserSessionRequest{
+		Id:     sessions.Edges[0].Node.Id,
+		UserId: userId.Bytes(),
+	}); !strings.HasSuffix(status.Convert(err).Message(), "Session not found") {
+		t.Fatalf("error deleting missing session: got %v", err)
+	}
+
+	// Events are not sent before the relay pass.
+	if sent := h.Bus.Sent(); len(sent) != 0 {
+		t.Fatalf("error sent events before relay: got %d", len(sent))
+	}
+
+	if err := h.RelayEvents(ctx); err != nil {
+		t.Fatalf("error relaying events: %s", err.Error())
+	}
+
+	want := []domain.EventType{
+		domain.EventUserSignedUp,
+		domain.EventSessionCreated,
+		domain.EventSessionRefreshed,
+		domain.EventSessionRevoked,
+	}
+
+	sent := h.Bus.Sent()
+	if len(sent) != len(want) {
+		t.Fatalf("error sent events: got %d, want %d", len(sent), len(want))
+	}
+
+	for i, message := range sent {
+		var envelope v1.AuthDomainEvent
+
+		if err := proto.Unmarshal(message.Payload, &envelope); err != nil {
+			t.Fatalf("error decoding event: %s", err.Error())
+		}
+
+		if envelope.Type != string(want[i]) || message.Subject != "durudex.auth.v1."+string(want[i]) {
+			t.Errorf("error event %d: got %s (%s), want %s", i, envelope.Type, message.Subject, want[i])
+		}
+
+		if envelope.Version != domain.EventVersion || ksuid.FromBytesOrNil(envelope.Id) != message.Id {
+			t.Errorf("error event %d envelope: got %v", i, &envelope)
+		}
+	}
+
+	// Relayed events are removed from the outbox.
+	if err := h.RelayEvents(ctx); err != nil {
+		t.Fatalf("error relaying events: %s", err.Error())
+	}
+
+	if sent := h.Bus.Sent(); len(sent) != len(want) {
+		t.Errorf("error events relayed twice: got %d", len(sent))
+	}
+}
+
+// Testing signed webhooks of auth domain events.
+func TestWebhooks(t *testing.T) {
+	h := harness.New(t)
+	ctx := context.Background()
+
+	var (
+		mu       sync.Mutex
+		received []*http.Request
+		bodies   [][]byte
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		body, _ := io.ReadAll(r.Body)
+		received, bodies = append(received, r), append(bodies, body)
+	}))
+	t.Cleanup(srv.Close)
+
+	const secret = "0123456789abcdef"
+
+	webhook, err := h.WebhookClient().CreateWebhook(ctx, &v1.CreateWebhookRequest{
+		Url:        srv.URL,
+		EventTypes: []string{string(domain.EventSessionCreated), string(domain.EventSessionRevoked)},
+		Secret:     secret,
+	})
+	if err != nil {
+		t.Fatalf("error creating webhook: %s", err.Error())
+	}
+
+	signUp(t, h)
+
+	if err := h.DispatchWebhooks(ctx); err != nil {
+		t.Fatalf("error dispatching webhooks: %s", err.Error())
+	}
+
+	// Only the subscribed session created event is delivered.
+	if len(received) != 1 {
+		t.Fatalf("error received webhooks: got %d, want 1", len(received))
+	}
+
+	if err := sig.Verify(secret, received[0].Header.Get(sig.SignatureHeader), bodies[0], time.Now(), sig.DefaultTolerance); err != nil {
+		t.Errorf("error verifying signature: %s", err.Error())
+	}
+
+	if typ := received[0].Header.Get(sig.EventHeader); typ != string(domain.EventSessionCreated) {
+		t.Errorf("error webhook event type: got %s", typ)
+	}
+
+	first := int32(10)
+
+	deliveries, err := h.WebhookClient().GetWebhookDeliveries(ctx, &v1.GetWebhookDeliveriesRequest{
+		WebhookId:   webhook.Id,
+		SortOptions: &pbtype.SortOptions{First: &first},
+	})
+	if err != nil {
+		t.Fatalf("error getting deliveries: %s", err.Error())
+	}
+
+	if len(deliveries.Edges) != 1 || deliveries.Edges[0].Node.Status != v1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DELIVERED ||
+		deliveries.Edges[0].Node.ResponseCode != http.StatusOK {
+		t.Errorf("error deliveries log: got %v", deliveries.Edges)
+	}
+}
+
+// Testing disabling and re-enabling failing webhooks.
+func TestWebhooks_Disable(t *testing.T) {
+	cfg := harness.DefaultConfig()
+	cfg.Webhook.DisableAfter = 2
+	cfg.Webhook.InitialBackoff, cfg.Webhook.MaxBackoff = 0, 0
+
+	h := harness.NewWithConfig(t, cfg)
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+
+	webhook, err := h.WebhookClient().CreateWebhook(ctx, &v1.CreateWebhookRequest{
+		Url:        srv.URL,
+		EventTypes: []string{string(domain.EventUserSignedUp)},
+		Secret:     "0123456789abcdef",
+	})
+	if err != nil {
+		t.Fatalf("error creating webhook: %s", err.Error())
+	}
+
+	signUp(t, h)
+
+	for i := 0; i < 3; i++ {
+		if err := h.DispatchWebhooks(ctx); err != nil {
+			t.Fatalf("error dispatching webhooks: %s", err.Error())
+		}
+	}
+
+	got, err := h.WebhookClient().GetWebhook(ctx, &v1.GetWebhookRequest{Id: webhook.Id})
+	if err != nil {
+		t.Fatalf("error getting webhook: %s", err.Error())
+	}
+
+	if got.Webhook.Enabled || got.Webhook.Failures != 2 || got.Webhook.DisabledAt == nil {
+		t.Errorf("error disabled webhook: got %v", got.Webhook)
+	}
+
+	first := int32(10)
+
+	deliveries, err := h.WebhookClient().GetWebhookDeliveries(ctx, &v1.GetWebhookDeliveriesRequest{
+		WebhookId:   webhook.Id,
+		SortOptions: &pbtype.SortOptions{First: &first},
+	})
+	if err != nil {
+		t.Fatalf("error getting deliveries: %s", err.Error())
+	}
+
+	if len(deliveries.Edges) != 1 || deliveries.Edges[0].Node.Status != v1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED ||
+		deliveries.Edges[0].Node.Attempts != 2 {
+		t.Errorf("error deliveries log: got %v", deliveries.Edges)
+	}
+
+	// Enabling the webhook resets the failures.
+	if _, err := h.WebhookClient().EnableWebhook(ctx, &v1.EnableWebhookRequest{Id: webhook.Id}); err != nil {
+		t.Fatalf("error enabling webhook: %s", err.Error())
+	}
+
+	got, err = h.WebhookClient().GetWebhook(ctx, &v1.GetWebhookRequest{Id: webhook.Id})
+	if err != nil {
+		t.Fatalf("error getting webhook: %s", err.Error())
+	}
+
+	if !got.Webhook.Enabled || got.Webhook.Failures != 0 || got.Webhook.DisabledAt != nil {
+		t.Errorf("error enabled webhook: got %v", got.Webhook)
+	}
+}
@@ -0,0 +1,254 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package testing provides an end-to-end harness running the full gRPC server in-process
+// with in-memory storage and downstream services.
+package testing
+
+import (
+	"context"
+	"net"
+	gotesting "testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/audit"
+	"github.com/durudex/durudex-auth-service/internal/client"
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/event"
+	"github.com/durudex/durudex-auth-service/internal/outbox"
+	"github.com/durudex/durudex-auth-service/internal/repository"
+	"github.com/durudex/durudex-auth-service/internal/repository/memory"
+	"github.com/durudex/durudex-auth-service/internal/risk"
+	"github.com/durudex/durudex-auth-service/internal/service"
+	transport "github.com/durudex/durudex-auth-service/internal/transport/grpc"
+	"github.com/durudex/durudex-auth-service/internal/webhook"
+	"github.com/durudex/durudex-auth-service/pkg/geoip"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Listener buffer size.
+const bufferSize int = 1024 * 1024
+
+// End-to-end test harness.
+type Harness struct {
+	// Client connection to the gRPC server.
+	Conn *grpc.ClientConn
+	// Client connection to the in-process gRPC server.
+	Local *grpc.ClientConn
+	// In-memory storage.
+	Repos *memory.MemoryRepository
+	// In-memory downstream services.
+	User  *client.FakeUser
+	Code  *client.FakeCode
+	Email *client.FakeEmail
+	// In-memory message bus.
+	Bus *event.FakeBus
+	// Static ip address locations.
+	Geo geoip.Static
+	// Service config.
+	Config *config.Config
+
+	dispatcher *outbox.Dispatcher
+	relay      *event.Relay
+	webhooks   *webhook.Dispatcher
+}
+
+// Getting a default harness config.
+func DefaultConfig() *config.Config {
+	return &config.Config{
+		GRPC: config.GRPCConfig{
+			Validation: config.ValidationConfig{MaxPageSize: 50},
+		},
+		Auth: config.AuthConfig{
+			Session: config.SessionConfig{TTL: time.Hour},
+			JWT:     config.JWTConfig{TTL: time.Minute * 15, StepUpTTL: time.Minute * 5, SigningKey: "durudex-testing-signing-key"},
+			LoginAlert: config.LoginAlertConfig{
+				Lookback:   time.Hour * 720,
+				Sessions:   50,
+				IPv4Prefix: 24,
+				IPv6Prefix: 48,
+			},
+		},
+		Outbox: config.OutboxConfig{
+			BatchSize:      50,
+			Lease:          time.Minute,
+			MaxAttempts:    3,
+			InitialBackoff: time.Second,
+			MaxBackoff:     time.Minute,
+		},
+		Saga: config.SagaConfig{SessionAttempts: 3, RetryBackoff: time.Millisecond * 10},
+		Webhook: config.WebhookConfig{
+			BatchSize:      50,
+			Lease:          time.Minute,
+			Timeout:        time.Second * 5,
+			MaxAttempts:    3,
+			InitialBackoff: time.Second,
+			MaxBackoff:     time.Minute,
+			DisableAfter:   5,
+		},
+		Events: config.EventsConfig{
+			SubjectPrefix: "durudex.auth",
+			Outbox: config.EventOutboxConfig{
+				BatchSize:      50,
+				Lease:          time.Minute,
+				InitialBackoff: time.Second,
+				MaxBackoff:     time.Minute,
+			},
+		},
+		Risk: config.RiskConfig{
+			Enable:         true,
+			ChallengeScore: 50,
+			BlockScore:     100,
+			Velocity:       config.RiskVelocityConfig{Window: time.Minute * 15, MaxFailures: 3, Score: 60},
+			NewDevice:      config.RiskNewDeviceConfig{Score: 30},
+			Travel:         config.RiskTravelConfig{MaxSpeed: 900, Score: 60},
+			TimeOfDay:      config.RiskTimeOfDayConfig{Window: 2, MinSessions: 10, Score: 20},
+		},
+		Idempotency: config.IdempotencyConfig{
+			TTL:   time.Hour,
+			Lease: time.Minute,
+			Methods: []string{
+				"/durudex.v1.UserAuthService/UserSignUp",
+				"/durudex.v1.UserAuthService/UserSignIn",
+			},
+		},
+	}
+}
+
+// Starting a new harness with the default config. The server is stopped on test cleanup.
+func New(t gotesting.TB) *Harness {
+	return NewWithConfig(t, DefaultConfig())
+}
+
+// Starting a new harness with the config.
+func NewWithConfig(t gotesting.TB, cfg *config.Config) *Harness {
+	t.Helper()
+
+	h := &Harness{
+		Repos:  memory.NewMemoryRepository(),
+		User:   client.NewFakeUser(),
+		Code:   client.NewFakeCode(),
+		Email:  client.NewFakeEmail(),
+		Bus:    event.NewFakeBus(),
+		Geo:    geoip.Static{},
+		Config: cfg,
+	}
+
+	repos := &repository.Repository{
+		Session:         h.Repos.Session,
+		Outbox:          h.Repos.Outbox,
+		Saga:            h.Repos.Saga,
+		Idempotency:     h.Repos.Idempotency,
+		Audit:           h.Repos.Audit,
+		EventOutbox:     h.Repos.EventOutbox,
+		Webhook:         h.Repos.Webhook,
+		WebhookDelivery: h.Repos.WebhookDelivery,
+		Risk:            h.Repos.Risk,
+	}
+
+	// Auth audit events are written immediately without the buffer.
+	recorder := audit.NewLog(h.Repos.Audit, config.AuditConfig{})
+
+	// Auth domain events are stored in the in-memory outbox and webhook deliveries.
+	publisher := event.Publishers{
+		event.NewOutboxPublisher(h.Repos.EventOutbox, cfg.Events.SubjectPrefix),
+		webhook.NewPublisher(h.Repos.WebhookDelivery),
+	}
+
+	// Creating a new sign in risk assessor with the static locations.
+	assessor, err := risk.New(h.Repos.Risk, h.Repos.Audit, h.Geo, cfg.Risk)
+	if err != nil {
+		t.Fatalf("error creating sign in risk assessor: %s", err.Error())
+	}
+
+	// Creating a new service with in-memory downstream services.
+	svc := service.NewService(repos, &client.Client{User: h.User, Code: h.Code, Email: h.Email}, recorder, publisher, h.Geo, assessor, cfg)
+
+	h.dispatcher = outbox.NewDispatcher(h.Repos.Outbox, h.Email, cfg.Outbox)
+	h.relay = event.NewRelay(h.Repos.EventOutbox, h.Bus, cfg.Events.Outbox)
+	h.webhooks = webhook.NewDispatcher(h.Repos.Webhook, h.Repos.WebhookDelivery, cfg.Webhook)
+
+	// Running gRPC server on the in-memory listener.
+	lis := bufconn.Listen(bufferSize)
+	srv := transport.NewServer(cfg.GRPC, transport.NewHandler(svc))
+
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("error creating client connection: %s", err.Error())
+	}
+
+	t.Cleanup(func() { conn.Close() })
+
+	// Creating a new in-process client connection.
+	local, err := srv.DialLocal()
+	if err != nil {
+		t.Fatalf("error creating in-process client connection: %s", err.Error())
+	}
+
+	t.Cleanup(func() { local.Close() })
+
+	h.Conn, h.Local = conn, local
+
+	return h
+}
+
+// Getting a user auth service client.
+func (h *Harness) AuthClient() v1.UserAuthServiceClient {
+	return v1.NewUserAuthServiceClient(h.Conn)
+}
+
+// Getting an in-process user session service client.
+func (h *Harness) SessionClient() v1.UserSessionServiceClient {
+	return v1.NewUserSessionServiceClient(h.Local)
+}
+
+// Getting an auth audit service client.
+func (h *Harness) AuditClient() v1.AuthAuditServiceClient {
+	return v1.NewAuthAuditServiceClient(h.Conn)
+}
+
+// Getting an auth webhook service client.
+func (h *Harness) WebhookClient() v1.AuthWebhookServiceClient {
+	return v1.NewAuthWebhookServiceClient(h.Conn)
+}
+
+// Delivering due outbox emails to the in-memory email service.
+func (h *Harness) DispatchEmails(ctx context.Context) error {
+	return h.dispatcher.Dispatch(ctx)
+}
+
+// Relaying outbox auth domain events to the in-memory message bus.
+func (h *Harness) RelayEvents(ctx context.Context) error {
+	return h.relay.Relay(ctx)
+}
+
+// Sending due webhook deliveries to the webhook endpoints.
+func (h *Harness) DispatchWebhooks(ctx context.Context) error {
+	return h.webhooks.Dispatch(ctx)
+}
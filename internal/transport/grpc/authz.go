@@ -0,0 +1,209 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/durudex/durudex-auth-service/internal/audit"
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Rule matching any method or any verified peer identity.
+	authzWildcard string = "*"
+	// Permission denied error message.
+	authzDeniedMessage string = "Permission denied"
+)
+
+// gRPC peer identity authorizer. Peer identities are taken from the verified client
+// certificate, calls without a certificate use the configured local identity.
+type authorizer struct {
+	enable bool
+	// Allowed peer identities by method, service wildcard or global wildcard.
+	rules map[string]map[string]struct{}
+	// Identity of in-process calls.
+	local string
+	// Auth audit recorder of denied calls.
+	audit audit.Recorder
+}
+
+// Creating a new peer identity authorizer. Local identity is used only by the in-process server.
+func newAuthorizer(cfg config.AuthzConfig, local string, recorder audit.Recorder) *authorizer {
+	a := &authorizer{
+		enable: cfg.Enable,
+		rules:  make(map[string]map[string]struct{}, len(cfg.Rules)),
+		local:  local,
+		audit:  recorder,
+	}
+
+	for _, rule := range cfg.Rules {
+		allow, ok := a.rules[rule.Method]
+		if !ok {
+			allow = make(map[string]struct{}, len(rule.Allow))
+			a.rules[rule.Method] = allow
+		}
+
+		for _, identity := range rule.Allow {
+			allow[identity] = struct{}{}
+		}
+	}
+
+	return a
+}
+
+// Unary gRPC peer authorization interceptor.
+func (a *authorizer) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// Stream gRPC peer authorization interceptor.
+func (a *authorizer) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, ss)
+}
+
+// Authorizing the peer to call the method. Denied calls are recorded to the auth audit log.
+func (a *authorizer) authorize(ctx context.Context, method string) error {
+	if !a.enable {
+		return nil
+	}
+
+	identities := a.identities(ctx)
+
+	if a.allowed(method, identities) {
+		return nil
+	}
+
+	record := domain.AuditEvent{
+		Kind:    domain.AuditAuthz,
+		Actor:   strings.Join(identities, ","),
+		Subject: method,
+		Outcome: domain.AuditFailure,
+		Reason:  authzDeniedMessage,
+	}
+
+	event := log.Warn().
+		Str("method", method).
+		Str("request_id", RequestIdFromContext(ctx)).
+		Strs("identities", identities)
+
+	if p, ok := peer.FromContext(ctx); ok {
+		event = event.Str("peer", p.Addr.String())
+
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			record.Ip = host
+		}
+	}
+
+	event.Msg("gRPC call denied")
+
+	a.audit.Record(ctx, record)
+
+	return status.Error(codes.PermissionDenied, authzDeniedMessage)
+}
+
+// Checking is any of the peer identities allowed to call the method.
+func (a *authorizer) allowed(method string, identities []string) bool {
+	if len(identities) == 0 {
+		return false
+	}
+
+	allow, ok := a.rules[method]
+	if !ok {
+		// Getting service wildcard rule, e.g. "/durudex.v1.UserSessionService/*".
+		if i := strings.LastIndexByte(method, '/'); i > 0 {
+			allow, ok = a.rules[method[:i+1]+authzWildcard]
+		}
+	}
+
+	if !ok {
+		// Getting global wildcard rule.
+		if allow, ok = a.rules[authzWildcard]; !ok {
+			return false
+		}
+	}
+
+	if _, ok := allow[authzWildcard]; ok {
+		return true
+	}
+
+	for _, identity := range identities {
+		if _, ok := allow[identity]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Getting peer identities from the verified client certificate SAN and CN.
+func (a *authorizer) identities(ctx context.Context) []string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		// In-process calls have no transport credentials.
+		if a.local != "" {
+			return []string{a.local}
+		}
+
+		return nil
+	}
+
+	if len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	cert := info.State.VerifiedChains[0][0]
+
+	identities := make([]string, 0, len(cert.DNSNames)+len(cert.URIs)+len(cert.EmailAddresses)+1)
+	identities = append(identities, cert.DNSNames...)
+
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+
+	identities = append(identities, cert.EmailAddresses...)
+
+	if cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+
+	return identities
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Auth audit recorder capturing recorded events.
+type auditRecorder struct{ events []domain.AuditEvent }
+
+// Capturing auth audit events.
+func (r *auditRecorder) Record(_ context.Context, events ...domain.AuditEvent) {
+	r.events = append(r.events, events...)
+}
+
+// Creating a new peer context with verified client certificate.
+func peerContext(cert *x509.Certificate) context.Context {
+	p := &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1}}
+
+	if cert != nil {
+		p.AuthInfo = credentials.TLSInfo{State: tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{cert}},
+		}}
+	}
+
+	return peer.NewContext(context.Background(), p)
+}
+
+// Testing peer identity authorization.
+func TestAuthorizer_Authorize(t *testing.T) {
+	// Testing args.
+	type args struct {
+		method string
+		cert   *x509.Certificate
+		local  string
+	}
+
+	cfg := config.AuthzConfig{
+		Enable: true,
+		Rules: []config.AuthzRuleConfig{
+			{Method: "/durudex.v1.UserAuthService/UserSignIn", Allow: []string{"gateway.durudex.local"}},
+			{Method: "/durudex.v1.AdminService/*", Allow: []string{"admin"}},
+			{Method: "*", Allow: []string{"*"}},
+		},
+	}
+
+	gateway := &x509.Certificate{DNSNames: []string{"gateway.durudex.local"}}
+	admin := &x509.Certificate{Subject: pkix.Name{CommonName: "admin"}}
+
+	// Tests structures.
+	tests := []struct {
+		name string
+		args args
+		want codes.Code
+	}{
+		{
+			name: "Allowed by SAN",
+			args: args{method: "/durudex.v1.UserAuthService/UserSignIn", cert: gateway},
+			want: codes.OK,
+		},
+		{
+			name: "Denied by method rule",
+			args: args{method: "/durudex.v1.UserAuthService/UserSignIn", cert: admin},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "Allowed by CN service wildcard",
+			args: args{method: "/durudex.v1.AdminService/RevokeSessions", cert: admin},
+			want: codes.OK,
+		},
+		{
+			name: "Denied by service wildcard",
+			args: args{method: "/durudex.v1.AdminService/RevokeSessions", cert: gateway},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "Allowed by global wildcard",
+			args: args{method: "/durudex.v1.UserSessionService/GetUserSession", cert: admin},
+			want: codes.OK,
+		},
+		{
+			name: "Denied without certificate",
+			args: args{method: "/durudex.v1.UserSessionService/GetUserSession"},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "Allowed local identity",
+			args: args{method: "/durudex.v1.UserAuthService/UserSignIn", local: "gateway.durudex.local"},
+			want: codes.OK,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &auditRecorder{}
+
+			err := newAuthorizer(cfg, tt.args.local, recorder).authorize(peerContext(tt.args.cert), tt.args.method)
+
+			// Check for similarity of status code.
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("error status code: got %s, want %s", got, tt.want)
+			}
+
+			// Check denied call is recorded to the audit log.
+			if tt.want == codes.OK {
+				if len(recorder.events) != 0 {
+					t.Errorf("error allowed call audit events: got %v", recorder.events)
+				}
+
+				return
+			}
+
+			if len(recorder.events) != 1 {
+				t.Fatalf("error denied call audit events: got %v", recorder.events)
+			}
+
+			event := recorder.events[0]
+			if event.Kind != domain.AuditAuthz || event.Outcome != domain.AuditFailure ||
+				event.Subject != tt.args.method || event.Ip != "127.0.0.1" {
+				t.Errorf("error denied call audit event: got %+v", event)
+			}
+		})
+	}
+}
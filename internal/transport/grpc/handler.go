@@ -36,3 +36,8 @@ func NewHandler(service *service.Service) *Handler {
 func (h *Handler) RegisterHandlers(srv *grpc.Server) {
 	v1.NewHandler(h.service).RegisterHandlers(srv)
 }
+
+// Registering in-process gRPC version handlers.
+func (h *Handler) RegisterLocalHandlers(srv *grpc.Server) {
+	v1.NewHandler(h.service).RegisterLocalHandlers(srv)
+}
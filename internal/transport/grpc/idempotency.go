@@ -0,0 +1,145 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"crypto/sha256"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/service"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// Idempotency key metadata key.
+	IdempotencyKey string = "idempotency-key"
+	// Idempotency key length limits.
+	minIdempotencyKeyLength int = 16
+	maxIdempotencyKeyLength int = 255
+)
+
+// Unary gRPC idempotency key interceptor. Retried calls with the same key and parameters get
+// the response of the completed call instead of being executed again.
+func idempotencyUnary(s service.Idempotency) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		msg, ok := req.(proto.Message)
+		if !ok || !s.Supports(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		key := idempotencyKeyFromContext(ctx)
+		if key == "" {
+			return handler(ctx, req)
+		} else if len(key) < minIdempotencyKeyLength || len(key) > maxIdempotencyKeyLength {
+			return nil, status.Error(codes.InvalidArgument, "Invalid idempotency key")
+		}
+
+		// Getting a fingerprint of the request parameters.
+		data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		fingerprint := sha256.Sum256(data)
+
+		stored, err := s.Begin(ctx, key, info.FullMethod, fingerprint[:])
+		switch err {
+		case nil:
+		case domain.ErrIdempotencyKeyMismatch:
+			return nil, status.Error(codes.InvalidArgument, err.(*domain.Error).Message)
+		case domain.ErrIdempotencyKeyInProgress:
+			return nil, status.Error(codes.Aborted, err.(*domain.Error).Message)
+		default:
+			return nil, err
+		}
+
+		// Returning the response of the completed call.
+		if stored != nil {
+			var response anypb.Any
+
+			if err := proto.Unmarshal(stored, &response); err != nil {
+				return nil, err
+			}
+
+			return response.UnmarshalNew()
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			// Releasing the key, so the failed call can be retried.
+			if err := s.Release(ctx, key, info.FullMethod); err != nil {
+				log.Error().Err(err).Str("method", info.FullMethod).Msg("failed to release idempotency key")
+			}
+
+			return resp, err
+		}
+
+		// Storing the response of the completed call.
+		if err := complete(ctx, s, key, info.FullMethod, resp); err != nil {
+			log.Error().Err(err).Str("method", info.FullMethod).Msg("failed to store idempotent response")
+
+			if err := s.Release(ctx, key, info.FullMethod); err != nil {
+				log.Error().Err(err).Str("method", info.FullMethod).Msg("failed to release idempotency key")
+			}
+		}
+
+		return resp, nil
+	}
+}
+
+// Storing the response of the completed idempotent call.
+func complete(ctx context.Context, s service.Idempotency, key, method string, resp interface{}) error {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "response of %s is not a proto message", method)
+	}
+
+	response, err := anypb.New(msg)
+	if err != nil {
+		return err
+	}
+
+	data, err := proto.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	return s.Complete(ctx, key, method, data)
+}
+
+// Getting an idempotency key from the incoming metadata.
+func idempotencyKeyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(IdempotencyKey); len(values) != 0 {
+		return values[0]
+	}
+
+	return ""
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/repository/memory"
+	"github.com/durudex/durudex-auth-service/internal/service"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Testing idempotency key interceptor.
+func TestIdempotencyUnary(t *testing.T) {
+	const (
+		method = "/durudex.v1.UserAuthService/UserSignIn"
+		key    = "3b2f1c0e-7d7a-4b0f-9a51-0c7e1e3c9a11"
+	)
+
+	interceptor := idempotencyUnary(service.NewIdempotencyService(
+		memory.NewMemoryRepository().Idempotency,
+		config.IdempotencyConfig{TTL: time.Hour, Lease: time.Minute, Methods: []string{method}},
+	))
+
+	var (
+		calls int
+		fail  bool
+	)
+
+	// Sign in handler returning new tokens on every call.
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		calls++
+
+		if fail {
+			return nil, errors.New("user service unavailable")
+		}
+
+		return &v1.UserSignInResponse{Access: time.Now().String()}, nil
+	}
+
+	// Calling the interceptor with the idempotency key.
+	call := func(key, username string) (*v1.UserSignInResponse, error) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKey, key))
+
+		resp, err := interceptor(ctx, &v1.UserSignInRequest{Username: username}, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		if err != nil {
+			return nil, err
+		}
+
+		return resp.(*v1.UserSignInResponse), nil
+	}
+
+	// Failed call is not stored and can be retried.
+	fail = true
+
+	if _, err := call(key, "example"); err == nil {
+		t.Fatal("error failed call: got nil error")
+	}
+
+	fail = false
+
+	first, err := call(key, "example")
+	if err != nil {
+		t.Fatalf("error first call: %s", err.Error())
+	}
+
+	// Retried call returns the original response.
+	retried, err := call(key, "example")
+	if err != nil {
+		t.Fatalf("error retried call: %s", err.Error())
+	}
+
+	if !proto.Equal(first, retried) || calls != 2 {
+		t.Errorf("error retried call: got %v with %d calls, want %v with %d calls", retried, calls, first, 2)
+	}
+
+	// Reused key with different parameters is rejected.
+	if _, err := call(key, "other"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("error reused key status code: got %s, want %s", status.Code(err), codes.InvalidArgument)
+	}
+
+	// Too short key is rejected.
+	if _, err := call("short", "example"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("error short key status code: got %s, want %s", status.Code(err), codes.InvalidArgument)
+	}
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/pkg/redact"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Request field name used as the access log subject.
+const subjectField protoreflect.Name = "user_id"
+
+// gRPC access log structure.
+type accessLog struct {
+	// Log redacted request payload at debug level.
+	payload bool
+	// Method samplers of the successful calls.
+	samplers map[string]zerolog.Sampler
+	// Request payload redactor.
+	redactor *redact.Redactor
+}
+
+// Creating a new gRPC access log.
+func newAccessLog(cfg config.LogConfig) *accessLog {
+	samplers := make(map[string]zerolog.Sampler, len(cfg.Sampling))
+
+	for _, s := range cfg.Sampling {
+		if s.Every > 1 {
+			samplers[s.Method] = &zerolog.BasicSampler{N: s.Every}
+		}
+	}
+
+	return &accessLog{
+		payload:  cfg.Payload,
+		samplers: samplers,
+		redactor: redact.New(redact.DefaultFields...),
+	}
+}
+
+// Unary gRPC access log interceptor.
+func (l *accessLog) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+
+	// Getting a request id.
+	ctx, id := withRequestId(ctx)
+	if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIdKey, id)); err != nil {
+		log.Warn().Err(err).Msg("failed to set request id header")
+	}
+
+	res, err := handler(ctx, req)
+
+	l.log(ctx, info.FullMethod, req, start, err)
+
+	return res, err
+}
+
+// Stream gRPC access log interceptor.
+func (l *accessLog) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+
+	// Getting a request id.
+	ctx, id := withRequestId(ss.Context())
+	if err := ss.SetHeader(metadata.Pairs(RequestIdKey, id)); err != nil {
+		log.Warn().Err(err).Msg("failed to set request id header")
+	}
+
+	err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+
+	l.log(ctx, info.FullMethod, nil, start, err)
+
+	return err
+}
+
+// Writing an access log entry.
+func (l *accessLog) log(ctx context.Context, method string, req interface{}, start time.Time, err error) {
+	code := status.Code(err)
+
+	// Sampling successful calls of the high-volume methods.
+	if sampler, ok := l.samplers[method]; ok && code == codes.OK && !sampler.Sample(zerolog.InfoLevel) {
+		return
+	}
+
+	event := log.WithLevel(codeLevel(code)).
+		Str("method", method).
+		Str("request_id", RequestIdFromContext(ctx)).
+		Dur("duration", time.Since(start)).
+		Str("code", code.String())
+
+	// Getting a client address.
+	if p, ok := peer.FromContext(ctx); ok {
+		event.Str("peer", p.Addr.String())
+	}
+
+	msg, _ := req.(proto.Message)
+
+	// Getting a request subject.
+	if subject := requestSubject(msg); subject != "" {
+		event.Str("subject", subject)
+	}
+
+	// Added redacted request payload.
+	if l.payload && msg != nil && zerolog.GlobalLevel() <= zerolog.DebugLevel {
+		if payload, err := protojson.Marshal(l.redactor.Redact(msg)); err == nil {
+			event.RawJSON("request", payload)
+		}
+	}
+
+	if err != nil {
+		event.Str("error", status.Convert(err).Message())
+	}
+
+	event.Msg("gRPC call")
+}
+
+// Getting a request subject from the user id field of the request.
+func requestSubject(msg proto.Message) string {
+	if msg == nil {
+		return ""
+	}
+
+	m := msg.ProtoReflect()
+
+	// Getting a subject field.
+	fd := m.Descriptor().Fields().ByName(subjectField)
+	if fd == nil || fd.Kind() != protoreflect.BytesKind || !m.Has(fd) {
+		return ""
+	}
+
+	// Parsing user id bytes.
+	id, err := ksuid.FromBytes(m.Get(fd).Bytes())
+	if err != nil {
+		return ""
+	}
+
+	return id.String()
+}
+
+// Getting an access log level by gRPC status code.
+func codeLevel(code codes.Code) zerolog.Level {
+	switch code {
+	case codes.OK:
+		return zerolog.InfoLevel
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded, codes.Unimplemented:
+		return zerolog.ErrorLevel
+	default:
+		return zerolog.WarnLevel
+	}
+}
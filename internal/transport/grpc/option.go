@@ -18,9 +18,10 @@
 package grpc
 
 import (
-	"context"
-
 	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/metrics"
+	"github.com/durudex/durudex-auth-service/internal/service"
+	"github.com/durudex/durudex-auth-service/internal/validator"
 	"github.com/durudex/durudex-auth-service/pkg/tls"
 
 	"github.com/rs/zerolog/log"
@@ -28,43 +29,48 @@ import (
 	"google.golang.org/grpc/credentials"
 )
 
-// Getting gRPC server options.
-func getOptions(cfg config.TLSConfig) []grpc.ServerOption {
+// Getting gRPC server interceptor options. The authorizer differs between the network and
+// in-process servers.
+func getOptions(cfg config.GRPCConfig, authz *authorizer, idempotency service.Idempotency) []grpc.ServerOption {
 	log.Debug().Msg("Getting gRPC server options...")
 
-	var opts []grpc.ServerOption
-
-	// Added basic server options.
-	opts = append(opts,
-		// Unary interceptor.
-		grpc.UnaryInterceptor(unaryInterceptor),
-		// Stream interceptor.
-		grpc.StreamInterceptor(streamInterceptor),
-	)
+	// Creating a new access log.
+	accessLog := newAccessLog(cfg.Log)
+	// Creating a new request validator.
+	requestValidator := validator.NewValidator(cfg.Validation)
 
-	if cfg.Enable {
-		creds, err := tls.LoadTLSConfig(cfg.CACert, cfg.Cert, cfg.Key)
-		if err != nil {
-			log.Fatal().Err(err).Msg("failed to load TLS credentials")
-		}
-
-		// Append server credential options.
-		opts = append(opts, grpc.Creds(credentials.NewTLS(creds)))
+	return []grpc.ServerOption{
+		// Unary interceptors.
+		grpc.ChainUnaryInterceptor(
+			accessLog.unary, recoveryUnary, authz.unary, validationUnary(requestValidator),
+			idempotencyUnary(idempotency),
+		),
+		// Stream interceptors.
+		grpc.ChainStreamInterceptor(accessLog.stream, recoveryStream, authz.stream),
 	}
-
-	return opts
 }
 
-// Unary gRPC server interceptor.
-func unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	log.Info().Str("method", info.FullMethod).Msg("Unary interceptor")
+// Getting gRPC server credential options. Returned reloader watches credentials files.
+func getCredentialOptions(cfg config.TLSConfig) ([]grpc.ServerOption, *tls.Reloader) {
+	if !cfg.Enable {
+		return nil, nil
+	}
 
-	return handler(ctx, req)
-}
+	reloader, err := tls.NewReloader(cfg.CACert, cfg.Cert, cfg.Key, metrics.ObserveCertificate("server"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load TLS credentials")
+	}
 
-// Stream gRPC server interceptor.
-func streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-	log.Info().Str("method", info.FullMethod).Msg("Stream interceptor")
+	// Creating a new server TLS config.
+	creds, err := reloader.ServerConfig(tls.Policy{
+		MinVersion:   cfg.MinVersion,
+		MaxVersion:   cfg.MaxVersion,
+		CipherSuites: cfg.CipherSuites,
+		ClientAuth:   cfg.ClientAuth,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid TLS policy")
+	}
 
-	return handler(srv, ss)
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(creds))}, reloader
 }
@@ -0,0 +1,60 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/durudex/durudex-auth-service/internal/recovery"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error returned to the client on recovered panic.
+var errPanic = status.Error(codes.Internal, "Internal server error")
+
+// Unary gRPC panic recovery interceptor.
+func recoveryUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger := log.With().Str("request_id", RequestIdFromContext(ctx)).Logger()
+			recovery.Report(logger, recovery.SourceUnary, info.FullMethod, r)
+
+			res, err = nil, errPanic
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// Stream gRPC panic recovery interceptor.
+func recoveryStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger := log.With().Str("request_id", RequestIdFromContext(ss.Context())).Logger()
+			recovery.Report(logger, recovery.SourceStream, info.FullMethod, r)
+
+			err = errPanic
+		}
+	}()
+
+	return handler(srv, ss)
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Request id metadata key.
+const RequestIdKey string = "x-request-id"
+
+// Maximum length of the request id received from the client.
+const maxRequestIdLength int = 128
+
+// Request id context key.
+type requestIdContextKey struct{}
+
+// Getting a request id from the context.
+func RequestIdFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIdContextKey{}).(string)
+	return id
+}
+
+// Getting a request id from the incoming metadata or generating a new one.
+func withRequestId(ctx context.Context) (context.Context, string) {
+	// Checking is request id already set.
+	if id := RequestIdFromContext(ctx); id != "" {
+		return ctx, id
+	}
+
+	var id string
+
+	// Getting request id from incoming metadata.
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIdKey); len(values) != 0 && len(values[0]) <= maxRequestIdLength {
+			id = values[0]
+		}
+	}
+
+	// Generating a new request id.
+	if id == "" {
+		id = ksuid.New().String()
+	}
+
+	return context.WithValue(ctx, requestIdContextKey{}, id), id
+}
+
+// gRPC server stream with overridden context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Getting server stream context.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
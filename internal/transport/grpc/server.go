@@ -18,30 +18,79 @@
 package grpc
 
 import (
+	"context"
+	"errors"
 	"net"
+	"net/http"
+	"time"
 
 	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/recovery"
+	"github.com/durudex/durudex-auth-service/pkg/tls"
 
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	// In-process listener buffer size.
+	localBufferSize int = 1024 * 1024
+	// gRPC-Web server shutdown timeout.
+	webShutdownTimeout time.Duration = time.Second * 5
 )
 
 // gRPC server structure.
 type Server struct {
-	server  *grpc.Server
+	server *grpc.Server
+	// In-process server without transport credentials.
+	local    *grpc.Server
+	listener *bufconn.Listener
+	// TLS credentials reloader, nil when TLS is disabled.
+	reloader *tls.Reloader
+	// gRPC-Web and Connect server for browser clients.
+	web     *http.Server
+	webConn *grpc.ClientConn
 	config  config.GRPCConfig
-	handler *Handler
 }
 
 // Creating a new gRPC server.
 func NewServer(cfg config.GRPCConfig, handler *Handler) *Server {
-	options := getOptions(cfg.TLS)
+	// Getting network and in-process server options.
+	credentialOptions, reloader := getCredentialOptions(cfg.TLS)
+	options := append(getOptions(cfg, newAuthorizer(cfg.Authz, "", handler.service.Audit), handler.service.Idempotency),
+		credentialOptions...)
+	localOptions := getOptions(cfg, newAuthorizer(cfg.Authz, cfg.Authz.Local, handler.service.Audit), handler.service.Idempotency)
 
-	return &Server{
-		server:  grpc.NewServer(options...),
-		config:  cfg,
-		handler: handler,
+	s := &Server{
+		server:   grpc.NewServer(options...),
+		local:    grpc.NewServer(localOptions...),
+		listener: bufconn.Listen(localBufferSize),
+		reloader: reloader,
+		config:   cfg,
 	}
+
+	// Registering gRPC handlers.
+	handler.RegisterHandlers(s.server)
+	handler.RegisterLocalHandlers(s.local)
+
+	// Creating a new gRPC-Web and Connect server.
+	if cfg.Web.Enable {
+		conn, err := s.DialLocal()
+		if err != nil {
+			log.Fatal().Err(err).Msg("error creating in-process gRPC connection")
+		}
+
+		s.webConn = conn
+		s.web = &http.Server{
+			Addr:              cfg.Web.Host + ":" + cfg.Web.Port,
+			Handler:           newWebHandler(conn, s.local, cfg.Web),
+			ReadHeaderTimeout: time.Second * 5,
+		}
+	}
+
+	return s
 }
 
 // Running gRPC server.
@@ -56,8 +105,28 @@ func (s *Server) Run() {
 		log.Fatal().Err(err).Msg("error creating tcp listener")
 	}
 
-	// Registering gRPC handlers.
-	s.handler.RegisterHandlers(s.server)
+	s.Serve(lis)
+}
+
+// Serving gRPC server on the listener with in-process and gRPC-Web servers.
+func (s *Server) Serve(lis net.Listener) {
+	// Running in-process gRPC server.
+	recovery.Go("local grpc server", func() {
+		if err := s.local.Serve(s.listener); err != nil {
+			log.Error().Err(err).Msg("error running in-process gRPC server")
+		}
+	})
+
+	// Running gRPC-Web and Connect server.
+	if s.web != nil {
+		recovery.Go("grpc web server", func() {
+			log.Info().Msg("Running gRPC-Web server...")
+
+			if err := s.web.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatal().Err(err).Msg("error running gRPC-Web server")
+			}
+		})
+	}
 
 	// Running gRPC server.
 	if err := s.server.Serve(lis); err != nil {
@@ -65,9 +134,42 @@ func (s *Server) Run() {
 	}
 }
 
-// Stoping gRPC server.
+// Creating a new in-process client connection. Calls pass the same interceptors as the
+// network server.
+func (s *Server) DialLocal() (*grpc.ClientConn, error) {
+	return grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+}
+
+// Stoping gRPC server. In-flight calls are finished before the servers stop.
 func (s *Server) Stop() {
 	log.Info().Msg("Stopping gRPC server...")
 
-	s.server.Stop()
+	// Stopping gRPC-Web and Connect server.
+	if s.web != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), webShutdownTimeout)
+		defer cancel()
+
+		if err := s.web.Shutdown(ctx); err != nil {
+			log.Error().Err(err).Msg("failed to stop gRPC-Web server")
+		}
+
+		if err := s.webConn.Close(); err != nil {
+			log.Error().Err(err).Msg("failed to close in-process gRPC connection")
+		}
+	}
+
+	s.server.GracefulStop()
+	s.local.GracefulStop()
+
+	// Stopping TLS credentials reloader.
+	if s.reloader != nil {
+		if err := s.reloader.Close(); err != nil {
+			log.Error().Err(err).Msg("failed to close TLS credentials reloader")
+		}
+	}
 }
@@ -0,0 +1,116 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package v1
+
+import (
+	"context"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/service"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+	"github.com/durudex/go-protobuf-type/pbtype"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Auth audit event kinds by the request enum.
+var auditKinds = map[v1.AuthEventKind]domain.AuditKind{
+	v1.AuthEventKind_AUTH_EVENT_KIND_SIGN_UP:        domain.AuditSignUp,
+	v1.AuthEventKind_AUTH_EVENT_KIND_SIGN_IN:        domain.AuditSignIn,
+	v1.AuthEventKind_AUTH_EVENT_KIND_TOKEN_REFRESH:  domain.AuditTokenRefresh,
+	v1.AuthEventKind_AUTH_EVENT_KIND_SESSION_REVOKE: domain.AuditSessionRevoke,
+	v1.AuthEventKind_AUTH_EVENT_KIND_STEP_UP:        domain.AuditStepUp,
+	v1.AuthEventKind_AUTH_EVENT_KIND_AUTHZ:          domain.AuditAuthz,
+}
+
+// Auth audit event outcomes by the request enum.
+var auditOutcomes = map[v1.AuthEventOutcome]domain.AuditOutcome{
+	v1.AuthEventOutcome_AUTH_EVENT_OUTCOME_SUCCESS: domain.AuditSuccess,
+	v1.AuthEventOutcome_AUTH_EVENT_OUTCOME_FAILURE: domain.AuditFailure,
+}
+
+// Auth audit gRPC handler.
+type AuditHandler struct {
+	service service.Audit
+	v1.UnimplementedAuthAuditServiceServer
+}
+
+// Creating a new auth audit gRPC handler.
+func NewAuditHandler(service service.Audit) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+// Getting auth audit events gRPC handler.
+func (h *AuditHandler) GetAuthEvents(ctx context.Context, input *v1.GetAuthEventsRequest) (*v1.GetAuthEventsResponse, error) {
+	filter := domain.AuditFilter{
+		Subject: input.Filter.GetSubject(),
+		Kind:    auditKinds[input.Filter.GetKind()],
+		Outcome: auditOutcomes[input.Filter.GetOutcome()],
+	}
+
+	sort, err := newSortOptions(input.SortOptions)
+	if err != nil {
+		return &v1.GetAuthEventsResponse{}, err
+	}
+
+	events, info, err := h.service.GetList(ctx, filter, sort)
+	if err != nil {
+		return &v1.GetAuthEventsResponse{}, err
+	}
+
+	edges := make([]*v1.AuthEventEdge, len(events))
+
+	for i, event := range events {
+		edges[i] = &v1.AuthEventEdge{Cursor: domain.EncodeCursor(event.Id), Node: newAuthEvent(event)}
+	}
+
+	return &v1.GetAuthEventsResponse{Edges: edges, PageInfo: newPageInfo(info)}, nil
+}
+
+// Creating a new auth audit event response.
+func newAuthEvent(event domain.AuditEvent) *v1.AuthEvent {
+	response := &v1.AuthEvent{
+		Id:        event.Id.Bytes(),
+		Kind:      v1.AuthEventKind_AUTH_EVENT_KIND_UNSPECIFIED,
+		Actor:     event.Actor,
+		Subject:   event.Subject,
+		Ip:        event.Ip,
+		UserAgent: event.UserAgent,
+		Outcome:   v1.AuthEventOutcome_AUTH_EVENT_OUTCOME_UNSPECIFIED,
+		Reason:    event.Reason,
+		CreatedAt: pbtype.New(event.CreatedAt),
+	}
+
+	for kind, value := range auditKinds {
+		if value == event.Kind {
+			response.Kind = kind
+		}
+	}
+
+	for outcome, value := range auditOutcomes {
+		if value == event.Outcome {
+			response.Outcome = outcome
+		}
+	}
+
+	if event.SessionId != ksuid.Nil {
+		response.SessionId = event.SessionId.Bytes()
+	}
+
+	return response
+}
@@ -35,4 +35,13 @@ func NewHandler(service *service.Service) *Handler {
 // Registering gRPC handlers.
 func (h *Handler) RegisterHandlers(srv *grpc.Server) {
 	v1.RegisterUserAuthServiceServer(srv, NewUserHandler(h.service.User))
+	v1.RegisterAuthAuditServiceServer(srv, NewAuditHandler(h.service.Audit))
+	v1.RegisterAuthWebhookServiceServer(srv, NewWebhookHandler(h.service.Webhook))
+}
+
+// Registering in-process gRPC handlers. The session service is served only in-process
+// to the HTTP gateway, which takes the user id from the validated access token.
+func (h *Handler) RegisterLocalHandlers(srv *grpc.Server) {
+	h.RegisterHandlers(srv)
+	v1.RegisterUserSessionServiceServer(srv, NewSessionHandler(h.service.Session))
 }
@@ -47,34 +47,113 @@ func (h *SessionHandler) GetUserSession(ctx context.Context, input *v1.GetUserSe
 	}
 
 	return &v1.GetUserSessionResponse{
-		Ip:        session.Ip,
-		ExpiresIn: pbtype.New(session.ExpiresIn),
+		Ip:         session.Ip,
+		ExpiresIn:  pbtype.New(session.ExpiresIn),
+		DeviceType: session.DeviceType,
+		DeviceName: session.DeviceName,
 	}, nil
 }
 
 // Getting a user sessions gRPC handler.
 func (h *SessionHandler) GetUserSessions(ctx context.Context, input *v1.GetUserSessionsRequest) (*v1.GetUserSessionsResponse, error) {
-	sessions, err := h.service.GetList(ctx, ksuid.FromBytesOrNil(input.UserId), domain.SortOptions{
-		First:  input.SortOptions.First,
-		Last:   input.SortOptions.Last,
-		Before: ksuid.FromBytesOrNil(input.SortOptions.Before),
-		After:  ksuid.FromBytesOrNil(input.SortOptions.After),
-	})
+	filter, err := newSessionFilter(input.Filter)
+	if err != nil {
+		return &v1.GetUserSessionsResponse{}, err
+	}
+
+	sort, err := newSortOptions(input.SortOptions)
+	if err != nil {
+		return &v1.GetUserSessionsResponse{}, err
+	}
+
+	sessions, info, err := h.service.GetList(ctx, ksuid.FromBytesOrNil(input.UserId), filter, sort)
 	if err != nil {
 		return &v1.GetUserSessionsResponse{}, err
 	}
 
 	responseSessions := make([]*v1.UserSession, len(sessions))
+	edges := make([]*v1.UserSessionEdge, len(sessions))
 
 	for i, session := range sessions {
 		responseSessions[i] = &v1.UserSession{
-			Id:        session.Id.Bytes(),
-			Ip:        session.Ip,
-			ExpiresIn: pbtype.New(session.ExpiresIn),
+			Id:         session.Id.Bytes(),
+			Ip:         session.Ip,
+			ExpiresIn:  pbtype.New(session.ExpiresIn),
+			DeviceType: session.DeviceType,
+			DeviceName: session.DeviceName,
+		}
+
+		edges[i] = &v1.UserSessionEdge{Cursor: domain.EncodeCursor(session.Id), Node: responseSessions[i]}
+	}
+
+	return &v1.GetUserSessionsResponse{
+		Sessions: responseSessions,
+		Edges:    edges,
+		PageInfo: newPageInfo(info),
+	}, nil
+}
+
+// Creating a new user sessions filter from the request.
+func newSessionFilter(input *v1.UserSessionFilter) (domain.SessionFilter, error) {
+	if input == nil {
+		return domain.SessionFilter{}, nil
+	}
+
+	filter := domain.SessionFilter{
+		State:      domain.SessionState(input.State),
+		DeviceType: input.GetDeviceType(),
+		DeviceName: input.GetDeviceName(),
+	}
+
+	// Parsing ip address or network filter.
+	if input.Ip != nil {
+		network, err := domain.ParseNetwork(input.GetIp())
+		if err != nil {
+			return domain.SessionFilter{}, &domain.Error{Code: domain.CodeInvalidArgument, Message: "Invalid ip filter"}
 		}
+
+		filter.Network = network
+	}
+
+	// Parsing created time filters.
+	if input.CreatedAfter != nil {
+		filter.CreatedAfter = input.CreatedAfter.AsTime()
+	}
+	if input.CreatedBefore != nil {
+		filter.CreatedBefore = input.CreatedBefore.AsTime()
+	}
+
+	return filter, nil
+}
+
+// Creating a new query sorting options from the request. Sort options are required by the
+// validation interceptor of both network and in-process servers.
+func newSortOptions(input *pbtype.SortOptions) (domain.SortOptions, error) {
+	before, err := domain.DecodeCursor(input.Before)
+	if err != nil {
+		return domain.SortOptions{}, err
+	}
+
+	after, err := domain.DecodeCursor(input.After)
+	if err != nil {
+		return domain.SortOptions{}, err
+	}
+
+	return domain.SortOptions{First: input.First, Last: input.Last, Before: before, After: after}, nil
+}
+
+// Creating a new connection page info response.
+func newPageInfo(info domain.PageInfo) *v1.PageInfo {
+	pageInfo := &v1.PageInfo{HasNextPage: info.HasNextPage, HasPreviousPage: info.HasPreviousPage}
+
+	if info.StartCursor != ksuid.Nil {
+		pageInfo.StartCursor = domain.EncodeCursor(info.StartCursor)
+	}
+	if info.EndCursor != ksuid.Nil {
+		pageInfo.EndCursor = domain.EncodeCursor(info.EndCursor)
 	}
 
-	return &v1.GetUserSessionsResponse{Sessions: responseSessions}, nil
+	return pageInfo
 }
 
 // Deleting a user session.
@@ -0,0 +1,87 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package v1_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	v1 "github.com/durudex/durudex-auth-service/internal/transport/grpc/v1"
+	pb "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+	"github.com/durudex/go-protobuf-type/pbtype"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Testing getting user sessions with invalid sort options.
+func TestSessionHandler_GetUserSessions(t *testing.T) {
+	first := int32(10)
+
+	// Tests structures.
+	tests := []struct {
+		name    string
+		sort    *pbtype.SortOptions
+		wantErr string
+	}{
+		{
+			name:    "Raw id cursor",
+			sort:    &pbtype.SortOptions{First: &first, After: ksuid.New().Bytes()},
+			wantErr: "Invalid cursor",
+		},
+		{
+			name:    "Unknown cursor version",
+			sort:    &pbtype.SortOptions{First: &first, Before: []byte("AgAAAAAAAAAAAAAAAAAAAAAAAAA")},
+			wantErr: "Invalid cursor",
+		},
+	}
+
+	// Handler fails before the service is called.
+	h := v1.NewSessionHandler(nil)
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := h.GetUserSessions(context.Background(), &pb.GetUserSessionsRequest{
+				UserId:      ksuid.New().Bytes(),
+				SortOptions: tt.sort,
+			})
+
+			domainErr, ok := err.(*domain.Error)
+			if !ok || domainErr.Code != domain.CodeInvalidArgument || !strings.Contains(domainErr.Message, tt.wantErr) {
+				t.Errorf("error getting user sessions: got %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Testing opaque page cursors.
+func TestCursor(t *testing.T) {
+	id := ksuid.New()
+
+	cursor := domain.EncodeCursor(id)
+	if string(cursor) == string(id.Bytes()) {
+		t.Fatal("error cursor: got raw id")
+	}
+
+	got, err := domain.DecodeCursor(cursor)
+	if err != nil || got != id {
+		t.Errorf("error decoding cursor: got %s, %v, want %s", got, err, id)
+	}
+}
@@ -39,12 +39,13 @@ func NewUserHandler(service service.User) *UserHandler {
 // User Sign Up gRPC handler.
 func (h *UserHandler) UserSignUp(ctx context.Context, input *v1.UserSignUpRequest) (*v1.UserSignUpResponse, error) {
 	tokens, err := h.service.SignUp(ctx, domain.UserSignUpInput{
-		Username: input.Username,
-		Email:    input.Email,
-		Password: input.Password,
-		Secret:   input.Secret,
-		Code:     input.Code,
-		Ip:       input.Ip,
+		Username:  input.Username,
+		Email:     input.Email,
+		Password:  input.Password,
+		Secret:    input.Secret,
+		Code:      input.Code,
+		Ip:        input.Ip,
+		UserAgent: input.UserAgent,
 	})
 	if err != nil {
 		return &v1.UserSignUpResponse{}, err
@@ -56,10 +57,12 @@ func (h *UserHandler) UserSignUp(ctx context.Context, input *v1.UserSignUpReques
 // User Sign In gRPC handler.
 func (h *UserHandler) UserSignIn(ctx context.Context, input *v1.UserSignInRequest) (*v1.UserSignInResponse, error) {
 	tokens, err := h.service.SignIn(ctx, domain.UserSignInInput{
-		Username: input.Username,
-		Password: input.Password,
-		Secret:   input.Secret,
-		Ip:       input.Ip,
+		Username:  input.Username,
+		Password:  input.Password,
+		Secret:    input.Secret,
+		Ip:        input.Ip,
+		UserAgent: input.UserAgent,
+		Code:      input.Code,
 	})
 	if err != nil {
 		return &v1.UserSignInResponse{}, err
@@ -77,3 +80,29 @@ func (h *UserHandler) RefreshUserToken(ctx context.Context, input *v1.RefreshUse
 
 	return &v1.RefreshUserTokenResponse{Access: access}, nil
 }
+
+// User session step up gRPC handler.
+func (h *UserHandler) UserStepUp(ctx context.Context, input *v1.UserStepUpRequest) (*v1.UserStepUpResponse, error) {
+	access, err := h.service.StepUp(ctx, domain.UserStepUpInput{
+		Refresh:   input.Refresh,
+		Secret:    input.Secret,
+		Password:  input.Password,
+		Code:      input.Code,
+		Ip:        input.Ip,
+		UserAgent: input.UserAgent,
+	})
+	if err != nil {
+		return &v1.UserStepUpResponse{}, err
+	}
+
+	return &v1.UserStepUpResponse{Access: access}, nil
+}
+
+// User Sign Out gRPC handler.
+func (h *UserHandler) UserSignOut(ctx context.Context, input *v1.UserSignOutRequest) (*v1.UserSignOutResponse, error) {
+	if err := h.service.SignOut(ctx, input.Refresh, input.Secret); err != nil {
+		return &v1.UserSignOutResponse{}, err
+	}
+
+	return &v1.UserSignOutResponse{}, nil
+}
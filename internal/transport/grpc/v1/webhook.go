@@ -0,0 +1,163 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package v1
+
+import (
+	"context"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/service"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+	"github.com/durudex/go-protobuf-type/pbtype"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Webhook delivery statuses by the response enum.
+var webhookDeliveryStatuses = map[domain.WebhookDeliveryStatus]v1.WebhookDeliveryStatus{
+	domain.WebhookDeliveryPending:   v1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING,
+	domain.WebhookDeliveryDelivered: v1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DELIVERED,
+	domain.WebhookDeliveryFailed:    v1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED,
+}
+
+// Auth webhook gRPC handler.
+type WebhookHandler struct {
+	service service.Webhook
+	v1.UnimplementedAuthWebhookServiceServer
+}
+
+// Creating a new auth webhook gRPC handler.
+func NewWebhookHandler(service service.Webhook) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// Creating a new webhook gRPC handler.
+func (h *WebhookHandler) CreateWebhook(ctx context.Context, input *v1.CreateWebhookRequest) (*v1.CreateWebhookResponse, error) {
+	types := make([]domain.EventType, len(input.EventTypes))
+	for i, typ := range input.EventTypes {
+		types[i] = domain.EventType(typ)
+	}
+
+	id, err := h.service.Create(ctx, input.Url, types, input.Secret)
+	if err != nil {
+		return &v1.CreateWebhookResponse{}, err
+	}
+
+	return &v1.CreateWebhookResponse{Id: id.Bytes()}, nil
+}
+
+// Getting a webhook gRPC handler.
+func (h *WebhookHandler) GetWebhook(ctx context.Context, input *v1.GetWebhookRequest) (*v1.GetWebhookResponse, error) {
+	webhook, err := h.service.Get(ctx, ksuid.FromBytesOrNil(input.Id))
+	if err != nil {
+		return &v1.GetWebhookResponse{}, err
+	}
+
+	return &v1.GetWebhookResponse{Webhook: newWebhook(webhook)}, nil
+}
+
+// Getting all webhooks gRPC handler.
+func (h *WebhookHandler) GetWebhooks(ctx context.Context, input *v1.GetWebhooksRequest) (*v1.GetWebhooksResponse, error) {
+	webhooks, err := h.service.GetList(ctx)
+	if err != nil {
+		return &v1.GetWebhooksResponse{}, err
+	}
+
+	response := make([]*v1.Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		response[i] = newWebhook(webhook)
+	}
+
+	return &v1.GetWebhooksResponse{Webhooks: response}, nil
+}
+
+// Deleting a webhook gRPC handler.
+func (h *WebhookHandler) DeleteWebhook(ctx context.Context, input *v1.DeleteWebhookRequest) (*v1.DeleteWebhookResponse, error) {
+	if err := h.service.Delete(ctx, ksuid.FromBytesOrNil(input.Id)); err != nil {
+		return &v1.DeleteWebhookResponse{}, err
+	}
+
+	return &v1.DeleteWebhookResponse{}, nil
+}
+
+// Enabling a webhook gRPC handler.
+func (h *WebhookHandler) EnableWebhook(ctx context.Context, input *v1.EnableWebhookRequest) (*v1.EnableWebhookResponse, error) {
+	if err := h.service.Enable(ctx, ksuid.FromBytesOrNil(input.Id)); err != nil {
+		return &v1.EnableWebhookResponse{}, err
+	}
+
+	return &v1.EnableWebhookResponse{}, nil
+}
+
+// Getting webhook deliveries gRPC handler.
+func (h *WebhookHandler) GetWebhookDeliveries(ctx context.Context, input *v1.GetWebhookDeliveriesRequest) (*v1.GetWebhookDeliveriesResponse, error) {
+	sort, err := newSortOptions(input.SortOptions)
+	if err != nil {
+		return &v1.GetWebhookDeliveriesResponse{}, err
+	}
+
+	deliveries, info, err := h.service.GetDeliveries(ctx, ksuid.FromBytesOrNil(input.WebhookId), sort)
+	if err != nil {
+		return &v1.GetWebhookDeliveriesResponse{}, err
+	}
+
+	edges := make([]*v1.WebhookDeliveryEdge, len(deliveries))
+
+	for i, delivery := range deliveries {
+		edges[i] = &v1.WebhookDeliveryEdge{Cursor: domain.EncodeCursor(delivery.Id), Node: newWebhookDelivery(delivery)}
+	}
+
+	return &v1.GetWebhookDeliveriesResponse{Edges: edges, PageInfo: newPageInfo(info)}, nil
+}
+
+// Creating a new webhook response, the signing secret is not returned.
+func newWebhook(webhook domain.Webhook) *v1.Webhook {
+	response := &v1.Webhook{
+		Id:         webhook.Id.Bytes(),
+		Url:        webhook.URL,
+		EventTypes: make([]string, len(webhook.EventTypes)),
+		Enabled:    webhook.Enabled,
+		Failures:   webhook.Failures,
+		CreatedAt:  pbtype.New(webhook.CreatedAt),
+	}
+
+	for i, typ := range webhook.EventTypes {
+		response.EventTypes[i] = string(typ)
+	}
+
+	if !webhook.DisabledAt.IsZero() {
+		response.DisabledAt = pbtype.New(webhook.DisabledAt)
+	}
+
+	return response
+}
+
+// Creating a new webhook delivery response.
+func newWebhookDelivery(delivery domain.WebhookDelivery) *v1.WebhookDelivery {
+	return &v1.WebhookDelivery{
+		Id:           delivery.Id.Bytes(),
+		WebhookId:    delivery.WebhookId.Bytes(),
+		EventId:      delivery.EventId.Bytes(),
+		EventType:    string(delivery.EventType),
+		Status:       webhookDeliveryStatuses[delivery.Status],
+		Attempts:     delivery.Attempts,
+		ResponseCode: delivery.ResponseCode,
+		LastError:    delivery.LastError,
+		CreatedAt:    pbtype.New(delivery.CreatedAt),
+		UpdatedAt:    pbtype.New(delivery.UpdatedAt),
+	}
+}
@@ -0,0 +1,304 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Maximum browser request body size.
+const maxWebBodySize int64 = 4 << 20
+
+// Request headers forwarded as gRPC metadata.
+var forwardHeaders = []string{RequestIdKey, IdempotencyKey}
+
+// Public auth methods served to browser clients. Other services take caller-supplied user
+// ids and are served only to authorized peers.
+var webMethods = []string{
+	"/durudex.v1.UserAuthService/UserSignUp",
+	"/durudex.v1.UserAuthService/UserSignIn",
+	"/durudex.v1.UserAuthService/RefreshUserToken",
+	"/durudex.v1.UserAuthService/UserStepUp",
+	"/durudex.v1.UserAuthService/UserSignOut",
+}
+
+// Browser protocol codec.
+type webProtocol interface {
+	// Decoding a request message from the request body.
+	decode(r *http.Request, body []byte, msg proto.Message) error
+	// Writing a response message or error.
+	write(w http.ResponseWriter, r *http.Request, msg proto.Message, header, trailer metadata.MD, err error)
+}
+
+// gRPC-Web and Connect protocol handler. Unary calls are proxied to the in-process server
+// so all handler registrations and interceptors are shared with the gRPC server.
+type webHandler struct {
+	conn    grpc.ClientConnInterface
+	methods map[string]protoreflect.MethodDescriptor
+	origins map[string]struct{}
+}
+
+// Creating a new gRPC-Web and Connect protocol handler.
+func newWebHandler(conn grpc.ClientConnInterface, srv *grpc.Server, cfg config.WebConfig) *webHandler {
+	h := &webHandler{
+		conn:    conn,
+		methods: make(map[string]protoreflect.MethodDescriptor),
+		origins: make(map[string]struct{}, len(cfg.Origins)),
+	}
+
+	services := srv.GetServiceInfo()
+
+	// Getting the public methods of the registered services.
+	for _, name := range webMethods {
+		service, method := path.Split(strings.TrimPrefix(name, "/"))
+		service = strings.TrimSuffix(service, "/")
+
+		if _, ok := services[service]; !ok {
+			continue
+		}
+
+		d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service + "." + method))
+		if err != nil {
+			continue
+		}
+
+		if d, ok := d.(protoreflect.MethodDescriptor); ok && !d.IsStreamingClient() && !d.IsStreamingServer() {
+			h.methods[name] = d
+		}
+	}
+
+	for _, origin := range cfg.Origins {
+		h.origins[origin] = struct{}{}
+	}
+
+	return h
+}
+
+// Serving gRPC-Web and Connect HTTP requests.
+func (h *webHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Checking cross-origin request.
+	if !h.cors(w, r) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Getting request protocol.
+	protocol := h.protocol(r.Header.Get("Content-Type"))
+	if protocol == nil {
+		http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	// Getting a called method.
+	method, ok := h.methods[r.URL.Path]
+	if !ok {
+		protocol.write(w, r, nil, nil, nil, status.Errorf(codes.Unimplemented, "unknown method %s", r.URL.Path))
+		return
+	}
+
+	// Reading request body.
+	body, err := readBody(w, r)
+	if err != nil {
+		protocol.write(w, r, nil, nil, nil, status.Error(codes.InvalidArgument, "failed to read request body"))
+		return
+	}
+
+	in, out, err := newMessages(method)
+	if err != nil {
+		protocol.write(w, r, nil, nil, nil, status.Error(codes.Internal, err.Error()))
+		return
+	}
+
+	// Decoding request message.
+	if err := protocol.decode(r, body, in); err != nil {
+		protocol.write(w, r, nil, nil, nil, status.Errorf(codes.InvalidArgument, "failed to decode request: %s", err.Error()))
+		return
+	}
+
+	ctx, cancel := webContext(r)
+	defer cancel()
+
+	var header, trailer metadata.MD
+
+	// Calling in-process gRPC server.
+	err = h.conn.Invoke(ctx, r.URL.Path, in, out, grpc.Header(&header), grpc.Trailer(&trailer))
+	if err != nil {
+		out = nil
+	}
+
+	protocol.write(w, r, out, header, trailer, err)
+}
+
+// Getting a protocol by request content type.
+func (h *webHandler) protocol(contentType string) webProtocol {
+	contentType = strings.TrimSpace(strings.Split(contentType, ";")[0])
+
+	switch contentType {
+	case "application/grpc-web", "application/grpc-web+proto":
+		return &grpcWebProtocol{contentType: contentType}
+	case "application/grpc-web-text", "application/grpc-web-text+proto":
+		return &grpcWebProtocol{contentType: contentType, text: true}
+	case "application/proto", "application/json":
+		return &connectProtocol{contentType: contentType}
+	default:
+		return nil
+	}
+}
+
+// Setting CORS headers. Returns false when the origin is not allowed.
+func (h *webHandler) cors(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	// Credentials are allowed only for explicitly listed origins.
+	if _, ok := h.origins[origin]; ok {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		w.Header().Add("Vary", "Origin")
+	} else if _, ok := h.origins["*"]; ok {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		return false
+	}
+
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join([]string{
+			"Content-Type", "X-Grpc-Web", "X-User-Agent", "Grpc-Timeout",
+			"Connect-Protocol-Version", "Connect-Timeout-Ms", RequestIdKey, IdempotencyKey,
+		}, ", "))
+		w.Header().Set("Access-Control-Max-Age", "7200")
+	}
+
+	w.Header().Set("Access-Control-Expose-Headers", strings.Join([]string{
+		"Grpc-Status", "Grpc-Message", RequestIdKey,
+	}, ", "))
+
+	return true
+}
+
+// Reading a limited request body.
+func readBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	body := http.MaxBytesReader(w, r.Body, maxWebBodySize)
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
+
+// Creating a new method input and output messages.
+func newMessages(method protoreflect.MethodDescriptor) (proto.Message, proto.Message, error) {
+	inType, err := protoregistry.GlobalTypes.FindMessageByName(method.Input().FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outType, err := protoregistry.GlobalTypes.FindMessageByName(method.Output().FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return inType.New().Interface(), outType.New().Interface(), nil
+}
+
+// Getting an outgoing call context with forwarded request headers and deadline.
+func webContext(r *http.Request) (context.Context, context.CancelFunc) {
+	md := metadata.MD{}
+
+	for _, key := range forwardHeaders {
+		if values := r.Header.Values(key); len(values) != 0 {
+			md.Set(key, values...)
+		}
+	}
+
+	ctx := metadata.NewOutgoingContext(r.Context(), md)
+
+	// Getting call timeout.
+	if timeout, ok := webTimeout(r.Header); ok {
+		return context.WithTimeout(ctx, timeout)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// Parsing gRPC-Web or Connect call timeout header.
+func webTimeout(header http.Header) (time.Duration, bool) {
+	// Connect timeout in milliseconds.
+	if value := header.Get("Connect-Timeout-Ms"); value != "" {
+		ms, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || ms <= 0 {
+			return 0, false
+		}
+
+		return time.Duration(ms) * time.Millisecond, true
+	}
+
+	// gRPC timeout with unit suffix.
+	value := header.Get("Grpc-Timeout")
+	if len(value) < 2 {
+		return 0, false
+	}
+
+	n, err := strconv.ParseInt(value[:len(value)-1], 10, 64)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+
+	units := map[byte]time.Duration{
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+		'm': time.Millisecond,
+		'u': time.Microsecond,
+		'n': time.Nanosecond,
+	}
+
+	unit, ok := units[value[len(value)-1]]
+	if !ok {
+		return 0, false
+	}
+
+	return time.Duration(n) * unit, true
+}
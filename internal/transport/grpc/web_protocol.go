@@ -0,0 +1,298 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// gRPC-Web frame prefix length.
+	framePrefixLength = 5
+	// gRPC-Web trailer frame flag.
+	frameTrailerFlag byte = 0x80
+)
+
+// gRPC-Web protocol codec.
+type grpcWebProtocol struct {
+	contentType string
+	text        bool
+}
+
+// Decoding a gRPC-Web request message. Only a single uncompressed frame is supported.
+func (p *grpcWebProtocol) decode(_ *http.Request, body []byte, msg proto.Message) error {
+	if p.text {
+		decoded, err := base64.StdEncoding.DecodeString(string(body))
+		if err != nil {
+			return err
+		}
+
+		body = decoded
+	}
+
+	if len(body) < framePrefixLength {
+		return errors.New("invalid frame")
+	}
+
+	if body[0] != 0 {
+		return errors.New("compressed frames are not supported")
+	}
+
+	length := binary.BigEndian.Uint32(body[1:framePrefixLength])
+	if uint32(len(body)-framePrefixLength) < length {
+		return errors.New("invalid frame length")
+	}
+
+	return proto.Unmarshal(body[framePrefixLength:framePrefixLength+length], msg)
+}
+
+// Writing a gRPC-Web response. Status is sent in the trailer frame.
+func (p *grpcWebProtocol) write(w http.ResponseWriter, _ *http.Request, msg proto.Message, header, trailer metadata.MD, err error) {
+	var body bytes.Buffer
+
+	// Writing response message frame.
+	if msg != nil {
+		data, mErr := proto.Marshal(msg)
+		if mErr != nil {
+			err = status.Error(codes.Internal, "failed to marshal response")
+		} else {
+			body.Write(frame(0, data))
+		}
+	}
+
+	// Writing trailer frame.
+	st := status.Convert(err)
+
+	var trailers bytes.Buffer
+	fmt.Fprintf(&trailers, "grpc-status: %d\r\n", st.Code())
+
+	if st.Message() != "" {
+		fmt.Fprintf(&trailers, "grpc-message: %s\r\n", url.PathEscape(st.Message()))
+	}
+
+	for key, values := range trailer {
+		for _, value := range values {
+			fmt.Fprintf(&trailers, "%s: %s\r\n", key, value)
+		}
+	}
+
+	body.Write(frame(frameTrailerFlag, trailers.Bytes()))
+
+	setHeaders(w.Header(), "", header)
+	w.Header().Set("Content-Type", p.contentType)
+
+	data := body.Bytes()
+	if p.text {
+		data = []byte(base64.StdEncoding.EncodeToString(data))
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(data); err != nil {
+		log.Debug().Err(err).Msg("failed to write gRPC-Web response")
+	}
+}
+
+// Creating a new gRPC-Web frame.
+func frame(flag byte, data []byte) []byte {
+	buf := make([]byte, framePrefixLength+len(data))
+	buf[0] = flag
+	binary.BigEndian.PutUint32(buf[1:framePrefixLength], uint32(len(data)))
+	copy(buf[framePrefixLength:], data)
+
+	return buf
+}
+
+// Connect unary protocol codec.
+type connectProtocol struct{ contentType string }
+
+// Connect error structure.
+type connectError struct {
+	Code    string               `json:"code"`
+	Message string               `json:"message,omitempty"`
+	Details []connectErrorDetail `json:"details,omitempty"`
+}
+
+// Connect error detail structure.
+type connectErrorDetail struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Decoding a Connect request message.
+func (p *connectProtocol) decode(r *http.Request, body []byte, msg proto.Message) error {
+	if err := connectVersion(r.Header); err != nil {
+		return err
+	}
+
+	if p.contentType == "application/json" {
+		return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(body, msg)
+	}
+
+	return proto.Unmarshal(body, msg)
+}
+
+// Writing a Connect unary response. Errors are sent as JSON with a mapped HTTP status code.
+func (p *connectProtocol) write(w http.ResponseWriter, _ *http.Request, msg proto.Message, header, trailer metadata.MD, err error) {
+	setHeaders(w.Header(), "", header)
+	setHeaders(w.Header(), "Trailer-", trailer)
+
+	if err == nil {
+		var data []byte
+
+		if p.contentType == "application/json" {
+			data, err = protojson.Marshal(msg)
+		} else {
+			data, err = proto.Marshal(msg)
+		}
+
+		if err == nil {
+			w.Header().Set("Content-Type", p.contentType)
+			w.WriteHeader(http.StatusOK)
+
+			if _, err := w.Write(data); err != nil {
+				log.Debug().Err(err).Msg("failed to write Connect response")
+			}
+
+			return
+		}
+
+		err = status.Error(codes.Internal, "failed to marshal response")
+	}
+
+	st := status.Convert(err)
+
+	response := connectError{Code: connectCode(st.Code()), Message: st.Message()}
+
+	for _, detail := range st.Proto().Details {
+		response.Details = append(response.Details, connectErrorDetail{
+			Type:  strings.TrimPrefix(detail.TypeUrl, "type.googleapis.com/"),
+			Value: base64.RawStdEncoding.EncodeToString(detail.Value),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(connectHTTPStatus(st.Code()))
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Debug().Err(err).Msg("failed to write Connect error")
+	}
+}
+
+// Setting metadata as HTTP headers with prefix.
+func setHeaders(h http.Header, prefix string, md metadata.MD) {
+	for key, values := range md {
+		if strings.HasSuffix(key, "-bin") {
+			for i := range values {
+				values[i] = base64.RawStdEncoding.EncodeToString([]byte(values[i]))
+			}
+		}
+
+		for _, value := range values {
+			h.Add(prefix+key, value)
+		}
+	}
+}
+
+// Getting a Connect error code name.
+func connectCode(code codes.Code) string {
+	switch code {
+	case codes.Canceled:
+		return "canceled"
+	case codes.InvalidArgument:
+		return "invalid_argument"
+	case codes.DeadlineExceeded:
+		return "deadline_exceeded"
+	case codes.NotFound:
+		return "not_found"
+	case codes.AlreadyExists:
+		return "already_exists"
+	case codes.PermissionDenied:
+		return "permission_denied"
+	case codes.ResourceExhausted:
+		return "resource_exhausted"
+	case codes.FailedPrecondition:
+		return "failed_precondition"
+	case codes.Aborted:
+		return "aborted"
+	case codes.OutOfRange:
+		return "out_of_range"
+	case codes.Unimplemented:
+		return "unimplemented"
+	case codes.Internal:
+		return "internal"
+	case codes.Unavailable:
+		return "unavailable"
+	case codes.DataLoss:
+		return "data_loss"
+	case codes.Unauthenticated:
+		return "unauthenticated"
+	default:
+		return "unknown"
+	}
+}
+
+// Getting a Connect error HTTP status code.
+func connectHTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.Canceled, codes.DeadlineExceeded:
+		return http.StatusRequestTimeout
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.NotFound, codes.Unimplemented:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Checking Connect protocol version header.
+func connectVersion(h http.Header) error {
+	if version := h.Get("Connect-Protocol-Version"); version != "" && version != "1" {
+		return fmt.Errorf("unsupported connect protocol version %s", version)
+	}
+
+	return nil
+}
@@ -0,0 +1,309 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// Refresh token gRPC server used by the gRPC-Web tests.
+type refreshServer struct {
+	v1.UnimplementedUserAuthServiceServer
+}
+
+// Refresh user authentication token. Empty refresh token is rejected.
+func (refreshServer) RefreshUserToken(_ context.Context, input *v1.RefreshUserTokenRequest) (*v1.RefreshUserTokenResponse, error) {
+	if input.Refresh == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh token is required")
+	}
+
+	return &v1.RefreshUserTokenResponse{Access: "access"}, nil
+}
+
+// Session count gRPC server, not served to browser clients.
+type sessionCountServer struct {
+	v1.UnimplementedUserSessionServiceServer
+}
+
+// Getting total user session count.
+func (sessionCountServer) GetTotalUserSessionCount(context.Context, *v1.GetTotalUserSessionCountRequest) (*v1.GetTotalUserSessionCountResponse, error) {
+	return &v1.GetTotalUserSessionCountResponse{Count: 3}, nil
+}
+
+// Public refresh token method.
+const refreshMethod = "/durudex.v1.UserAuthService/RefreshUserToken"
+
+// Creating a new gRPC-Web handler with in-process server.
+func newTestWebHandler(t *testing.T, origins ...string) *webHandler {
+	t.Helper()
+
+	srv := grpc.NewServer()
+	v1.RegisterUserAuthServiceServer(srv, refreshServer{})
+	v1.RegisterUserSessionServiceServer(srv, sessionCountServer{})
+
+	lis := bufconn.Listen(localBufferSize)
+	go srv.Serve(lis)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("error dialing in-process server: %s", err.Error())
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		srv.Stop()
+	})
+
+	return newWebHandler(conn, srv, config.WebConfig{Origins: origins})
+}
+
+// Testing Connect unary protocol.
+func TestWebHandler_Connect(t *testing.T) {
+	// Testing args.
+	type args struct {
+		method, contentType, body string
+	}
+
+	handler := newTestWebHandler(t, "https://durudex.com")
+
+	// Tests structures.
+	tests := []struct {
+		name     string
+		args     args
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "OK",
+			args:     args{method: refreshMethod, contentType: "application/json", body: `{"refresh":"token"}`},
+			wantCode: http.StatusOK,
+			wantBody: `"access":"access"`,
+		},
+		{
+			name:     "Invalid argument",
+			args:     args{method: refreshMethod, contentType: "application/json", body: `{}`},
+			wantCode: http.StatusBadRequest,
+			wantBody: `"code":"invalid_argument"`,
+		},
+		{
+			name:     "Invalid JSON",
+			args:     args{method: refreshMethod, contentType: "application/json", body: `{`},
+			wantCode: http.StatusBadRequest,
+			wantBody: `"code":"invalid_argument"`,
+		},
+		{
+			name:     "Unsupported media type",
+			args:     args{method: refreshMethod, contentType: "text/plain", body: `{}`},
+			wantCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name: "Not public method",
+			args: args{
+				method:      "/durudex.v1.UserSessionService/GetTotalUserSessionCount",
+				contentType: "application/json",
+				body:        `{"userId":"AQ=="}`,
+			},
+			wantCode: http.StatusNotFound,
+			wantBody: `"code":"unimplemented"`,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, tt.args.method, strings.NewReader(tt.args.body))
+			r.Header.Set("Content-Type", tt.args.contentType)
+			r.Header.Set("Connect-Protocol-Version", "1")
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			// Check response status code.
+			if w.Code != tt.wantCode {
+				t.Errorf("error response status code: got %d, want %d", w.Code, tt.wantCode)
+			}
+
+			// Check response body.
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("error response body: got %s, want %s", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+// Testing gRPC-Web binary and text protocols.
+func TestWebHandler_GRPCWeb(t *testing.T) {
+	handler := newTestWebHandler(t, "https://durudex.com")
+
+	request, err := proto.Marshal(&v1.RefreshUserTokenRequest{Refresh: "token"})
+	if err != nil {
+		t.Fatalf("error marshaling request: %s", err.Error())
+	}
+
+	for _, contentType := range []string{"application/grpc-web+proto", "application/grpc-web-text"} {
+		t.Run(contentType, func(t *testing.T) {
+			text := strings.HasPrefix(contentType, "application/grpc-web-text")
+
+			body := frame(0, request)
+			if text {
+				body = []byte(base64.StdEncoding.EncodeToString(body))
+			}
+
+			r := httptest.NewRequest(http.MethodPost, refreshMethod, bytes.NewReader(body))
+			r.Header.Set("Content-Type", contentType)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			response := w.Body.Bytes()
+			if text {
+				if response, err = base64.StdEncoding.DecodeString(string(response)); err != nil {
+					t.Fatalf("error decoding text response: %s", err.Error())
+				}
+			}
+
+			// Reading message frame.
+			length := binary.BigEndian.Uint32(response[1:framePrefixLength])
+
+			var out v1.RefreshUserTokenResponse
+			if err := proto.Unmarshal(response[framePrefixLength:framePrefixLength+length], &out); err != nil {
+				t.Fatalf("error unmarshaling response: %s", err.Error())
+			}
+
+			// Check response message.
+			if out.Access != "access" {
+				t.Errorf("error response access token: got %s, want %s", out.Access, "access")
+			}
+
+			// Check trailer frame.
+			trailer := response[framePrefixLength+length:]
+			if trailer[0] != frameTrailerFlag || !bytes.Contains(trailer, []byte("grpc-status: 0")) {
+				t.Errorf("error trailer frame: got %q", trailer)
+			}
+		})
+	}
+}
+
+// Testing cross-origin requests.
+func TestWebHandler_CORS(t *testing.T) {
+	handler := newTestWebHandler(t, "https://durudex.com")
+	wildcard := newTestWebHandler(t, "*")
+
+	// Tests structures.
+	tests := []struct {
+		name            string
+		handler         *webHandler
+		origin          string
+		wantCode        int
+		wantOrigin      string
+		wantCredentials string
+	}{
+		{
+			name:            "Allowed origin",
+			handler:         handler,
+			origin:          "https://durudex.com",
+			wantCode:        http.StatusNoContent,
+			wantOrigin:      "https://durudex.com",
+			wantCredentials: "true",
+		},
+		{name: "Forbidden origin", handler: handler, origin: "https://example.com", wantCode: http.StatusForbidden},
+		{
+			name:       "Wildcard origin",
+			handler:    wildcard,
+			origin:     "https://example.com",
+			wantCode:   http.StatusNoContent,
+			wantOrigin: "*",
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodOptions, refreshMethod, nil)
+			r.Header.Set("Origin", tt.origin)
+
+			w := httptest.NewRecorder()
+			tt.handler.ServeHTTP(w, r)
+
+			// Check response status code.
+			if w.Code != tt.wantCode {
+				t.Errorf("error response status code: got %d, want %d", w.Code, tt.wantCode)
+			}
+
+			// Check allowed origin and credentials.
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("error allowed origin: got %q, want %q", got, tt.wantOrigin)
+			}
+
+			if got := w.Header().Get("Access-Control-Allow-Credentials"); got != tt.wantCredentials {
+				t.Errorf("error allowed credentials: got %q, want %q", got, tt.wantCredentials)
+			}
+		})
+	}
+}
+
+// Testing forwarded request headers.
+func TestWebContext(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, refreshMethod, nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	r.Header.Set("X-Request-Id", "request")
+	r.Header.Set("Authorization", "Bearer token")
+	r.Header.Set("X-Forwarded-For", "203.0.113.7")
+	r.Header.Set("X-Internal-Identity", "admin")
+	r.Header.Set("Cookie", "session=secret")
+
+	ctx, cancel := webContext(r)
+	defer cancel()
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+
+	if got := md.Get(RequestIdKey); len(got) != 1 || got[0] != "request" {
+		t.Errorf("error request id: got %v", got)
+	}
+
+	// Check headers not in the allow-list are dropped.
+	for _, key := range []string{"x-forwarded-for", "x-internal-identity", "authorization", "cookie"} {
+		if got := md.Get(key); len(got) != 0 {
+			t.Errorf("error forwarded %s header: got %v", key, got)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+)
+
+// User Sign Up request body.
+type signUpRequest struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Secret   string `json:"secret"`
+	Code     uint64 `json:"code"`
+}
+
+// User Sign In request body.
+type signInRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Secret   string `json:"secret"`
+	Code     uint64 `json:"code,omitempty"`
+}
+
+// Refresh token request body. The refresh token is taken from the cookie when it is omitted.
+type refreshRequest struct {
+	Refresh string `json:"refresh,omitempty"`
+	Secret  string `json:"secret"`
+}
+
+// Step up request body. The refresh token is taken from the cookie when it is omitted.
+type stepUpRequest struct {
+	Refresh  string `json:"refresh,omitempty"`
+	Secret   string `json:"secret"`
+	Password string `json:"password"`
+	Code     uint64 `json:"code,omitempty"`
+}
+
+// User auth tokens response body. The refresh token is omitted when it is set as cookie.
+type tokensResponse struct {
+	Access  string `json:"access"`
+	Refresh string `json:"refresh,omitempty"`
+}
+
+// Getting user auth routes.
+func (h *Handler) authRoutes() []route {
+	return []route{
+		{
+			Method:   http.MethodPost,
+			Path:     "/v1/auth/sign-up",
+			Summary:  "User Sign Up.",
+			Request:  signUpRequest{},
+			Response: tokensResponse{},
+			handler:  h.signUp,
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     "/v1/auth/sign-in",
+			Summary:  "User Sign In.",
+			Request:  signInRequest{},
+			Response: tokensResponse{},
+			handler:  h.signIn,
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     "/v1/auth/refresh",
+			Summary:  "Refresh user authentication token.",
+			Request:  refreshRequest{},
+			Response: tokensResponse{},
+			handler:  h.refresh,
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     "/v1/auth/step-up",
+			Summary:  "Re-authenticating the user session.",
+			Request:  stepUpRequest{},
+			Response: tokensResponse{},
+			handler:  h.stepUp,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/v1/auth/sign-out",
+			Summary: "User Sign Out, deleting the refresh token session.",
+			Request: refreshRequest{},
+			handler: h.signOut,
+		},
+	}
+}
+
+// User Sign Up HTTP handler.
+func (h *Handler) signUp(w http.ResponseWriter, r *http.Request) error {
+	var input signUpRequest
+	if err := readJSON(r, &input); err != nil {
+		return err
+	}
+
+	res, err := h.auth.UserSignUp(outgoingContext(r), &v1.UserSignUpRequest{
+		Username:  input.Username,
+		Email:     input.Email,
+		Password:  input.Password,
+		Secret:    input.Secret,
+		Code:      input.Code,
+		Ip:        clientIp(r),
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		return err
+	}
+
+	h.writeTokens(w, http.StatusCreated, res.Access, res.Refresh)
+
+	return nil
+}
+
+// User Sign In HTTP handler.
+func (h *Handler) signIn(w http.ResponseWriter, r *http.Request) error {
+	var input signInRequest
+	if err := readJSON(r, &input); err != nil {
+		return err
+	}
+
+	res, err := h.auth.UserSignIn(outgoingContext(r), &v1.UserSignInRequest{
+		Username:  input.Username,
+		Password:  input.Password,
+		Secret:    input.Secret,
+		Code:      input.Code,
+		Ip:        clientIp(r),
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		return err
+	}
+
+	h.writeTokens(w, http.StatusOK, res.Access, res.Refresh)
+
+	return nil
+}
+
+// Refresh user authentication token HTTP handler.
+func (h *Handler) refresh(w http.ResponseWriter, r *http.Request) error {
+	var input refreshRequest
+	if err := readJSON(r, &input); err != nil {
+		return err
+	}
+
+	res, err := h.auth.RefreshUserToken(outgoingContext(r), &v1.RefreshUserTokenRequest{
+		Refresh: h.refreshToken(r, input.Refresh),
+		Secret:  input.Secret,
+	})
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, tokensResponse{Access: res.Access})
+
+	return nil
+}
+
+// User session step up HTTP handler.
+func (h *Handler) stepUp(w http.ResponseWriter, r *http.Request) error {
+	var input stepUpRequest
+	if err := readJSON(r, &input); err != nil {
+		return err
+	}
+
+	res, err := h.auth.UserStepUp(outgoingContext(r), &v1.UserStepUpRequest{
+		Refresh:   h.refreshToken(r, input.Refresh),
+		Secret:    input.Secret,
+		Password:  input.Password,
+		Code:      input.Code,
+		Ip:        clientIp(r),
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, tokensResponse{Access: res.Access})
+
+	return nil
+}
+
+// User Sign Out HTTP handler.
+func (h *Handler) signOut(w http.ResponseWriter, r *http.Request) error {
+	var input refreshRequest
+	if err := readJSON(r, &input); err != nil {
+		return err
+	}
+
+	// Deleting the refresh token session.
+	if _, err := h.auth.UserSignOut(outgoingContext(r), &v1.UserSignOutRequest{
+		Refresh: h.refreshToken(r, input.Refresh),
+		Secret:  input.Secret,
+	}); err != nil {
+		return err
+	}
+
+	// Removing refresh token cookie.
+	if h.cfg.Cookie.Enable {
+		cookie := h.cookie("")
+		cookie.MaxAge = -1
+
+		http.SetCookie(w, cookie)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+// Writing user auth tokens response, the refresh token is set as cookie when configured.
+func (h *Handler) writeTokens(w http.ResponseWriter, code int, access, refresh string) {
+	if h.cfg.Cookie.Enable {
+		http.SetCookie(w, h.cookie(refresh))
+		refresh = ""
+	}
+
+	writeJSON(w, code, tokensResponse{Access: access, Refresh: refresh})
+}
+
+// Getting a refresh token from the request body or the cookie.
+func (h *Handler) refreshToken(r *http.Request, body string) string {
+	if body != "" || !h.cfg.Cookie.Enable {
+		return body
+	}
+
+	cookie, err := r.Cookie(h.cfg.Cookie.Name)
+	if err != nil {
+		return ""
+	}
+
+	return cookie.Value
+}
+
+// Creating a new refresh token cookie.
+func (h *Handler) cookie(value string) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     h.cfg.Cookie.Name,
+		Value:    value,
+		Domain:   h.cfg.Cookie.Domain,
+		Path:     h.cfg.Cookie.Path,
+		Secure:   h.cfg.Cookie.Secure,
+		HttpOnly: true,
+	}
+
+	// Set cookie lifetime to the session lifetime.
+	if value != "" {
+		cookie.MaxAge = int(h.authCfg.Session.TTL.Seconds())
+	}
+
+	switch strings.ToLower(h.cfg.Cookie.SameSite) {
+	case "strict":
+		cookie.SameSite = http.SameSiteStrictMode
+	case "none":
+		cookie.SameSite = http.SameSiteNoneMode
+	default:
+		cookie.SameSite = http.SameSiteLaxMode
+	}
+
+	return cookie
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/pkg/auth"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Maximum request body size.
+const maxBodySize int64 = 1 << 20
+
+// HTTP gateway handler structure.
+type Handler struct {
+	auth    v1.UserAuthServiceClient
+	session v1.UserSessionServiceClient
+	cfg     config.HTTPConfig
+	authCfg config.AuthConfig
+}
+
+// Creating a new HTTP gateway handler.
+func NewHandler(conn grpc.ClientConnInterface, cfg config.HTTPConfig, authCfg config.AuthConfig) *Handler {
+	return &Handler{
+		auth:    v1.NewUserAuthServiceClient(conn),
+		session: v1.NewUserSessionServiceClient(conn),
+		cfg:     cfg,
+		authCfg: authCfg,
+	}
+}
+
+// Getting HTTP gateway routes.
+func (h *Handler) routes() []route {
+	routes := append(h.authRoutes(), h.sessionRoutes()...)
+
+	return append(routes, route{
+		Method:  http.MethodGet,
+		Path:    "/openapi.json",
+		Summary: "Getting OpenAPI document.",
+		handler: h.openAPI(routes),
+	})
+}
+
+// Creating a new HTTP gateway router.
+func (h *Handler) Router() http.Handler {
+	return &router{routes: h.routes(), onError: writeError}
+}
+
+// Getting an outgoing gRPC context with forwarded request metadata.
+func outgoingContext(r *http.Request) context.Context {
+	md := metadata.Pairs("x-forwarded-for", clientIp(r))
+
+	// Forwarding request id.
+	if id := r.Header.Get("X-Request-Id"); id != "" {
+		md.Set("x-request-id", id)
+	}
+
+	// Forwarding idempotency key.
+	if key := r.Header.Get("Idempotency-Key"); key != "" {
+		md.Set("idempotency-key", key)
+	}
+
+	return metadata.NewOutgoingContext(r.Context(), md)
+}
+
+// Getting a client ip address.
+func clientIp(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// Getting an authorized user id from the bearer access token.
+func (h *Handler) userId(r *http.Request) (ksuid.KSUID, error) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		return ksuid.Nil, status.Error(codes.Unauthenticated, "Missing access token")
+	}
+
+	// Validating access token.
+	subject, err := auth.ValidateAccessToken(token, h.authCfg.JWT.SigningKey)
+	if err != nil {
+		return ksuid.Nil, status.Error(codes.Unauthenticated, "Invalid access token")
+	}
+
+	id, err := ksuid.Parse(subject)
+	if err != nil {
+		return ksuid.Nil, status.Error(codes.Unauthenticated, "Invalid access token subject")
+	}
+
+	return id, nil
+}
+
+// Reading a JSON request body.
+func readJSON(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodySize))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(v); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid request body: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Writing a JSON response.
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Warn().Err(err).Msg("failed to write response")
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	transport "github.com/durudex/durudex-auth-service/internal/transport/http"
+)
+
+// Testing HTTP gateway routing without calling gRPC services.
+func TestHandler_Router(t *testing.T) {
+	// Testing args.
+	type args struct {
+		method, path, body string
+	}
+
+	// Creating a new router.
+	router := transport.NewHandler(nil, config.HTTPConfig{}, config.AuthConfig{}).Router()
+
+	// Tests structures.
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "OpenAPI document",
+			args: args{method: http.MethodGet, path: "/openapi.json"},
+			want: http.StatusOK,
+		},
+		{
+			name: "Unauthorized sessions",
+			args: args{method: http.MethodGet, path: "/v1/sessions"},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "Invalid request body",
+			args: args{method: http.MethodPost, path: "/v1/auth/sign-in", body: "{"},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Method not allowed",
+			args: args{method: http.MethodPut, path: "/v1/sessions/count"},
+			want: http.StatusMethodNotAllowed,
+		},
+		{
+			name: "Not found",
+			args: args{method: http.MethodGet, path: "/v1/unknown"},
+			want: http.StatusNotFound,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tt.args.method, tt.args.path, strings.NewReader(tt.args.body))
+
+			router.ServeHTTP(w, r)
+
+			// Check response status code.
+			if w.Code != tt.want {
+				t.Errorf("error response status code: got %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+// Testing generated OpenAPI document.
+func TestHandler_OpenAPI(t *testing.T) {
+	router := transport.NewHandler(nil, config.HTTPConfig{}, config.AuthConfig{}).Router()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	var document struct {
+		Paths map[string]map[string]interface{} `json:"paths"`
+	}
+
+	// Decoding OpenAPI document.
+	if err := json.NewDecoder(w.Body).Decode(&document); err != nil {
+		t.Fatalf("error decoding OpenAPI document: %s", err.Error())
+	}
+
+	// Check documented routes.
+	for path, method := range map[string]string{
+		"/v1/auth/sign-up":  "post",
+		"/v1/auth/sign-in":  "post",
+		"/v1/auth/refresh":  "post",
+		"/v1/auth/step-up":  "post",
+		"/v1/auth/sign-out": "post",
+		"/v1/sessions":      "get",
+		"/v1/sessions/{id}": "delete",
+	} {
+		if _, ok := document.Paths[path][method]; !ok {
+			t.Errorf("error route is not documented: %s %s", method, path)
+		}
+	}
+}
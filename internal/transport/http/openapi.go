@@ -0,0 +1,172 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// OpenAPI document object.
+type object map[string]interface{}
+
+// Getting OpenAPI document HTTP handler. The document is generated from the routes table.
+func (h *Handler) openAPI(routes []route) handlerFunc {
+	document := newOpenAPI(routes)
+
+	return func(w http.ResponseWriter, _ *http.Request) error {
+		writeJSON(w, http.StatusOK, document)
+		return nil
+	}
+}
+
+// Generating an OpenAPI document.
+func newOpenAPI(routes []route) object {
+	paths := object{}
+
+	for _, rt := range routes {
+		operation := object{
+			"summary": rt.Summary,
+			"responses": object{
+				"default": object{
+					"description": "Error response.",
+					"content":     jsonContent(schema(reflect.TypeOf(errorResponse{}))),
+				},
+			},
+		}
+
+		// Added successful response.
+		if rt.Response != nil {
+			operation["responses"].(object)["2XX"] = object{
+				"description": "Successful response.",
+				"content":     jsonContent(schema(reflect.TypeOf(rt.Response))),
+			}
+		} else {
+			operation["responses"].(object)["204"] = object{"description": "Successful response."}
+		}
+
+		// Added request parameters or body.
+		if rt.Request != nil {
+			if params := parameters(reflect.TypeOf(rt.Request)); len(params) != 0 {
+				operation["parameters"] = params
+			} else {
+				operation["requestBody"] = object{
+					"required": true,
+					"content":  jsonContent(schema(reflect.TypeOf(rt.Request))),
+				}
+			}
+		}
+
+		// Added bearer authorization.
+		if rt.Auth {
+			operation["security"] = []object{{"bearer": []string{}}}
+		}
+
+		path, ok := paths[rt.Path].(object)
+		if !ok {
+			path = object{}
+			paths[rt.Path] = path
+		}
+
+		path[strings.ToLower(rt.Method)] = operation
+	}
+
+	return object{
+		"openapi": "3.0.3",
+		"info": object{
+			"title":   "Durudex Auth Service",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": object{
+			"securitySchemes": object{
+				"bearer": object{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+	}
+}
+
+// Getting JSON media type content.
+func jsonContent(schema object) object {
+	return object{"application/json": object{"schema": schema}}
+}
+
+// Getting query and path parameters of the structure.
+func parameters(t reflect.Type) []object {
+	var params []object
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		in := field.Tag.Get("in")
+		if in == "" {
+			continue
+		}
+
+		params = append(params, object{
+			"name":     jsonName(field),
+			"in":       in,
+			"required": in == "path",
+			"schema":   schema(field.Type),
+		})
+	}
+
+	return params
+}
+
+// Generating a JSON schema of the type.
+func schema(t reflect.Type) object {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		return object{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.String:
+		return object{"type": "string"}
+	case t.Kind() == reflect.Bool:
+		return object{"type": "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return object{"type": "integer"}
+	case t.Kind() == reflect.Slice:
+		return object{"type": "array", "items": schema(t.Elem())}
+	case t.Kind() == reflect.Struct:
+		properties := object{}
+
+		for i := 0; i < t.NumField(); i++ {
+			properties[jsonName(t.Field(i))] = schema(t.Field(i).Type)
+		}
+
+		return object{"type": "object", "properties": properties}
+	default:
+		return object{}
+	}
+}
+
+// Getting a JSON field name.
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// HTTP route handler function.
+type handlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// HTTP route structure.
+type route struct {
+	// HTTP method.
+	Method string
+	// Route path, segments in braces are path parameters.
+	Path string
+	// Route summary.
+	Summary string
+	// Is route requires bearer access token.
+	Auth bool
+	// Request body, query or path parameters structure.
+	Request interface{}
+	// Response body structure.
+	Response interface{}
+	// Route handler.
+	handler handlerFunc
+}
+
+// Path parameters context key.
+type paramsContextKey struct{}
+
+// Getting a path parameter value.
+func param(r *http.Request, name string) string {
+	params, _ := r.Context().Value(paramsContextKey{}).(map[string]string)
+	return params[name]
+}
+
+// Matching a request path with the route path.
+func (rt *route) match(path string) (map[string]string, bool) {
+	want := strings.Split(strings.Trim(rt.Path, "/"), "/")
+	got := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(want) != len(got) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+
+	for i, segment := range want {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params[strings.Trim(segment, "{}")] = got[i]
+			continue
+		}
+
+		if segment != got[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// HTTP router structure.
+type router struct {
+	routes []route
+	// Route error handler.
+	onError func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// Serving HTTP request by the first matched route.
+func (rr *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var allowed bool
+
+	for i := range rr.routes {
+		rt := &rr.routes[i]
+
+		params, ok := rt.match(r.URL.Path)
+		if !ok {
+			continue
+		}
+
+		if rt.Method != r.Method {
+			allowed = true
+			continue
+		}
+
+		ctx := context.WithValue(r.Context(), paramsContextKey{}, params)
+
+		if err := rt.handler(w, r.WithContext(ctx)); err != nil {
+			rr.onError(w, r, err)
+		}
+
+		return
+	}
+
+	if allowed {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	http.NotFound(w, r)
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HTTP server shutdown timeout.
+const shutdownTimeout time.Duration = time.Second * 5
+
+// HTTP gateway server structure.
+type Server struct{ server *http.Server }
+
+// Creating a new HTTP gateway server.
+func NewServer(cfg config.HTTPConfig, handler *Handler) *Server {
+	return &Server{server: &http.Server{
+		Addr:              cfg.Host + ":" + cfg.Port,
+		Handler:           handler.Router(),
+		ReadHeaderTimeout: time.Second * 5,
+	}}
+}
+
+// Running HTTP gateway server.
+func (s *Server) Run() {
+	log.Info().Msg("Running HTTP gateway server...")
+
+	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal().Err(err).Msg("error running HTTP gateway server")
+	}
+}
+
+// Stopping HTTP gateway server.
+func (s *Server) Stop() {
+	log.Info().Msg("Stopping HTTP gateway server...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		log.Error().Err(err).Msg("failed to stop HTTP gateway server")
+	}
+}
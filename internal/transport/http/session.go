@@ -0,0 +1,356 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+	"github.com/durudex/go-protobuf-type/pbtype"
+
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// User sessions query parameters.
+type sessionsQuery struct {
+	First  *int32 `json:"first,omitempty" in:"query"`
+	Last   *int32 `json:"last,omitempty" in:"query"`
+	Before string `json:"before,omitempty" in:"query"`
+	After  string `json:"after,omitempty" in:"query"`
+
+	Ip            string    `json:"ip,omitempty" in:"query"`
+	CreatedAfter  time.Time `json:"created_after,omitempty" in:"query"`
+	CreatedBefore time.Time `json:"created_before,omitempty" in:"query"`
+	State         string    `json:"state,omitempty" in:"query"`
+	DeviceType    string    `json:"device_type,omitempty" in:"query"`
+	DeviceName    string    `json:"device_name,omitempty" in:"query"`
+}
+
+// User session path parameters.
+type sessionPath struct {
+	Id string `json:"id" in:"path"`
+}
+
+// User session response body.
+type sessionResponse struct {
+	Id         string    `json:"id,omitempty"`
+	Ip         string    `json:"ip"`
+	ExpiresIn  time.Time `json:"expires_in"`
+	DeviceType string    `json:"device_type"`
+	DeviceName string    `json:"device_name"`
+}
+
+// User session edge response body.
+type sessionEdgeResponse struct {
+	Cursor string          `json:"cursor"`
+	Node   sessionResponse `json:"node"`
+}
+
+// Connection page info response body.
+type pageInfoResponse struct {
+	HasNextPage     bool   `json:"has_next_page"`
+	HasPreviousPage bool   `json:"has_previous_page"`
+	StartCursor     string `json:"start_cursor,omitempty"`
+	EndCursor       string `json:"end_cursor,omitempty"`
+}
+
+// User sessions response body. Sessions are kept for compatibility, use edges instead.
+type sessionsResponse struct {
+	Sessions []sessionResponse     `json:"sessions"`
+	Edges    []sessionEdgeResponse `json:"edges"`
+	PageInfo pageInfoResponse      `json:"page_info"`
+}
+
+// User sessions count response body.
+type sessionsCountResponse struct {
+	Count int32 `json:"count"`
+}
+
+// Getting user session routes.
+func (h *Handler) sessionRoutes() []route {
+	return []route{
+		{
+			Method:   http.MethodGet,
+			Path:     "/v1/sessions",
+			Summary:  "Getting authorized user sessions.",
+			Auth:     true,
+			Request:  sessionsQuery{},
+			Response: sessionsResponse{},
+			handler:  h.getSessions,
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/v1/sessions/count",
+			Summary:  "Getting total authorized user session count.",
+			Auth:     true,
+			Response: sessionsCountResponse{},
+			handler:  h.getSessionsCount,
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/v1/sessions/{id}",
+			Summary:  "Getting authorized user session.",
+			Auth:     true,
+			Request:  sessionPath{},
+			Response: sessionResponse{},
+			handler:  h.getSession,
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/v1/sessions/{id}",
+			Summary: "Deleting authorized user session.",
+			Auth:    true,
+			Request: sessionPath{},
+			handler: h.deleteSession,
+		},
+	}
+}
+
+// Getting user sessions HTTP handler.
+func (h *Handler) getSessions(w http.ResponseWriter, r *http.Request) error {
+	userId, err := h.userId(r)
+	if err != nil {
+		return err
+	}
+
+	// Parsing sort options query parameters.
+	sort, err := sortOptions(r)
+	if err != nil {
+		return err
+	}
+
+	// Parsing filter query parameters.
+	filter, err := sessionFilter(r)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.session.GetUserSessions(outgoingContext(r), &v1.GetUserSessionsRequest{
+		UserId:      userId.Bytes(),
+		SortOptions: sort,
+		Filter:      filter,
+	})
+	if err != nil {
+		return err
+	}
+
+	sessions := make([]sessionResponse, len(res.Edges))
+	edges := make([]sessionEdgeResponse, len(res.Edges))
+
+	for i, edge := range res.Edges {
+		sessions[i] = sessionResponse{
+			Id:         ksuid.FromBytesOrNil(edge.Node.Id).String(),
+			Ip:         edge.Node.Ip,
+			ExpiresIn:  edge.Node.ExpiresIn.AsTime(),
+			DeviceType: edge.Node.DeviceType,
+			DeviceName: edge.Node.DeviceName,
+		}
+
+		edges[i] = sessionEdgeResponse{Cursor: cursorString(edge.Cursor), Node: sessions[i]}
+	}
+
+	writeJSON(w, http.StatusOK, sessionsResponse{
+		Sessions: sessions,
+		Edges:    edges,
+		PageInfo: pageInfoResponse{
+			HasNextPage:     res.PageInfo.GetHasNextPage(),
+			HasPreviousPage: res.PageInfo.GetHasPreviousPage(),
+			StartCursor:     cursorString(res.PageInfo.GetStartCursor()),
+			EndCursor:       cursorString(res.PageInfo.GetEndCursor()),
+		},
+	})
+
+	return nil
+}
+
+// Getting total user session count HTTP handler.
+func (h *Handler) getSessionsCount(w http.ResponseWriter, r *http.Request) error {
+	userId, err := h.userId(r)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.session.GetTotalUserSessionCount(outgoingContext(r), &v1.GetTotalUserSessionCountRequest{
+		UserId: userId.Bytes(),
+	})
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, sessionsCountResponse{Count: res.Count})
+
+	return nil
+}
+
+// Getting a user session HTTP handler.
+func (h *Handler) getSession(w http.ResponseWriter, r *http.Request) error {
+	userId, err := h.userId(r)
+	if err != nil {
+		return err
+	}
+
+	id, err := pathId(r)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.session.GetUserSession(outgoingContext(r), &v1.GetUserSessionRequest{
+		Id:     id.Bytes(),
+		UserId: userId.Bytes(),
+	})
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, sessionResponse{
+		Id:         id.String(),
+		Ip:         res.Ip,
+		ExpiresIn:  res.ExpiresIn.AsTime(),
+		DeviceType: res.DeviceType,
+		DeviceName: res.DeviceName,
+	})
+
+	return nil
+}
+
+// Deleting a user session HTTP handler.
+func (h *Handler) deleteSession(w http.ResponseWriter, r *http.Request) error {
+	userId, err := h.userId(r)
+	if err != nil {
+		return err
+	}
+
+	id, err := pathId(r)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.session.DeleteUserSession(outgoingContext(r), &v1.DeleteUserSessionRequest{
+		Id:     id.Bytes(),
+		UserId: userId.Bytes(),
+	}); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+// Parsing a session id path parameter.
+func pathId(r *http.Request) (ksuid.KSUID, error) {
+	id, err := ksuid.Parse(param(r, "id"))
+	if err != nil {
+		return ksuid.Nil, status.Error(codes.InvalidArgument, "Invalid session id")
+	}
+
+	return id, nil
+}
+
+// Getting a cursor query parameter string. Cursors are opaque url safe strings.
+func cursorString(cursor []byte) string {
+	return string(cursor)
+}
+
+// Parsing query sort options.
+func sortOptions(r *http.Request) (*pbtype.SortOptions, error) {
+	query := r.URL.Query()
+	sort := &pbtype.SortOptions{}
+
+	// Parsing page size parameters.
+	for name, dst := range map[string]**int32{"first": &sort.First, "last": &sort.Last} {
+		if value := query.Get(name); value != "" {
+			n, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "Invalid `%s` parameter", name)
+			}
+
+			size := int32(n)
+			*dst = &size
+		}
+	}
+
+	// Parsing cursor parameters.
+	for name, dst := range map[string]*[]byte{"before": &sort.Before, "after": &sort.After} {
+		if value := query.Get(name); value != "" {
+			if _, err := domain.DecodeCursor([]byte(value)); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "Invalid `%s` parameter", name)
+			}
+
+			*dst = []byte(value)
+		}
+	}
+
+	return sort, nil
+}
+
+// Session state query parameter values.
+var sessionStates = map[string]v1.UserSessionState{
+	"active":  v1.UserSessionState_USER_SESSION_STATE_ACTIVE,
+	"expired": v1.UserSessionState_USER_SESSION_STATE_EXPIRED,
+}
+
+// Parsing query sessions filter.
+func sessionFilter(r *http.Request) (*v1.UserSessionFilter, error) {
+	query := r.URL.Query()
+	filter := &v1.UserSessionFilter{}
+
+	// Parsing optional string parameters.
+	for name, dst := range map[string]**string{
+		"ip":          &filter.Ip,
+		"device_type": &filter.DeviceType,
+		"device_name": &filter.DeviceName,
+	} {
+		if query.Has(name) {
+			value := query.Get(name)
+			*dst = &value
+		}
+	}
+
+	// Parsing created time parameters.
+	for name, dst := range map[string]**pbtype.Timestamp{
+		"created_after":  &filter.CreatedAfter,
+		"created_before": &filter.CreatedBefore,
+	} {
+		if value := query.Get(name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "Invalid `%s` parameter", name)
+			}
+
+			*dst = pbtype.New(t)
+		}
+	}
+
+	// Parsing session state parameter.
+	if value := query.Get("state"); value != "" {
+		state, ok := sessionStates[value]
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "Invalid `state` parameter")
+		}
+
+		filter.State = state
+	}
+
+	return filter, nil
+}
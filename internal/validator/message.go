@@ -0,0 +1,108 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package validator
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Common field rules.
+var (
+	usernameRules = []Rule{Required, Length(3, 40)}
+	emailRules    = []Rule{Required, Email}
+	passwordRules = []Rule{Required, Length(1, 256)}
+	secretRules   = []Rule{Required, Length(1, 256)}
+	ipRules       = []Rule{Required, IP}
+	idRules       = []Rule{Required, KSUID}
+)
+
+// Request messages field rules.
+var messages = map[protoreflect.FullName][]Field{
+	// User auth service.
+	"durudex.v1.UserSignUpRequest": {
+		{Name: "username", Rules: usernameRules},
+		{Name: "email", Rules: emailRules},
+		{Name: "password", Rules: passwordRules},
+		{Name: "secret", Rules: secretRules},
+		{Name: "code", Rules: []Rule{Required}},
+		{Name: "ip", Rules: ipRules},
+	},
+	"durudex.v1.UserSignInRequest": {
+		{Name: "username", Rules: usernameRules},
+		{Name: "password", Rules: passwordRules},
+		{Name: "secret", Rules: secretRules},
+		{Name: "ip", Rules: ipRules},
+	},
+	"durudex.v1.RefreshUserTokenRequest": {
+		{Name: "refresh", Rules: []Rule{Required}},
+		{Name: "secret", Rules: secretRules},
+	},
+	"durudex.v1.UserStepUpRequest": {
+		{Name: "refresh", Rules: []Rule{Required}},
+		{Name: "secret", Rules: secretRules},
+		{Name: "password", Rules: passwordRules},
+		{Name: "ip", Rules: ipRules},
+	},
+	"durudex.v1.UserSignOutRequest": {
+		{Name: "refresh", Rules: []Rule{Required}},
+		{Name: "secret", Rules: secretRules},
+	},
+
+	// User session service.
+	"durudex.v1.GetUserSessionRequest": {
+		{Name: "id", Rules: idRules},
+		{Name: "user_id", Rules: idRules},
+	},
+	"durudex.v1.GetUserSessionsRequest": {
+		{Name: "user_id", Rules: idRules},
+		{Name: "sort_options", Rules: []Rule{SortOptions}},
+		{Name: "filter", Rules: []Rule{SessionFilter}},
+	},
+	"durudex.v1.DeleteUserSessionRequest": {
+		{Name: "id", Rules: idRules},
+		{Name: "user_id", Rules: idRules},
+	},
+	"durudex.v1.GetTotalUserSessionCountRequest": {
+		{Name: "user_id", Rules: idRules},
+	},
+
+	// Auth audit service.
+	"durudex.v1.GetAuthEventsRequest": {
+		{Name: "sort_options", Rules: []Rule{SortOptions}},
+	},
+
+	// Auth webhook service.
+	"durudex.v1.CreateWebhookRequest": {
+		{Name: "url", Rules: []Rule{Required, Length(1, 2048), URL}},
+		{Name: "event_types", Rules: []Rule{Required, EventTypes}},
+		{Name: "secret", Rules: []Rule{Required, Length(16, 256)}},
+	},
+	"durudex.v1.GetWebhookRequest": {
+		{Name: "id", Rules: idRules},
+	},
+	"durudex.v1.DeleteWebhookRequest": {
+		{Name: "id", Rules: idRules},
+	},
+	"durudex.v1.EnableWebhookRequest": {
+		{Name: "id", Rules: idRules},
+	},
+	"durudex.v1.GetWebhookDeliveriesRequest": {
+		{Name: "webhook_id", Rules: idRules},
+		{Name: "sort_options", Rules: []Rule{SortOptions}},
+	},
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package validator
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/pkg/useragent"
+
+	"github.com/segmentio/ksuid"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Checking is field value set.
+func Required(_ *config.ValidationConfig, v protoreflect.Value) string {
+	if !v.IsValid() {
+		return "must be set"
+	}
+
+	switch value := v.Interface().(type) {
+	case string:
+		if value == "" {
+			return "must be set"
+		}
+	case []byte:
+		if len(value) == 0 {
+			return "must be set"
+		}
+	case uint64:
+		if value == 0 {
+			return "must be set"
+		}
+	}
+
+	return ""
+}
+
+// Checking string field length in characters.
+func Length(min, max int) Rule {
+	return func(_ *config.ValidationConfig, v protoreflect.Value) string {
+		if n := utf8.RuneCountInString(v.String()); n < min || n > max {
+			return fmt.Sprintf("must be between %d and %d characters", min, max)
+		}
+
+		return ""
+	}
+}
+
+// Checking is string field a valid email address.
+func Email(_ *config.ValidationConfig, v protoreflect.Value) string {
+	address, err := mail.ParseAddress(v.String())
+	if err != nil || address.Address != v.String() {
+		return "must be a valid email address"
+	}
+
+	return ""
+}
+
+// Checking is string field a valid ip address.
+func IP(_ *config.ValidationConfig, v protoreflect.Value) string {
+	if net.ParseIP(v.String()) == nil {
+		return "must be a valid ip address"
+	}
+
+	return ""
+}
+
+// Checking is string field an absolute http or https URL.
+func URL(_ *config.ValidationConfig, v protoreflect.Value) string {
+	u, err := url.Parse(v.String())
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "must be a valid http or https URL"
+	}
+
+	return ""
+}
+
+// Checking is repeated string field a list of known auth domain event types.
+func EventTypes(_ *config.ValidationConfig, v protoreflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+
+	list := v.List()
+
+	for i := 0; i < list.Len(); i++ {
+		if typ := list.Get(i).String(); !domain.EventType(typ).Valid() {
+			return fmt.Sprintf("`%s` is not a known event type", typ)
+		}
+	}
+
+	return ""
+}
+
+// Checking is bytes field a valid id.
+func KSUID(_ *config.ValidationConfig, v protoreflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+
+	if _, err := ksuid.FromBytes(v.Bytes()); err != nil {
+		return "must be a valid id"
+	}
+
+	return ""
+}
+
+// Checking query sort options.
+func SortOptions(cfg *config.ValidationConfig, v protoreflect.Value) string {
+	if !v.IsValid() {
+		return "must be set"
+	}
+
+	m := v.Message()
+	fields := m.Descriptor().Fields()
+
+	first, last := fields.ByName("first"), fields.ByName("last")
+
+	// Checking is first and last are set.
+	if m.Has(first) == m.Has(last) {
+		return "must be `first` or `last`"
+	}
+
+	// Checking page size.
+	for _, fd := range []protoreflect.FieldDescriptor{first, last} {
+		if !m.Has(fd) {
+			continue
+		}
+
+		if n := m.Get(fd).Int(); n < 1 || n > int64(cfg.MaxPageSize) {
+			return fmt.Sprintf("`%s` must be between 1 and %d", fd.Name(), cfg.MaxPageSize)
+		}
+	}
+
+	// Checking cursors.
+	for _, name := range []protoreflect.Name{"before", "after"} {
+		fd := fields.ByName(name)
+		if !m.Has(fd) {
+			continue
+		}
+
+		if _, err := domain.DecodeCursor(m.Get(fd).Bytes()); err != nil {
+			return fmt.Sprintf("`%s` must be a valid cursor", name)
+		}
+	}
+
+	return ""
+}
+
+// Checking user sessions filter.
+func SessionFilter(_ *config.ValidationConfig, v protoreflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+
+	m := v.Message()
+	fields := m.Descriptor().Fields()
+
+	// Checking ip address or network.
+	if fd := fields.ByName("ip"); m.Has(fd) {
+		if _, err := domain.ParseNetwork(m.Get(fd).String()); err != nil {
+			return "`ip` must be a valid ip address or network"
+		}
+	}
+
+	// Checking device type.
+	if fd := fields.ByName("device_type"); m.Has(fd) && !useragent.IsDeviceType(m.Get(fd).String()) {
+		return fmt.Sprintf("`device_type` must be one of %s", strings.Join(useragent.DeviceTypes, ", "))
+	}
+
+	// Checking device name length.
+	if fd := fields.ByName("device_name"); m.Has(fd) && utf8.RuneCountInString(m.Get(fd).String()) > 255 {
+		return "`device_name` must be at most 255 characters"
+	}
+
+	return ""
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package validator
+
+import (
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Field validation rule.
+type Rule func(cfg *config.ValidationConfig, v protoreflect.Value) string
+
+// Message field rules.
+type Field struct {
+	Name  protoreflect.Name
+	Rules []Rule
+}
+
+// Request validator structure.
+type Validator struct {
+	cfg      *config.ValidationConfig
+	messages map[protoreflect.FullName][]Field
+}
+
+// Creating a new request validator.
+func NewValidator(cfg config.ValidationConfig) *Validator {
+	// Set default maximum page size.
+	if cfg.MaxPageSize <= 0 {
+		cfg.MaxPageSize = domain.DefaultMaxPageSize
+	}
+
+	return &Validator{cfg: &cfg, messages: messages}
+}
+
+// Validating a request message. Returns InvalidArgument status error with field violation details.
+func (v *Validator) Validate(msg proto.Message) error {
+	m := msg.ProtoReflect()
+
+	// Getting message fields rules.
+	fields, ok := v.messages[m.Descriptor().FullName()]
+	if !ok {
+		return nil
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	for _, field := range fields {
+		fd := m.Descriptor().Fields().ByName(field.Name)
+		if fd == nil {
+			continue
+		}
+
+		// Getting a field value, unset fields are passed as invalid value.
+		var value protoreflect.Value
+		if m.Has(fd) {
+			value = m.Get(fd)
+		}
+
+		for _, rule := range field.Rules {
+			if description := rule(v.cfg, value); description != "" {
+				violations = append(violations, &errdetails.BadRequest_FieldViolation{
+					Field:       string(field.Name),
+					Description: description,
+				})
+				break
+			}
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	st := status.New(codes.InvalidArgument, "Invalid request argument")
+
+	// Added field violation details.
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+
+	return st.Err()
+}
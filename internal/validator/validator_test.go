@@ -0,0 +1,193 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package validator_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/validator"
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+	"github.com/durudex/go-protobuf-type/pbtype"
+
+	"github.com/segmentio/ksuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Testing validating a request message.
+func TestValidator_Validate(t *testing.T) {
+	// Testing args.
+	type args struct{ msg proto.Message }
+
+	// Creating a new validator.
+	v := validator.NewValidator(config.ValidationConfig{MaxPageSize: 10})
+
+	first, tooMany := int32(10), int32(11)
+
+	// Tests structures.
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "OK",
+			args: args{msg: &v1.UserSignUpRequest{
+				Username: "example",
+				Email:    "example@durudex.com",
+				Password: "qwerty",
+				Secret:   "secret",
+				Code:     123456,
+				Ip:       "0.0.0.0",
+			}},
+		},
+		{
+			name: "Invalid sign up",
+			args: args{msg: &v1.UserSignUpRequest{
+				Username: "ex",
+				Email:    "example",
+				Password: "qwerty",
+				Secret:   "secret",
+				Ip:       "0.0.0.0",
+			}},
+			want: []string{"username", "email", "code"},
+		},
+		{
+			name: "Invalid sign out",
+			args: args{msg: &v1.UserSignOutRequest{Secret: "secret"}},
+			want: []string{"refresh"},
+		},
+		{
+			name: "Unset sort options",
+			args: args{msg: &v1.GetUserSessionsRequest{UserId: ksuid.New().Bytes()}},
+			want: []string{"sort_options"},
+		},
+		{
+			name: "Sessions page",
+			args: args{msg: &v1.GetUserSessionsRequest{
+				UserId:      ksuid.New().Bytes(),
+				SortOptions: &pbtype.SortOptions{First: &first},
+			}},
+		},
+		{
+			name: "Too large sessions page",
+			args: args{msg: &v1.GetUserSessionsRequest{
+				UserId:      []byte("invalid"),
+				SortOptions: &pbtype.SortOptions{Last: &tooMany},
+			}},
+			want: []string{"user_id", "sort_options"},
+		},
+		{
+			name: "Sessions page after cursor",
+			args: args{msg: &v1.GetUserSessionsRequest{
+				UserId:      ksuid.New().Bytes(),
+				SortOptions: &pbtype.SortOptions{First: &first, After: domain.EncodeCursor(ksuid.New())},
+			}},
+		},
+		{
+			name: "Raw id cursor",
+			args: args{msg: &v1.GetUserSessionsRequest{
+				UserId:      ksuid.New().Bytes(),
+				SortOptions: &pbtype.SortOptions{First: &first, Before: ksuid.New().Bytes()},
+			}},
+			want: []string{"sort_options"},
+		},
+		{
+			name: "Filtered sessions page",
+			args: args{msg: &v1.GetUserSessionsRequest{
+				UserId:      ksuid.New().Bytes(),
+				SortOptions: &pbtype.SortOptions{First: &first},
+				Filter:      &v1.UserSessionFilter{Ip: proto.String("10.0.0.0/8"), DeviceType: proto.String("mobile")},
+			}},
+		},
+		{
+			name: "Invalid sessions filter",
+			args: args{msg: &v1.GetUserSessionsRequest{
+				UserId:      ksuid.New().Bytes(),
+				SortOptions: &pbtype.SortOptions{First: &first},
+				Filter:      &v1.UserSessionFilter{Ip: proto.String("10.0.0.0/33")},
+			}},
+			want: []string{"filter"},
+		},
+		{
+			name: "Too large auth events page",
+			args: args{msg: &v1.GetAuthEventsRequest{SortOptions: &pbtype.SortOptions{First: &tooMany}}},
+			want: []string{"sort_options"},
+		},
+		{
+			name: "Valid webhook",
+			args: args{msg: &v1.CreateWebhookRequest{
+				Url:        "https://example.com/webhook",
+				EventTypes: []string{"session_created", "session_revoked"},
+				Secret:     "0123456789abcdef",
+			}},
+		},
+		{
+			name: "Invalid webhook",
+			args: args{msg: &v1.CreateWebhookRequest{
+				Url:        "ftp://example.com",
+				EventTypes: []string{"session_created", "unknown"},
+				Secret:     "short",
+			}},
+			want: []string{"url", "event_types", "secret"},
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Validating a request message.
+			err := v.Validate(tt.args.msg)
+			if (err != nil) != (tt.want != nil) {
+				t.Fatalf("error validating message: %v", err)
+			}
+
+			if err == nil {
+				return
+			}
+
+			st := status.Convert(err)
+
+			// Check error status code.
+			if st.Code() != codes.InvalidArgument {
+				t.Fatalf("error status code: %s", st.Code())
+			}
+
+			var got []string
+
+			// Getting violated fields.
+			for _, detail := range st.Details() {
+				if br, ok := detail.(*errdetails.BadRequest); ok {
+					for _, violation := range br.FieldViolations {
+						got = append(got, violation.Field)
+					}
+				}
+			}
+
+			// Check for similarity of violated fields.
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("error violated fields are not similar: %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/metrics"
+	"github.com/durudex/durudex-auth-service/internal/recovery"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	sig "github.com/durudex/durudex-auth-service/pkg/webhook"
+
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/ksuid"
+)
+
+// Webhook delivery results.
+const (
+	resultDelivered string = "delivered"
+	resultRetried   string = "retried"
+	resultFailed    string = "failed"
+)
+
+// Maximum size of the endpoint response body read before closing the connection.
+const maxResponseSize int64 = 64 << 10
+
+// Webhook dispatcher user agent.
+const userAgent string = "Durudex-Webhook/1.0"
+
+// Disabled webhook delivery error message.
+const disabledError string = "Webhook is disabled"
+
+// Webhook dispatcher. Sends claimed deliveries to the webhook endpoints with exponential
+// backoff, fails deliveries after max attempts and disables endpoints that keep failing.
+type Dispatcher struct {
+	webhooks   postgres.Webhook
+	deliveries postgres.WebhookDelivery
+	client     *http.Client
+	cfg        config.WebhookConfig
+	done       chan struct{}
+	stop       chan struct{}
+}
+
+// Creating a new webhook dispatcher.
+func NewDispatcher(webhooks postgres.Webhook, deliveries postgres.WebhookDelivery, cfg config.WebhookConfig) *Dispatcher {
+	return &Dispatcher{
+		webhooks:   webhooks,
+		deliveries: deliveries,
+		client:     &http.Client{Timeout: cfg.Timeout},
+		cfg:        cfg,
+		done:       make(chan struct{}),
+		stop:       make(chan struct{}),
+	}
+}
+
+// Running webhook dispatcher.
+func (d *Dispatcher) Run() {
+	log.Info().Msg("Running webhook dispatcher...")
+
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+			recovery.Do("webhook dispatcher", func() {
+				if err := d.Dispatch(context.Background()); err != nil {
+					log.Error().Err(err).Msg("failed to dispatch webhook deliveries")
+				}
+			})
+		}
+	}
+}
+
+// Stopping webhook dispatcher. Waits for the current batch.
+func (d *Dispatcher) Stop() {
+	log.Info().Msg("Stopping webhook dispatcher...")
+
+	close(d.stop)
+	<-d.done
+}
+
+// Dispatching a batch of due webhook deliveries. Deliveries of the staged auth domain events
+// are created before claiming.
+func (d *Dispatcher) Dispatch(ctx context.Context) error {
+	if err := d.deliveries.Fanout(ctx, d.cfg.BatchSize); err != nil {
+		return err
+	}
+
+	deliveries, err := d.deliveries.Claim(ctx, d.cfg.BatchSize, d.cfg.Lease)
+	if err != nil {
+		return err
+	}
+
+	// Webhooks of the batch, nil when the webhook is deleted.
+	webhooks := make(map[ksuid.KSUID]*domain.Webhook)
+
+	for _, delivery := range deliveries {
+		webhook, ok := webhooks[delivery.WebhookId]
+		if !ok {
+			if webhook, err = d.getWebhook(ctx, delivery.WebhookId); err != nil {
+				return err
+			}
+
+			webhooks[delivery.WebhookId] = webhook
+		}
+
+		// Deliveries of disabled and deleted webhooks are failed without sending.
+		if webhook == nil || !webhook.Enabled {
+			delivery.Status, delivery.LastError = domain.WebhookDeliveryFailed, disabledError
+			metrics.WebhookDeliveriesTotal.WithLabelValues(string(delivery.EventType), resultFailed).Inc()
+
+			if err := d.deliveries.Update(ctx, delivery, time.Time{}); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		code, err := d.send(ctx, webhook, delivery)
+		delivery.ResponseCode = code
+
+		if err != nil {
+			if err := d.fail(ctx, webhook, delivery, err); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		delivery.Status, delivery.LastError = domain.WebhookDeliveryDelivered, ""
+		metrics.WebhookDeliveriesTotal.WithLabelValues(string(delivery.EventType), resultDelivered).Inc()
+
+		if err := d.deliveries.Update(ctx, delivery, time.Time{}); err != nil {
+			return err
+		}
+
+		// Resetting consecutive failures of the webhook.
+		if webhook.Failures != 0 {
+			if err := d.webhooks.Succeed(ctx, webhook.Id); err != nil {
+				return err
+			}
+
+			webhook.Failures = 0
+		}
+	}
+
+	return nil
+}
+
+// Getting a webhook of the delivery, nil when the webhook is deleted.
+func (d *Dispatcher) getWebhook(ctx context.Context, id ksuid.KSUID) (*domain.Webhook, error) {
+	webhook, err := d.webhooks.Get(ctx, id)
+	if err != nil {
+		var domainErr *domain.Error
+
+		if errors.As(err, &domainErr) && domainErr.Code == domain.CodeNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// Sending a signed delivery to the webhook endpoint, returns the response status code.
+func (d *Dispatcher) send(ctx context.Context, webhook *domain.Webhook, delivery domain.WebhookDelivery) (int32, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(sig.EventHeader, string(delivery.EventType))
+	req.Header.Set(sig.DeliveryHeader, delivery.Id.String())
+	req.Header.Set(sig.SignatureHeader, sig.Sign(webhook.Secret, time.Now(), delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Draining response body to reuse the connection.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return int32(resp.StatusCode), fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return int32(resp.StatusCode), nil
+}
+
+// Scheduling a retry or failing the delivery, the webhook is disabled after consecutive failures.
+func (d *Dispatcher) fail(ctx context.Context, webhook *domain.Webhook, delivery domain.WebhookDelivery, deliveryErr error) error {
+	logger := log.With().Str("id", delivery.Id.String()).Str("webhook", webhook.Id.String()).
+		Int32("attempts", delivery.Attempts).Err(deliveryErr).Logger()
+
+	// Zero limit never disables webhooks.
+	limit := d.cfg.DisableAfter
+	if limit <= 0 {
+		limit = math.MaxInt32
+	}
+
+	disabled, err := d.webhooks.Fail(ctx, webhook.Id, limit)
+	if err != nil {
+		return err
+	}
+
+	webhook.Failures++
+	delivery.LastError = deliveryErr.Error()
+
+	if disabled {
+		webhook.Enabled = false
+
+		logger.Warn().Msg("webhook disabled after consecutive failed deliveries")
+		metrics.WebhooksDisabledTotal.Inc()
+	}
+
+	// Failing the delivery.
+	if disabled || delivery.Attempts >= d.cfg.MaxAttempts {
+		logger.Error().Msg("webhook delivery failed")
+		metrics.WebhookDeliveriesTotal.WithLabelValues(string(delivery.EventType), resultFailed).Inc()
+
+		delivery.Status = domain.WebhookDeliveryFailed
+
+		return d.deliveries.Update(ctx, delivery, time.Time{})
+	}
+
+	logger.Warn().Msg("failed to send webhook delivery, retrying")
+	metrics.WebhookDeliveriesTotal.WithLabelValues(string(delivery.EventType), resultRetried).Inc()
+
+	delivery.Status = domain.WebhookDeliveryPending
+
+	return d.deliveries.Update(ctx, delivery, time.Now().Add(d.backoff(delivery.Attempts)))
+}
+
+// Getting exponential retry backoff by number of attempts.
+func (d *Dispatcher) backoff(attempts int32) time.Duration {
+	backoff := d.cfg.InitialBackoff
+
+	for i := int32(1); i < attempts && backoff < d.cfg.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > d.cfg.MaxBackoff {
+		return d.cfg.MaxBackoff
+	}
+
+	return backoff
+}
@@ -0,0 +1,222 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/internal/config"
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/repository/memory"
+	"github.com/durudex/durudex-auth-service/internal/webhook"
+	sig "github.com/durudex/durudex-auth-service/pkg/webhook"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Webhook receiver verifying delivery signatures.
+type receiver struct {
+	mu       sync.Mutex
+	secret   string
+	status   int
+	payloads []sig.Payload
+	invalid  int
+}
+
+// Receiving a webhook delivery.
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	body, _ := io.ReadAll(req.Body)
+
+	if err := sig.Verify(r.secret, req.Header.Get(sig.SignatureHeader), body, time.Now(), sig.DefaultTolerance); err != nil {
+		r.invalid++
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	var payload sig.Payload
+	if err := json.Unmarshal(body, &payload); err != nil || req.Header.Get(sig.EventHeader) != payload.Type {
+		r.invalid++
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	r.payloads = append(r.payloads, payload)
+	w.WriteHeader(r.status)
+}
+
+// Creating a new webhook with the receiver endpoint.
+func newWebhook(t *testing.T, repos *memory.MemoryRepository, r *receiver) domain.Webhook {
+	t.Helper()
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	webhook := domain.Webhook{
+		Id:         ksuid.New(),
+		URL:        srv.URL,
+		EventTypes: []domain.EventType{domain.EventSessionCreated},
+		Secret:     r.secret,
+		Enabled:    true,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := repos.Webhook.Create(context.Background(), webhook); err != nil {
+		t.Fatalf("error creating webhook: %s", err.Error())
+	}
+
+	return webhook
+}
+
+// Testing dispatching signed webhook deliveries.
+func TestDispatcher_Dispatch(t *testing.T) {
+	repos := memory.NewMemoryRepository()
+	ctx := context.Background()
+
+	r := &receiver{secret: "0123456789abcdef", status: http.StatusNoContent}
+	subscribed := newWebhook(t, repos, r)
+
+	publisher := webhook.NewPublisher(repos.WebhookDelivery)
+	dispatcher := webhook.NewDispatcher(repos.Webhook, repos.WebhookDelivery, config.WebhookConfig{
+		BatchSize:   10,
+		Lease:       time.Minute,
+		Timeout:     time.Second,
+		MaxAttempts: 3,
+	})
+
+	userId := ksuid.New()
+
+	// Not subscribed events are not delivered.
+	if err := publisher.Publish(ctx,
+		domain.Event{Type: domain.EventSessionCreated, UserId: userId, SessionId: ksuid.New(), Ip: "127.0.0.1"},
+		domain.Event{Type: domain.EventSessionRevoked, UserId: userId, SessionId: ksuid.New()},
+	); err != nil {
+		t.Fatalf("error publishing events: %s", err.Error())
+	}
+
+	if err := dispatcher.Dispatch(ctx); err != nil {
+		t.Fatalf("error dispatching deliveries: %s", err.Error())
+	}
+
+	if r.invalid != 0 || len(r.payloads) != 1 {
+		t.Fatalf("error received deliveries: got %d valid, %d invalid", len(r.payloads), r.invalid)
+	}
+
+	if p := r.payloads[0]; p.Type != string(domain.EventSessionCreated) || p.Data.UserId != userId.String() ||
+		p.Data.Ip != "127.0.0.1" {
+		t.Errorf("error delivery payload: got %+v", p)
+	}
+
+	first := int32(10)
+
+	deliveries, _, err := repos.WebhookDelivery.GetList(ctx, subscribed.Id, domain.SortOptions{First: &first})
+	if err != nil {
+		t.Fatalf("error getting deliveries: %s", err.Error())
+	}
+
+	if len(deliveries) != 1 || deliveries[0].Status != domain.WebhookDeliveryDelivered ||
+		deliveries[0].ResponseCode != http.StatusNoContent || deliveries[0].Attempts != 1 {
+		t.Errorf("error deliveries log: got %+v", deliveries)
+	}
+}
+
+// Testing retrying failed deliveries and disabling failing webhooks.
+func TestDispatcher_Dispatch_Failing(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name         string
+		disableAfter int32
+		wantAttempts int32
+		wantEnabled  bool
+	}{
+		{
+			name:         "Failed after max attempts",
+			wantAttempts: 3,
+			wantEnabled:  true,
+		},
+		{
+			name:         "Disabled after consecutive failures",
+			disableAfter: 2,
+			wantAttempts: 2,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := memory.NewMemoryRepository()
+			ctx := context.Background()
+
+			r := &receiver{secret: "0123456789abcdef", status: http.StatusInternalServerError}
+			failing := newWebhook(t, repos, r)
+
+			// Retries are due immediately without the backoff.
+			publisher := webhook.NewPublisher(repos.WebhookDelivery)
+			dispatcher := webhook.NewDispatcher(repos.Webhook, repos.WebhookDelivery, config.WebhookConfig{
+				BatchSize:    10,
+				Lease:        time.Minute,
+				Timeout:      time.Second,
+				MaxAttempts:  3,
+				DisableAfter: tt.disableAfter,
+			})
+
+			if err := publisher.Publish(ctx, domain.Event{Type: domain.EventSessionCreated, UserId: ksuid.New()}); err != nil {
+				t.Fatalf("error publishing event: %s", err.Error())
+			}
+
+			for i := 0; i < 5; i++ {
+				if err := dispatcher.Dispatch(ctx); err != nil {
+					t.Fatalf("error dispatching deliveries: %s", err.Error())
+				}
+			}
+
+			first := int32(10)
+
+			deliveries, _, err := repos.WebhookDelivery.GetList(ctx, failing.Id, domain.SortOptions{First: &first})
+			if err != nil {
+				t.Fatalf("error getting deliveries: %s", err.Error())
+			}
+
+			if len(deliveries) != 1 || deliveries[0].Status != domain.WebhookDeliveryFailed ||
+				deliveries[0].Attempts != tt.wantAttempts || deliveries[0].ResponseCode != http.StatusInternalServerError ||
+				deliveries[0].LastError == "" {
+				t.Errorf("error deliveries log: got %+v", deliveries)
+			}
+
+			got, err := repos.Webhook.Get(ctx, failing.Id)
+			if err != nil {
+				t.Fatalf("error getting webhook: %s", err.Error())
+			}
+
+			if got.Enabled != tt.wantEnabled || got.Failures != tt.wantAttempts || got.DisabledAt.IsZero() == !tt.wantEnabled {
+				t.Errorf("error webhook state: got %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/durudex/durudex-auth-service/internal/domain"
+	"github.com/durudex/durudex-auth-service/internal/event"
+	"github.com/durudex/durudex-auth-service/internal/repository/postgres"
+	sig "github.com/durudex/durudex-auth-service/pkg/webhook"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Auth domain events publisher staging events of the webhook deliveries, deliveries for the
+// subscribed webhooks are created and sent by the webhook dispatcher.
+type Publisher struct{ deliveries postgres.WebhookDelivery }
+
+// Creating a new webhook auth domain events publisher.
+func NewPublisher(deliveries postgres.WebhookDelivery) *Publisher {
+	return &Publisher{deliveries: deliveries}
+}
+
+// Staging auth domain events of the webhook deliveries.
+func (p *Publisher) Publish(ctx context.Context, events ...domain.Event) error {
+	staged, err := newEvents(events...)
+	if err != nil {
+		return err
+	}
+
+	return p.deliveries.CreateEvents(ctx, staged...)
+}
+
+// Staging auth domain events of the webhook deliveries in the outbox, nothing is published
+// after the commit.
+func (p *Publisher) Stage(_ context.Context, outbox *domain.Outbox, events ...domain.Event) (event.Publisher, error) {
+	staged, err := newEvents(events...)
+	if err != nil {
+		return nil, err
+	}
+
+	outbox.Webhooks = append(outbox.Webhooks, staged...)
+
+	return event.Staged, nil
+}
+
+// Creating new staged auth domain events of the webhook deliveries.
+func newEvents(events ...domain.Event) ([]domain.WebhookEvent, error) {
+	staged := make([]domain.WebhookEvent, len(events))
+
+	for i, e := range events {
+		e, err := event.Prepare(e)
+		if err != nil {
+			return nil, err
+		}
+
+		payload, err := json.Marshal(newPayload(e))
+		if err != nil {
+			return nil, err
+		}
+
+		staged[i] = domain.WebhookEvent{Id: e.Id, Type: e.Type, Payload: payload}
+	}
+
+	return staged, nil
+}
+
+// Creating a new webhook request body of the auth domain event.
+func newPayload(e domain.Event) sig.Payload {
+	payload := sig.Payload{
+		Id:         e.Id.String(),
+		Type:       string(e.Type),
+		Version:    domain.EventVersion,
+		OccurredAt: e.OccurredAt.UTC(),
+		Data: sig.PayloadData{
+			Ip:         e.Ip,
+			UserAgent:  e.UserAgent,
+			DeviceType: e.DeviceType,
+			DeviceName: e.DeviceName,
+			Method:     e.Method,
+		},
+	}
+
+	if e.UserId != ksuid.Nil {
+		payload.Data.UserId = e.UserId.String()
+	}
+
+	if e.SessionId != ksuid.Nil {
+		payload.Data.SessionId = e.SessionId.String()
+	}
+
+	return payload
+}
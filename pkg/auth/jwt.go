@@ -14,22 +14,174 @@
  * You should have received a copy of the GNU Affero General Public License
  * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
  */
-
 package auth
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 )
 
-// Generating a new jwt access token.
-func GenerateAccessToken(subject, signingKey string, ttl time.Duration) (string, error) {
+// User authentication level, stored in the access token "acr" claim.
+type Level int
+
+// User authentication levels.
+const (
+	// Session created without user authentication.
+	LevelNone Level = iota
+	// User authenticated with one factor.
+	LevelSingleFactor
+	// User authenticated with two factors.
+	LevelMultiFactor
+)
+
+// User authentication methods, stored in the access token "amr" claim.
+const (
+	// Password authentication.
+	MethodPassword string = "pwd"
+	// One-time email verification code authentication.
+	MethodOTP string = "otp"
+)
+
+// Access token validation errors.
+var (
+	// Token authentication level is lower than required.
+	ErrInsufficientLevel = errors.New("insufficient authentication level")
+	// Token authentication is older than the maximum age.
+	ErrAuthenticationExpired = errors.New("authentication is too old")
+)
+
+// User authentication context of the access token.
+type Authentication struct {
+	// Authentication level.
+	Level Level
+	// Authentication methods.
+	Methods []string
+	// Authentication time.
+	Time time.Time
+}
+
+// Getting the user authentication with the methods, level is the number of methods.
+func Authenticated(methods ...string) Authentication {
+	level := LevelMultiFactor
+	if len(methods) < int(LevelMultiFactor) {
+		level = Level(len(methods))
+	}
+
+	return Authentication{Level: level, Methods: methods, Time: time.Now()}
+}
+
+// Access token claims.
+type Claims struct {
+	jwt.StandardClaims
+	// Authentication context class reference, the authentication level.
+	ACR string `json:"acr,omitempty"`
+	// Authentication methods references.
+	AMR []string `json:"amr,omitempty"`
+	// Authentication time in unix seconds.
+	AuthTime int64 `json:"auth_time,omitempty"`
+}
+
+// Getting the user authentication of the token claims. Tokens without the authentication
+// context have no level.
+func (c *Claims) Authentication() Authentication {
+	authn := Authentication{Methods: c.AMR}
+
+	if level, err := strconv.Atoi(c.ACR); err == nil {
+		authn.Level = Level(level)
+	}
+
+	if c.AuthTime != 0 {
+		authn.Time = time.Unix(c.AuthTime, 0)
+	}
+
+	return authn
+}
+
+// Generating a new jwt access token with the user authentication context.
+func GenerateAccessToken(subject, signingKey string, ttl time.Duration, authn Authentication) (string, error) {
+	claims := Claims{
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(ttl).Unix(),
+			Subject:   subject,
+		},
+		ACR: strconv.Itoa(int(authn.Level)),
+		AMR: authn.Methods,
+	}
+
+	if !authn.Time.IsZero() {
+		claims.AuthTime = authn.Time.Unix()
+	}
+
 	// Generating a new jwt token with claims.
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
-		ExpiresAt: time.Now().Add(ttl).Unix(),
-		Subject:   subject,
-	})
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	return token.SignedString([]byte(signingKey))
 }
+
+// Access token validation options.
+type validateOptions struct {
+	level  Level
+	maxAge time.Duration
+}
+
+// Access token validation option.
+type ValidateOption func(*validateOptions)
+
+// Requiring the minimum authentication level.
+func WithMinLevel(level Level) ValidateOption {
+	return func(o *validateOptions) { o.level = level }
+}
+
+// Requiring the authentication not older than the maximum age.
+func WithMaxAge(age time.Duration) ValidateOption {
+	return func(o *validateOptions) { o.maxAge = age }
+}
+
+// Validating a jwt access token and getting the token subject.
+func ValidateAccessToken(token, signingKey string, opts ...ValidateOption) (string, error) {
+	claims, err := ParseAccessToken(token, signingKey)
+	if err != nil {
+		return "", err
+	}
+
+	var options validateOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	authn := claims.Authentication()
+
+	// Checking the authentication level.
+	if authn.Level < options.level {
+		return "", ErrInsufficientLevel
+	}
+
+	// Checking the authentication age, tokens without the authentication time are too old.
+	if options.maxAge > 0 && (authn.Time.IsZero() || time.Since(authn.Time) > options.maxAge) {
+		return "", ErrAuthenticationExpired
+	}
+
+	return claims.Subject, nil
+}
+
+// Parsing and verifying a jwt access token claims.
+func ParseAccessToken(token, signingKey string) (*Claims, error) {
+	var claims Claims
+
+	// Parsing and verifying jwt token with claims.
+	if _, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+
+		return []byte(signingKey), nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &claims, nil
+}
@@ -18,6 +18,8 @@
 package auth_test
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 
@@ -53,7 +55,7 @@ func Test_GenerateAccessToken(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Generate a new jwt access token.
-			got, err := auth.GenerateAccessToken(tt.args.subject, tt.args.signingKey, tt.args.ttl)
+			got, err := auth.GenerateAccessToken(tt.args.subject, tt.args.signingKey, tt.args.ttl, auth.Authenticated(auth.MethodPassword))
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("error generating access token: %s", err)
 			}
@@ -65,3 +67,150 @@ func Test_GenerateAccessToken(t *testing.T) {
 		})
 	}
 }
+
+// Testing validating a jwt access token.
+func Test_ValidateAccessToken(t *testing.T) {
+	// Testing args.
+	type args struct {
+		subject    string
+		signingKey string
+		ttl        time.Duration
+		authn      auth.Authentication
+		validKey   string
+		opts       []auth.ValidateOption
+	}
+
+	password := auth.Authenticated(auth.MethodPassword)
+
+	// Tests structures.
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+		// Expected validation error.
+		is error
+	}{
+		{
+			name: "OK",
+			args: args{subject: "1", signingKey: "secret-key", ttl: time.Hour, authn: password, validKey: "secret-key"},
+			want: "1",
+		},
+		{
+			name:    "Invalid signing key",
+			args:    args{subject: "1", signingKey: "secret-key", ttl: time.Hour, authn: password, validKey: "other-key"},
+			wantErr: true,
+		},
+		{
+			name:    "Expired",
+			args:    args{subject: "1", signingKey: "secret-key", ttl: -time.Hour, authn: password, validKey: "secret-key"},
+			wantErr: true,
+		},
+		{
+			name: "Minimum level",
+			args: args{
+				subject:    "1",
+				signingKey: "secret-key",
+				ttl:        time.Hour,
+				authn:      auth.Authenticated(auth.MethodPassword, auth.MethodOTP),
+				validKey:   "secret-key",
+				opts:       []auth.ValidateOption{auth.WithMinLevel(auth.LevelMultiFactor)},
+			},
+			want: "1",
+		},
+		{
+			name: "Insufficient level",
+			args: args{
+				subject:    "1",
+				signingKey: "secret-key",
+				ttl:        time.Hour,
+				authn:      password,
+				validKey:   "secret-key",
+				opts:       []auth.ValidateOption{auth.WithMinLevel(auth.LevelMultiFactor)},
+			},
+			wantErr: true,
+			is:      auth.ErrInsufficientLevel,
+		},
+		{
+			name: "Maximum age",
+			args: args{
+				subject:    "1",
+				signingKey: "secret-key",
+				ttl:        time.Hour,
+				authn:      password,
+				validKey:   "secret-key",
+				opts:       []auth.ValidateOption{auth.WithMaxAge(time.Minute)},
+			},
+			want: "1",
+		},
+		{
+			name: "Authentication too old",
+			args: args{
+				subject:    "1",
+				signingKey: "secret-key",
+				ttl:        time.Hour,
+				authn:      auth.Authentication{Level: auth.LevelSingleFactor, Time: time.Now().Add(-time.Hour)},
+				validKey:   "secret-key",
+				opts:       []auth.ValidateOption{auth.WithMaxAge(time.Minute)},
+			},
+			wantErr: true,
+			is:      auth.ErrAuthenticationExpired,
+		},
+		{
+			name: "Without authentication time",
+			args: args{
+				subject:    "1",
+				signingKey: "secret-key",
+				ttl:        time.Hour,
+				validKey:   "secret-key",
+				opts:       []auth.ValidateOption{auth.WithMaxAge(time.Minute)},
+			},
+			wantErr: true,
+			is:      auth.ErrAuthenticationExpired,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Generate a new jwt access token.
+			token, err := auth.GenerateAccessToken(tt.args.subject, tt.args.signingKey, tt.args.ttl, tt.args.authn)
+			if err != nil {
+				t.Fatalf("error generating access token: %s", err)
+			}
+
+			// Validating jwt access token.
+			got, err := auth.ValidateAccessToken(token, tt.args.validKey, tt.args.opts...)
+			if (err != nil) != tt.wantErr || (tt.is != nil && !errors.Is(err, tt.is)) {
+				t.Fatalf("error validating access token: %s", err)
+			}
+
+			// Check for similarity of subject.
+			if got != tt.want {
+				t.Errorf("error subject are not similar: %s", got)
+			}
+		})
+	}
+}
+
+// Testing parsing the access token authentication context.
+func Test_ParseAccessToken(t *testing.T) {
+	authn := auth.Authenticated(auth.MethodPassword, auth.MethodOTP)
+
+	token, err := auth.GenerateAccessToken("1", "secret-key", time.Hour, authn)
+	if err != nil {
+		t.Fatalf("error generating access token: %s", err)
+	}
+
+	claims, err := auth.ParseAccessToken(token, "secret-key")
+	if err != nil {
+		t.Fatalf("error parsing access token: %s", err)
+	}
+
+	got := claims.Authentication()
+
+	if got.Level != auth.LevelMultiFactor || !reflect.DeepEqual(got.Methods, authn.Methods) ||
+		got.Time.Unix() != authn.Time.Unix() {
+		t.Errorf("error authentication: got %+v, want %+v", got, authn)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgtype"
+	"github.com/segmentio/ksuid"
+)
+
+// KSUID postgres BYTEA type. Nil KSUID is encoded as NULL.
+type KSUID ksuid.KSUID
+
+// Encoding KSUID in the BYTEA binary format.
+func (k KSUID) EncodeBinary(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	return k.bytea().EncodeBinary(ci, buf)
+}
+
+// Encoding KSUID in the BYTEA text format.
+func (k KSUID) EncodeText(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	return k.bytea().EncodeText(ci, buf)
+}
+
+// Decoding KSUID from the BYTEA binary format.
+func (k *KSUID) DecodeBinary(ci *pgtype.ConnInfo, src []byte) error {
+	var b pgtype.Bytea
+
+	if err := b.DecodeBinary(ci, src); err != nil {
+		return err
+	}
+
+	return k.set(b)
+}
+
+// Decoding KSUID from the BYTEA text format.
+func (k *KSUID) DecodeText(ci *pgtype.ConnInfo, src []byte) error {
+	var b pgtype.Bytea
+
+	if err := b.DecodeText(ci, src); err != nil {
+		return err
+	}
+
+	return k.set(b)
+}
+
+// Scanning KSUID from the database/sql value.
+func (k *KSUID) Scan(src interface{}) error {
+	var b pgtype.Bytea
+
+	if err := b.Scan(src); err != nil {
+		return err
+	}
+
+	return k.set(b)
+}
+
+// Getting KSUID BYTEA value.
+func (k KSUID) bytea() pgtype.Bytea {
+	if ksuid.KSUID(k).IsNil() {
+		return pgtype.Bytea{Status: pgtype.Null}
+	}
+
+	return pgtype.Bytea{Bytes: k[:], Status: pgtype.Present}
+}
+
+// Setting KSUID from the BYTEA value.
+func (k *KSUID) set(b pgtype.Bytea) error {
+	if b.Status != pgtype.Present {
+		*k = KSUID(ksuid.Nil)
+		return nil
+	}
+
+	id, err := ksuid.FromBytes(b.Bytes)
+	if err != nil {
+		return fmt.Errorf("invalid KSUID bytes: %w", err)
+	}
+
+	*k = KSUID(id)
+
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/durudex/durudex-auth-service/pkg/database/postgres"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Testing KSUID BYTEA encoding.
+func TestKSUID(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name string
+		id   ksuid.KSUID
+	}{
+		{name: "OK", id: ksuid.New()},
+		{name: "Nil", id: ksuid.Nil},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			binary, err := postgres.KSUID(tt.id).EncodeBinary(nil, nil)
+			if err != nil {
+				t.Fatalf("error encoding binary: %s", err.Error())
+			}
+
+			text, err := postgres.KSUID(tt.id).EncodeText(nil, nil)
+			if err != nil {
+				t.Fatalf("error encoding text: %s", err.Error())
+			}
+
+			// Nil KSUID is encoded as NULL.
+			if tt.id.IsNil() != (binary == nil) || tt.id.IsNil() != (text == nil) {
+				t.Fatalf("error encoding NULL: got %v, %v", binary, text)
+			}
+
+			var got postgres.KSUID
+
+			if err := got.DecodeBinary(nil, binary); err != nil || ksuid.KSUID(got) != tt.id {
+				t.Errorf("error decoding binary: got %s, %v", ksuid.KSUID(got), err)
+			}
+
+			if err := got.DecodeText(nil, text); err != nil || ksuid.KSUID(got) != tt.id {
+				t.Errorf("error decoding text: got %s, %v", ksuid.KSUID(got), err)
+			}
+		})
+	}
+
+	// Decoding invalid length.
+	var got postgres.KSUID
+
+	if err := got.DecodeBinary(nil, []byte{1, 2, 3}); err == nil {
+		t.Error("error decoding invalid length: got nil error")
+	}
+}
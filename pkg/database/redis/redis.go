@@ -0,0 +1,57 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package redis
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+)
+
+// Redis config structure.
+type RedisConfig struct {
+	URL      string
+	PoolSize int
+}
+
+// Creating a new redis client.
+func NewClient(cfg *RedisConfig) (*redis.Client, error) {
+	log.Debug().Msg("Creating a new redis client")
+
+	// Parsing redis url.
+	options, err := redis.ParseURL(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set redis pool size.
+	if cfg.PoolSize != 0 {
+		options.PoolSize = cfg.PoolSize
+	}
+
+	client := redis.NewClient(options)
+
+	// Ping a redis connection.
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return client, nil
+}
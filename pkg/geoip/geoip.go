@@ -0,0 +1,115 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package geoip
+
+import (
+	"math"
+	"net"
+
+	"github.com/oschwald/geoip2-golang"
+)
+
+// Names language of the locations.
+const language = "en"
+
+// Ip address location structure.
+type Location struct {
+	// ISO 3166-1 alpha-2 country code.
+	Country string
+	// English city name.
+	City string
+	// Approximate latitude and longitude of the ip address.
+	Latitude, Longitude float64
+}
+
+// Checking is the location coordinates known.
+func (l Location) HasCoordinates() bool {
+	return l.Latitude != 0 || l.Longitude != 0
+}
+
+// Ip address locator interface.
+type Locator interface {
+	// Looking up the ip address location. Unknown addresses have an empty location.
+	Locate(ip string) (Location, error)
+}
+
+// Offline locator reading a local MaxMind-format city database.
+type Reader struct{ db *geoip2.Reader }
+
+// Opening a MaxMind-format city database file.
+func Open(path string) (*Reader, error) {
+	db, err := geoip2.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{db: db}, nil
+}
+
+// Looking up the ip address location.
+func (r *Reader) Locate(ip string) (Location, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return Location{}, nil
+	}
+
+	city, err := r.db.City(addr)
+	if err != nil {
+		return Location{}, err
+	}
+
+	return Location{
+		Country:   city.Country.IsoCode,
+		City:      city.City.Names[language],
+		Latitude:  city.Location.Latitude,
+		Longitude: city.Location.Longitude,
+	}, nil
+}
+
+// Closing the database.
+func (r *Reader) Close() error {
+	return r.db.Close()
+}
+
+// Locator without a database, all locations are empty.
+type Nop struct{}
+
+// Looking up the ip address location.
+func (Nop) Locate(string) (Location, error) {
+	return Location{}, nil
+}
+
+// Locator with the static ip address locations, unknown addresses have an empty location.
+type Static map[string]Location
+
+// Looking up the ip address location.
+func (s Static) Locate(ip string) (Location, error) {
+	return s[ip], nil
+}
+
+// Mean Earth radius in kilometers.
+const earthRadius = 6371.0
+
+// Getting the great-circle distance between the location coordinates in kilometers.
+func Distance(a, b Location) float64 {
+	lat1, lat2 := a.Latitude*math.Pi/180, b.Latitude*math.Pi/180
+	dLat, dLon := lat2-lat1, (b.Longitude-a.Longitude)*math.Pi/180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
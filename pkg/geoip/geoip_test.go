@@ -0,0 +1,174 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package geoip_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/durudex/durudex-auth-service/pkg/geoip"
+)
+
+// Encoding a MaxMind DB data field with the type and size.
+func field(typ, size int, data ...byte) []byte {
+	if typ > 7 {
+		return append([]byte{byte(size), byte(typ - 7)}, data...)
+	}
+
+	return append([]byte{byte(typ<<5 | size)}, data...)
+}
+
+// Encoding a MaxMind DB string.
+func str(s string) []byte { return field(2, len(s), []byte(s)...) }
+
+// Encoding a MaxMind DB unsigned integer.
+func unsigned(typ int, v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+
+	b = bytes.TrimLeft(b, "\x00")
+
+	return field(typ, len(b), b...)
+}
+
+// Encoding a MaxMind DB double.
+func double(v float64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, math.Float64bits(v))
+
+	return field(3, 8, b...)
+}
+
+// Encoding a MaxMind DB map with the ordered keys.
+func dict(kv ...[]byte) []byte {
+	b := field(7, len(kv)/2)
+	for _, v := range kv {
+		b = append(b, v...)
+	}
+
+	return b
+}
+
+// Building an IPv4 MaxMind DB with a single network city.
+func newDatabase(t *testing.T, network netip.Prefix, location geoip.Location) []byte {
+	t.Helper()
+
+	const recordSize = 24
+
+	nodes := network.Bits()
+	addr := network.Addr().As4()
+
+	var db []byte
+
+	// Search tree with a node per network bit, other branches have no data.
+	for i := 0; i < nodes; i++ {
+		next := uint32(i + 1)
+		if i == nodes-1 {
+			next = uint32(nodes) + 16
+		}
+
+		records := [2]uint32{uint32(nodes), uint32(nodes)}
+		records[addr[i/8]>>(7-i%8)&1] = next
+
+		for _, r := range records {
+			db = append(db, byte(r>>16), byte(r>>8), byte(r))
+		}
+	}
+
+	db = append(db, make([]byte, 16)...)
+
+	// Data section with the city record.
+	db = append(db, dict(
+		str("city"), dict(str("names"), dict(str("en"), str(location.City))),
+		str("country"), dict(str("iso_code"), str(location.Country)),
+		str("location"), dict(str("latitude"), double(location.Latitude), str("longitude"), double(location.Longitude)),
+	)...)
+
+	// Metadata section.
+	db = append(db, "\xAB\xCD\xEFMaxMind.com"...)
+	db = append(db, dict(
+		str("binary_format_major_version"), unsigned(5, 2),
+		str("binary_format_minor_version"), unsigned(5, 0),
+		str("build_epoch"), unsigned(9, 1660000000),
+		str("database_type"), str("GeoLite2-City"),
+		str("description"), dict(str("en"), str("Test database")),
+		str("ip_version"), unsigned(5, 4),
+		str("languages"), field(11, 1, str("en")...),
+		str("node_count"), unsigned(6, uint64(nodes)),
+		str("record_size"), unsigned(5, recordSize),
+	)...)
+
+	return db
+}
+
+// Testing looking up ip address locations.
+func TestReader_Locate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "city.mmdb")
+
+	london := geoip.Location{Country: "GB", City: "London", Latitude: 51.5142, Longitude: -0.0931}
+
+	if err := os.WriteFile(path, newDatabase(t, netip.MustParsePrefix("81.2.69.0/24"), london), 0o600); err != nil {
+		t.Fatalf("error writing database: %s", err.Error())
+	}
+
+	reader, err := geoip.Open(path)
+	if err != nil {
+		t.Fatalf("error opening database: %s", err.Error())
+	}
+
+	t.Cleanup(func() { reader.Close() })
+
+	// Tests structures.
+	tests := []struct {
+		name string
+		ip   string
+		want geoip.Location
+	}{
+		{
+			name: "Known network",
+			ip:   "81.2.69.142",
+			want: london,
+		},
+		{
+			name: "Unknown network",
+			ip:   "81.2.70.1",
+		},
+		{
+			name: "Invalid ip address",
+			ip:   "invalid",
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := reader.Locate(tt.ip)
+			if err != nil {
+				t.Fatalf("error looking up location: %s", err.Error())
+			}
+
+			if got != tt.want {
+				t.Errorf("error location: got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
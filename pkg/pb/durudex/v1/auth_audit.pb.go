@@ -0,0 +1,764 @@
+// Copyright © 2022 Durudex
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: durudex/v1/auth_audit.proto
+
+package durudexv1
+
+import (
+	pbtype "github.com/durudex/go-protobuf-type/pbtype"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Auth event kind.
+type AuthEventKind int32
+
+const (
+	// Any event kind.
+	AuthEventKind_AUTH_EVENT_KIND_UNSPECIFIED AuthEventKind = 0
+	// User sign up.
+	AuthEventKind_AUTH_EVENT_KIND_SIGN_UP AuthEventKind = 1
+	// User sign in.
+	AuthEventKind_AUTH_EVENT_KIND_SIGN_IN AuthEventKind = 2
+	// Access token refresh.
+	AuthEventKind_AUTH_EVENT_KIND_TOKEN_REFRESH AuthEventKind = 3
+	// User session revocation.
+	AuthEventKind_AUTH_EVENT_KIND_SESSION_REVOKE AuthEventKind = 4
+	// User session authentication step up.
+	AuthEventKind_AUTH_EVENT_KIND_STEP_UP AuthEventKind = 5
+	// Service call authorization.
+	AuthEventKind_AUTH_EVENT_KIND_AUTHZ AuthEventKind = 6
+)
+
+// Enum value maps for AuthEventKind.
+var (
+	AuthEventKind_name = map[int32]string{
+		0: "AUTH_EVENT_KIND_UNSPECIFIED",
+		1: "AUTH_EVENT_KIND_SIGN_UP",
+		2: "AUTH_EVENT_KIND_SIGN_IN",
+		3: "AUTH_EVENT_KIND_TOKEN_REFRESH",
+		4: "AUTH_EVENT_KIND_SESSION_REVOKE",
+		5: "AUTH_EVENT_KIND_STEP_UP",
+		6: "AUTH_EVENT_KIND_AUTHZ",
+	}
+	AuthEventKind_value = map[string]int32{
+		"AUTH_EVENT_KIND_UNSPECIFIED":    0,
+		"AUTH_EVENT_KIND_SIGN_UP":        1,
+		"AUTH_EVENT_KIND_SIGN_IN":        2,
+		"AUTH_EVENT_KIND_TOKEN_REFRESH":  3,
+		"AUTH_EVENT_KIND_SESSION_REVOKE": 4,
+		"AUTH_EVENT_KIND_STEP_UP":        5,
+		"AUTH_EVENT_KIND_AUTHZ":          6,
+	}
+)
+
+func (x AuthEventKind) Enum() *AuthEventKind {
+	p := new(AuthEventKind)
+	*p = x
+	return p
+}
+
+func (x AuthEventKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuthEventKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_durudex_v1_auth_audit_proto_enumTypes[0].Descriptor()
+}
+
+func (AuthEventKind) Type() protoreflect.EnumType {
+	return &file_durudex_v1_auth_audit_proto_enumTypes[0]
+}
+
+func (x AuthEventKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuthEventKind.Descriptor instead.
+func (AuthEventKind) EnumDescriptor() ([]byte, []int) {
+	return file_durudex_v1_auth_audit_proto_rawDescGZIP(), []int{0}
+}
+
+// Auth event outcome.
+type AuthEventOutcome int32
+
+const (
+	// Any event outcome.
+	AuthEventOutcome_AUTH_EVENT_OUTCOME_UNSPECIFIED AuthEventOutcome = 0
+	// Succeeded action.
+	AuthEventOutcome_AUTH_EVENT_OUTCOME_SUCCESS AuthEventOutcome = 1
+	// Failed action.
+	AuthEventOutcome_AUTH_EVENT_OUTCOME_FAILURE AuthEventOutcome = 2
+)
+
+// Enum value maps for AuthEventOutcome.
+var (
+	AuthEventOutcome_name = map[int32]string{
+		0: "AUTH_EVENT_OUTCOME_UNSPECIFIED",
+		1: "AUTH_EVENT_OUTCOME_SUCCESS",
+		2: "AUTH_EVENT_OUTCOME_FAILURE",
+	}
+	AuthEventOutcome_value = map[string]int32{
+		"AUTH_EVENT_OUTCOME_UNSPECIFIED": 0,
+		"AUTH_EVENT_OUTCOME_SUCCESS":     1,
+		"AUTH_EVENT_OUTCOME_FAILURE":     2,
+	}
+)
+
+func (x AuthEventOutcome) Enum() *AuthEventOutcome {
+	p := new(AuthEventOutcome)
+	*p = x
+	return p
+}
+
+func (x AuthEventOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuthEventOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_durudex_v1_auth_audit_proto_enumTypes[1].Descriptor()
+}
+
+func (AuthEventOutcome) Type() protoreflect.EnumType {
+	return &file_durudex_v1_auth_audit_proto_enumTypes[1]
+}
+
+func (x AuthEventOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuthEventOutcome.Descriptor instead.
+func (AuthEventOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_durudex_v1_auth_audit_proto_rawDescGZIP(), []int{1}
+}
+
+// Auth audit event message.
+type AuthEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Event id.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Event kind.
+	Kind AuthEventKind `protobuf:"varint,2,opt,name=kind,proto3,enum=durudex.v1.AuthEventKind" json:"kind,omitempty"`
+	// Identity performed the action.
+	Actor string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	// User id or username the event is about.
+	Subject string `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	// User session id.
+	SessionId []byte `protobuf:"bytes,5,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Client ip address.
+	Ip string `protobuf:"bytes,6,opt,name=ip,proto3" json:"ip,omitempty"`
+	// Client user agent.
+	UserAgent string `protobuf:"bytes,7,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	// Event outcome.
+	Outcome AuthEventOutcome `protobuf:"varint,8,opt,name=outcome,proto3,enum=durudex.v1.AuthEventOutcome" json:"outcome,omitempty"`
+	// Failure reason.
+	Reason string `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Event created at.
+	CreatedAt *pbtype.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *AuthEvent) Reset() {
+	*x = AuthEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_durudex_v1_auth_audit_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthEvent) ProtoMessage() {}
+
+func (x *AuthEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_durudex_v1_auth_audit_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthEvent.ProtoReflect.Descriptor instead.
+func (*AuthEvent) Descriptor() ([]byte, []int) {
+	return file_durudex_v1_auth_audit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuthEvent) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *AuthEvent) GetKind() AuthEventKind {
+	if x != nil {
+		return x.Kind
+	}
+	return AuthEventKind_AUTH_EVENT_KIND_UNSPECIFIED
+}
+
+func (x *AuthEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuthEvent) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *AuthEvent) GetSessionId() []byte {
+	if x != nil {
+		return x.SessionId
+	}
+	return nil
+}
+
+func (x *AuthEvent) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *AuthEvent) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *AuthEvent) GetOutcome() AuthEventOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return AuthEventOutcome_AUTH_EVENT_OUTCOME_UNSPECIFIED
+}
+
+func (x *AuthEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AuthEvent) GetCreatedAt() *pbtype.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Auth audit event connection edge message.
+type AuthEventEdge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Event cursor.
+	Cursor []byte `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Auth audit event.
+	Node *AuthEvent `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+}
+
+func (x *AuthEventEdge) Reset() {
+	*x = AuthEventEdge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_durudex_v1_auth_audit_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthEventEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthEventEdge) ProtoMessage() {}
+
+func (x *AuthEventEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_durudex_v1_auth_audit_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthEventEdge.ProtoReflect.Descriptor instead.
+func (*AuthEventEdge) Descriptor() ([]byte, []int) {
+	return file_durudex_v1_auth_audit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AuthEventEdge) GetCursor() []byte {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
+}
+
+func (x *AuthEventEdge) GetNode() *AuthEvent {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+// Auth audit events filter message.
+type AuthEventFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// User id or username the event is about.
+	Subject *string `protobuf:"bytes,1,opt,name=subject,proto3,oneof" json:"subject,omitempty"`
+	// Event kind.
+	Kind AuthEventKind `protobuf:"varint,2,opt,name=kind,proto3,enum=durudex.v1.AuthEventKind" json:"kind,omitempty"`
+	// Event outcome.
+	Outcome AuthEventOutcome `protobuf:"varint,3,opt,name=outcome,proto3,enum=durudex.v1.AuthEventOutcome" json:"outcome,omitempty"`
+}
+
+func (x *AuthEventFilter) Reset() {
+	*x = AuthEventFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_durudex_v1_auth_audit_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthEventFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthEventFilter) ProtoMessage() {}
+
+func (x *AuthEventFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_durudex_v1_auth_audit_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthEventFilter.ProtoReflect.Descriptor instead.
+func (*AuthEventFilter) Descriptor() ([]byte, []int) {
+	return file_durudex_v1_auth_audit_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AuthEventFilter) GetSubject() string {
+	if x != nil && x.Subject != nil {
+		return *x.Subject
+	}
+	return ""
+}
+
+func (x *AuthEventFilter) GetKind() AuthEventKind {
+	if x != nil {
+		return x.Kind
+	}
+	return AuthEventKind_AUTH_EVENT_KIND_UNSPECIFIED
+}
+
+func (x *AuthEventFilter) GetOutcome() AuthEventOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return AuthEventOutcome_AUTH_EVENT_OUTCOME_UNSPECIFIED
+}
+
+// Get Auth Events Request.
+type GetAuthEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Query sort options.
+	SortOptions *pbtype.SortOptions `protobuf:"bytes,1,opt,name=sort_options,json=sortOptions,proto3" json:"sort_options,omitempty"`
+	// Query filter.
+	Filter *AuthEventFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *GetAuthEventsRequest) Reset() {
+	*x = GetAuthEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_durudex_v1_auth_audit_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAuthEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthEventsRequest) ProtoMessage() {}
+
+func (x *GetAuthEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_durudex_v1_auth_audit_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthEventsRequest.ProtoReflect.Descriptor instead.
+func (*GetAuthEventsRequest) Descriptor() ([]byte, []int) {
+	return file_durudex_v1_auth_audit_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAuthEventsRequest) GetSortOptions() *pbtype.SortOptions {
+	if x != nil {
+		return x.SortOptions
+	}
+	return nil
+}
+
+func (x *GetAuthEventsRequest) GetFilter() *AuthEventFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// Get Auth Events Response.
+type GetAuthEventsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Auth audit event edges.
+	Edges []*AuthEventEdge `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	// Connection page info.
+	PageInfo *PageInfo `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+}
+
+func (x *GetAuthEventsResponse) Reset() {
+	*x = GetAuthEventsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_durudex_v1_auth_audit_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAuthEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthEventsResponse) ProtoMessage() {}
+
+func (x *GetAuthEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_durudex_v1_auth_audit_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthEventsResponse.ProtoReflect.Descriptor instead.
+func (*GetAuthEventsResponse) Descriptor() ([]byte, []int) {
+	return file_durudex_v1_auth_audit_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetAuthEventsResponse) GetEdges() []*AuthEventEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *GetAuthEventsResponse) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+var File_durudex_v1_auth_audit_proto protoreflect.FileDescriptor
+
+var file_durudex_v1_auth_audit_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x75, 0x74,
+	0x68, 0x5f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x64,
+	0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x64, 0x75, 0x72, 0x75, 0x64,
+	0x65, 0x78, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78,
+	0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1d, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78,
+	0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd0, 0x02, 0x0a, 0x09, 0x41, 0x75, 0x74, 0x68, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x2d, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x19, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x75, 0x74, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x41, 0x67, 0x65, 0x6e,
+	0x74, 0x12, 0x36, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x75, 0x74, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65,
+	0x52, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x12, 0x36, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x52, 0x0a, 0x0d, 0x41, 0x75, 0x74,
+	0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x45, 0x64, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x12, 0x29, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75,
+	0x74, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0xa3, 0x01,
+	0x0a, 0x0f, 0x41, 0x75, 0x74, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x12, 0x1d, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x88, 0x01, 0x01,
+	0x12, 0x2d, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19,
+	0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
+	0x36, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1c, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75,
+	0x74, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x52, 0x07,
+	0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x73, 0x75, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x22, 0x89, 0x01, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x68, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0c,
+	0x73, 0x6f, 0x72, 0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0b, 0x73,
+	0x6f, 0x72, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x75, 0x72,
+	0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22,
+	0x7b, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x65, 0x64, 0x67, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65,
+	0x78, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x45, 0x64,
+	0x67, 0x65, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64,
+	0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x2a, 0xe9, 0x01, 0x0a,
+	0x0d, 0x41, 0x75, 0x74, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1f,
+	0x0a, 0x1b, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4b, 0x49, 0x4e,
+	0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x1b, 0x0a, 0x17, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4b, 0x49,
+	0x4e, 0x44, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x5f, 0x55, 0x50, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17,
+	0x41, 0x55, 0x54, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f,
+	0x53, 0x49, 0x47, 0x4e, 0x5f, 0x49, 0x4e, 0x10, 0x02, 0x12, 0x21, 0x0a, 0x1d, 0x41, 0x55, 0x54,
+	0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x54, 0x4f, 0x4b,
+	0x45, 0x4e, 0x5f, 0x52, 0x45, 0x46, 0x52, 0x45, 0x53, 0x48, 0x10, 0x03, 0x12, 0x22, 0x0a, 0x1e,
+	0x41, 0x55, 0x54, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f,
+	0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x56, 0x4f, 0x4b, 0x45, 0x10, 0x04,
+	0x12, 0x1b, 0x0a, 0x17, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4b,
+	0x49, 0x4e, 0x44, 0x5f, 0x53, 0x54, 0x45, 0x50, 0x5f, 0x55, 0x50, 0x10, 0x05, 0x12, 0x19, 0x0a,
+	0x15, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4b, 0x49, 0x4e, 0x44,
+	0x5f, 0x41, 0x55, 0x54, 0x48, 0x5a, 0x10, 0x06, 0x2a, 0x76, 0x0a, 0x10, 0x41, 0x75, 0x74, 0x68,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x1e,
+	0x41, 0x55, 0x54, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4f, 0x55, 0x54, 0x43, 0x4f,
+	0x4d, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4f,
+	0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x01,
+	0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4f,
+	0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x10, 0x02,
+	0x32, 0x68, 0x0a, 0x10, 0x41, 0x75, 0x74, 0x68, 0x41, 0x75, 0x64, 0x69, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x54, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x68, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x20, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65,
+	0x78, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x68, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0xb1, 0x01, 0x0a, 0x0e, 0x63,
+	0x6f, 0x6d, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x42, 0x0e, 0x41,
+	0x75, 0x74, 0x68, 0x41, 0x75, 0x64, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a,
+	0x46, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x75, 0x72, 0x75,
+	0x64, 0x65, 0x78, 0x2f, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2d, 0x61, 0x75, 0x74, 0x68,
+	0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f,
+	0x76, 0x31, 0x2f, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2f, 0x76, 0x31, 0x3b, 0x64, 0x75,
+	0x72, 0x75, 0x64, 0x65, 0x78, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x44, 0x58, 0x58, 0xaa, 0x02, 0x0a,
+	0x44, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x0a, 0x44, 0x75, 0x72,
+	0x75, 0x64, 0x65, 0x78, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x16, 0x44, 0x75, 0x72, 0x75, 0x64, 0x65,
+	0x78, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0xea, 0x02, 0x0b, 0x44, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_durudex_v1_auth_audit_proto_rawDescOnce sync.Once
+	file_durudex_v1_auth_audit_proto_rawDescData = file_durudex_v1_auth_audit_proto_rawDesc
+)
+
+func file_durudex_v1_auth_audit_proto_rawDescGZIP() []byte {
+	file_durudex_v1_auth_audit_proto_rawDescOnce.Do(func() {
+		file_durudex_v1_auth_audit_proto_rawDescData = protoimpl.X.CompressGZIP(file_durudex_v1_auth_audit_proto_rawDescData)
+	})
+	return file_durudex_v1_auth_audit_proto_rawDescData
+}
+
+var file_durudex_v1_auth_audit_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_durudex_v1_auth_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_durudex_v1_auth_audit_proto_goTypes = []interface{}{
+	(AuthEventKind)(0),            // 0: durudex.v1.AuthEventKind
+	(AuthEventOutcome)(0),         // 1: durudex.v1.AuthEventOutcome
+	(*AuthEvent)(nil),             // 2: durudex.v1.AuthEvent
+	(*AuthEventEdge)(nil),         // 3: durudex.v1.AuthEventEdge
+	(*AuthEventFilter)(nil),       // 4: durudex.v1.AuthEventFilter
+	(*GetAuthEventsRequest)(nil),  // 5: durudex.v1.GetAuthEventsRequest
+	(*GetAuthEventsResponse)(nil), // 6: durudex.v1.GetAuthEventsResponse
+	(*pbtype.Timestamp)(nil),      // 7: durudex.type.Timestamp
+	(*pbtype.SortOptions)(nil),    // 8: durudex.type.SortOptions
+	(*PageInfo)(nil),              // 9: durudex.v1.PageInfo
+}
+var file_durudex_v1_auth_audit_proto_depIdxs = []int32{
+	0,  // 0: durudex.v1.AuthEvent.kind:type_name -> durudex.v1.AuthEventKind
+	1,  // 1: durudex.v1.AuthEvent.outcome:type_name -> durudex.v1.AuthEventOutcome
+	7,  // 2: durudex.v1.AuthEvent.created_at:type_name -> durudex.type.Timestamp
+	2,  // 3: durudex.v1.AuthEventEdge.node:type_name -> durudex.v1.AuthEvent
+	0,  // 4: durudex.v1.AuthEventFilter.kind:type_name -> durudex.v1.AuthEventKind
+	1,  // 5: durudex.v1.AuthEventFilter.outcome:type_name -> durudex.v1.AuthEventOutcome
+	8,  // 6: durudex.v1.GetAuthEventsRequest.sort_options:type_name -> durudex.type.SortOptions
+	4,  // 7: durudex.v1.GetAuthEventsRequest.filter:type_name -> durudex.v1.AuthEventFilter
+	3,  // 8: durudex.v1.GetAuthEventsResponse.edges:type_name -> durudex.v1.AuthEventEdge
+	9,  // 9: durudex.v1.GetAuthEventsResponse.page_info:type_name -> durudex.v1.PageInfo
+	5,  // 10: durudex.v1.AuthAuditService.GetAuthEvents:input_type -> durudex.v1.GetAuthEventsRequest
+	6,  // 11: durudex.v1.AuthAuditService.GetAuthEvents:output_type -> durudex.v1.GetAuthEventsResponse
+	11, // [11:12] is the sub-list for method output_type
+	10, // [10:11] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_durudex_v1_auth_audit_proto_init() }
+func file_durudex_v1_auth_audit_proto_init() {
+	if File_durudex_v1_auth_audit_proto != nil {
+		return
+	}
+	file_durudex_v1_user_session_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_durudex_v1_auth_audit_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_durudex_v1_auth_audit_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthEventEdge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_durudex_v1_auth_audit_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthEventFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_durudex_v1_auth_audit_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAuthEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_durudex_v1_auth_audit_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAuthEventsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_durudex_v1_auth_audit_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_durudex_v1_auth_audit_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_durudex_v1_auth_audit_proto_goTypes,
+		DependencyIndexes: file_durudex_v1_auth_audit_proto_depIdxs,
+		EnumInfos:         file_durudex_v1_auth_audit_proto_enumTypes,
+		MessageInfos:      file_durudex_v1_auth_audit_proto_msgTypes,
+	}.Build()
+	File_durudex_v1_auth_audit_proto = out.File
+	file_durudex_v1_auth_audit_proto_rawDesc = nil
+	file_durudex_v1_auth_audit_proto_goTypes = nil
+	file_durudex_v1_auth_audit_proto_depIdxs = nil
+}
@@ -0,0 +1,754 @@
+// Copyright © 2022 Durudex
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: durudex/v1/auth_domain_event.proto
+
+package durudexv1
+
+import (
+	pbtype "github.com/durudex/go-protobuf-type/pbtype"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Auth domain event envelope message.
+type AuthDomainEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Event id.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Event type.
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Event schema version.
+	Version int32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// Event occurred at.
+	OccurredAt *pbtype.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Event payload.
+	//
+	// Types that are assignable to Payload:
+	//	*AuthDomainEvent_UserSignedUp
+	//	*AuthDomainEvent_SessionCreated
+	//	*AuthDomainEvent_SessionRefreshed
+	//	*AuthDomainEvent_SessionRevoked
+	//	*AuthDomainEvent_MfaEnrolled
+	Payload isAuthDomainEvent_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *AuthDomainEvent) Reset() {
+	*x = AuthDomainEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_durudex_v1_auth_domain_event_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthDomainEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthDomainEvent) ProtoMessage() {}
+
+func (x *AuthDomainEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_durudex_v1_auth_domain_event_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthDomainEvent.ProtoReflect.Descriptor instead.
+func (*AuthDomainEvent) Descriptor() ([]byte, []int) {
+	return file_durudex_v1_auth_domain_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuthDomainEvent) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *AuthDomainEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AuthDomainEvent) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AuthDomainEvent) GetOccurredAt() *pbtype.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (m *AuthDomainEvent) GetPayload() isAuthDomainEvent_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *AuthDomainEvent) GetUserSignedUp() *UserSignedUp {
+	if x, ok := x.GetPayload().(*AuthDomainEvent_UserSignedUp); ok {
+		return x.UserSignedUp
+	}
+	return nil
+}
+
+func (x *AuthDomainEvent) GetSessionCreated() *SessionCreated {
+	if x, ok := x.GetPayload().(*AuthDomainEvent_SessionCreated); ok {
+		return x.SessionCreated
+	}
+	return nil
+}
+
+func (x *AuthDomainEvent) GetSessionRefreshed() *SessionRefreshed {
+	if x, ok := x.GetPayload().(*AuthDomainEvent_SessionRefreshed); ok {
+		return x.SessionRefreshed
+	}
+	return nil
+}
+
+func (x *AuthDomainEvent) GetSessionRevoked() *SessionRevoked {
+	if x, ok := x.GetPayload().(*AuthDomainEvent_SessionRevoked); ok {
+		return x.SessionRevoked
+	}
+	return nil
+}
+
+func (x *AuthDomainEvent) GetMfaEnrolled() *MFAEnrolled {
+	if x, ok := x.GetPayload().(*AuthDomainEvent_MfaEnrolled); ok {
+		return x.MfaEnrolled
+	}
+	return nil
+}
+
+type isAuthDomainEvent_Payload interface {
+	isAuthDomainEvent_Payload()
+}
+
+type AuthDomainEvent_UserSignedUp struct {
+	// User signed up event.
+	UserSignedUp *UserSignedUp `protobuf:"bytes,5,opt,name=user_signed_up,json=userSignedUp,proto3,oneof"`
+}
+
+type AuthDomainEvent_SessionCreated struct {
+	// User session created event.
+	SessionCreated *SessionCreated `protobuf:"bytes,6,opt,name=session_created,json=sessionCreated,proto3,oneof"`
+}
+
+type AuthDomainEvent_SessionRefreshed struct {
+	// User session refreshed event.
+	SessionRefreshed *SessionRefreshed `protobuf:"bytes,7,opt,name=session_refreshed,json=sessionRefreshed,proto3,oneof"`
+}
+
+type AuthDomainEvent_SessionRevoked struct {
+	// User session revoked event.
+	SessionRevoked *SessionRevoked `protobuf:"bytes,8,opt,name=session_revoked,json=sessionRevoked,proto3,oneof"`
+}
+
+type AuthDomainEvent_MfaEnrolled struct {
+	// User MFA enrolled event.
+	MfaEnrolled *MFAEnrolled `protobuf:"bytes,9,opt,name=mfa_enrolled,json=mfaEnrolled,proto3,oneof"`
+}
+
+func (*AuthDomainEvent_UserSignedUp) isAuthDomainEvent_Payload() {}
+
+func (*AuthDomainEvent_SessionCreated) isAuthDomainEvent_Payload() {}
+
+func (*AuthDomainEvent_SessionRefreshed) isAuthDomainEvent_Payload() {}
+
+func (*AuthDomainEvent_SessionRevoked) isAuthDomainEvent_Payload() {}
+
+func (*AuthDomainEvent_MfaEnrolled) isAuthDomainEvent_Payload() {}
+
+// User signed up event message.
+type UserSignedUp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// User id.
+	UserId []byte `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// User session id.
+	SessionId []byte `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Client ip address.
+	Ip string `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	// Client user agent.
+	UserAgent string `protobuf:"bytes,4,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+}
+
+func (x *UserSignedUp) Reset() {
+	*x = UserSignedUp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_durudex_v1_auth_domain_event_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserSignedUp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSignedUp) ProtoMessage() {}
+
+func (x *UserSignedUp) ProtoReflect() protoreflect.Message {
+	mi := &file_durudex_v1_auth_domain_event_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSignedUp.ProtoReflect.Descriptor instead.
+func (*UserSignedUp) Descriptor() ([]byte, []int) {
+	return file_durudex_v1_auth_domain_event_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserSignedUp) GetUserId() []byte {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *UserSignedUp) GetSessionId() []byte {
+	if x != nil {
+		return x.SessionId
+	}
+	return nil
+}
+
+func (x *UserSignedUp) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *UserSignedUp) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+// User session created event message.
+type SessionCreated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// User id.
+	UserId []byte `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// User session id.
+	SessionId []byte `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Client ip address.
+	Ip string `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	// Client device type.
+	DeviceType string `protobuf:"bytes,4,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
+	// Client device name.
+	DeviceName string `protobuf:"bytes,5,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`
+}
+
+func (x *SessionCreated) Reset() {
+	*x = SessionCreated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_durudex_v1_auth_domain_event_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionCreated) ProtoMessage() {}
+
+func (x *SessionCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_durudex_v1_auth_domain_event_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionCreated.ProtoReflect.Descriptor instead.
+func (*SessionCreated) Descriptor() ([]byte, []int) {
+	return file_durudex_v1_auth_domain_event_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SessionCreated) GetUserId() []byte {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *SessionCreated) GetSessionId() []byte {
+	if x != nil {
+		return x.SessionId
+	}
+	return nil
+}
+
+func (x *SessionCreated) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *SessionCreated) GetDeviceType() string {
+	if x != nil {
+		return x.DeviceType
+	}
+	return ""
+}
+
+func (x *SessionCreated) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+// User session refreshed event message.
+type SessionRefreshed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// User id.
+	UserId []byte `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// User session id.
+	SessionId []byte `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *SessionRefreshed) Reset() {
+	*x = SessionRefreshed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_durudex_v1_auth_domain_event_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionRefreshed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRefreshed) ProtoMessage() {}
+
+func (x *SessionRefreshed) ProtoReflect() protoreflect.Message {
+	mi := &file_durudex_v1_auth_domain_event_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRefreshed.ProtoReflect.Descriptor instead.
+func (*SessionRefreshed) Descriptor() ([]byte, []int) {
+	return file_durudex_v1_auth_domain_event_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SessionRefreshed) GetUserId() []byte {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *SessionRefreshed) GetSessionId() []byte {
+	if x != nil {
+		return x.SessionId
+	}
+	return nil
+}
+
+// User session revoked event message.
+type SessionRevoked struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// User id.
+	UserId []byte `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// User session id.
+	SessionId []byte `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *SessionRevoked) Reset() {
+	*x = SessionRevoked{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_durudex_v1_auth_domain_event_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionRevoked) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRevoked) ProtoMessage() {}
+
+func (x *SessionRevoked) ProtoReflect() protoreflect.Message {
+	mi := &file_durudex_v1_auth_domain_event_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRevoked.ProtoReflect.Descriptor instead.
+func (*SessionRevoked) Descriptor() ([]byte, []int) {
+	return file_durudex_v1_auth_domain_event_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SessionRevoked) GetUserId() []byte {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *SessionRevoked) GetSessionId() []byte {
+	if x != nil {
+		return x.SessionId
+	}
+	return nil
+}
+
+// User MFA enrolled event message.
+type MFAEnrolled struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// User id.
+	UserId []byte `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// MFA method.
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+}
+
+func (x *MFAEnrolled) Reset() {
+	*x = MFAEnrolled{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_durudex_v1_auth_domain_event_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MFAEnrolled) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MFAEnrolled) ProtoMessage() {}
+
+func (x *MFAEnrolled) ProtoReflect() protoreflect.Message {
+	mi := &file_durudex_v1_auth_domain_event_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MFAEnrolled.ProtoReflect.Descriptor instead.
+func (*MFAEnrolled) Descriptor() ([]byte, []int) {
+	return file_durudex_v1_auth_domain_event_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MFAEnrolled) GetUserId() []byte {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *MFAEnrolled) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+var File_durudex_v1_auth_domain_event_proto protoreflect.FileDescriptor
+
+var file_durudex_v1_auth_domain_event_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x75, 0x74,
+	0x68, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31,
+	0x1a, 0x1c, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xef,
+	0x03, 0x0a, 0x0f, 0x41, 0x75, 0x74, 0x68, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x38, 0x0a, 0x0b, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a,
+	0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x41, 0x74, 0x12, 0x40, 0x0a, 0x0e, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x75, 0x70, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x55, 0x70, 0x48, 0x00, 0x52, 0x0c,
+	0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x55, 0x70, 0x12, 0x45, 0x0a, 0x0f,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x12, 0x4b, 0x0a, 0x11, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72,
+	0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x65, 0x64, 0x48, 0x00, 0x52, 0x10,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x65, 0x64,
+	0x12, 0x45, 0x0a, 0x0f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x76, 0x6f,
+	0x6b, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x75, 0x72, 0x75,
+	0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x76, 0x6f, 0x6b, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x12, 0x3c, 0x0a, 0x0c, 0x6d, 0x66, 0x61, 0x5f, 0x65,
+	0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x46, 0x41, 0x45, 0x6e,
+	0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x66, 0x61, 0x45, 0x6e, 0x72,
+	0x6f, 0x6c, 0x6c, 0x65, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x22, 0x75, 0x0a, 0x0c, 0x55, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x55, 0x70,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73,
+	0x65, 0x72, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x22, 0x9a, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x22, 0x4a, 0x0a, 0x10, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x65, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x22, 0x48, 0x0a, 0x0e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x76, 0x6f, 0x6b,
+	0x65, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x3e, 0x0a, 0x0b, 0x4d, 0x46,
+	0x41, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x42, 0xb7, 0x01, 0x0a, 0x0e, 0x63,
+	0x6f, 0x6d, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x42, 0x14, 0x41,
+	0x75, 0x74, 0x68, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x46, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2f, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65,
+	0x78, 0x2d, 0x61, 0x75, 0x74, 0x68, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78,
+	0x2f, 0x76, 0x31, 0x3b, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x76, 0x31, 0xa2, 0x02, 0x03,
+	0x44, 0x58, 0x58, 0xaa, 0x02, 0x0a, 0x44, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x56, 0x31,
+	0xca, 0x02, 0x0a, 0x44, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x16,
+	0x44, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0b, 0x44, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78,
+	0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_durudex_v1_auth_domain_event_proto_rawDescOnce sync.Once
+	file_durudex_v1_auth_domain_event_proto_rawDescData = file_durudex_v1_auth_domain_event_proto_rawDesc
+)
+
+func file_durudex_v1_auth_domain_event_proto_rawDescGZIP() []byte {
+	file_durudex_v1_auth_domain_event_proto_rawDescOnce.Do(func() {
+		file_durudex_v1_auth_domain_event_proto_rawDescData = protoimpl.X.CompressGZIP(file_durudex_v1_auth_domain_event_proto_rawDescData)
+	})
+	return file_durudex_v1_auth_domain_event_proto_rawDescData
+}
+
+var file_durudex_v1_auth_domain_event_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_durudex_v1_auth_domain_event_proto_goTypes = []interface{}{
+	(*AuthDomainEvent)(nil),  // 0: durudex.v1.AuthDomainEvent
+	(*UserSignedUp)(nil),     // 1: durudex.v1.UserSignedUp
+	(*SessionCreated)(nil),   // 2: durudex.v1.SessionCreated
+	(*SessionRefreshed)(nil), // 3: durudex.v1.SessionRefreshed
+	(*SessionRevoked)(nil),   // 4: durudex.v1.SessionRevoked
+	(*MFAEnrolled)(nil),      // 5: durudex.v1.MFAEnrolled
+	(*pbtype.Timestamp)(nil), // 6: durudex.type.Timestamp
+}
+var file_durudex_v1_auth_domain_event_proto_depIdxs = []int32{
+	6, // 0: durudex.v1.AuthDomainEvent.occurred_at:type_name -> durudex.type.Timestamp
+	1, // 1: durudex.v1.AuthDomainEvent.user_signed_up:type_name -> durudex.v1.UserSignedUp
+	2, // 2: durudex.v1.AuthDomainEvent.session_created:type_name -> durudex.v1.SessionCreated
+	3, // 3: durudex.v1.AuthDomainEvent.session_refreshed:type_name -> durudex.v1.SessionRefreshed
+	4, // 4: durudex.v1.AuthDomainEvent.session_revoked:type_name -> durudex.v1.SessionRevoked
+	5, // 5: durudex.v1.AuthDomainEvent.mfa_enrolled:type_name -> durudex.v1.MFAEnrolled
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_durudex_v1_auth_domain_event_proto_init() }
+func file_durudex_v1_auth_domain_event_proto_init() {
+	if File_durudex_v1_auth_domain_event_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_durudex_v1_auth_domain_event_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthDomainEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_durudex_v1_auth_domain_event_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserSignedUp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_durudex_v1_auth_domain_event_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionCreated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_durudex_v1_auth_domain_event_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionRefreshed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_durudex_v1_auth_domain_event_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionRevoked); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_durudex_v1_auth_domain_event_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MFAEnrolled); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_durudex_v1_auth_domain_event_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*AuthDomainEvent_UserSignedUp)(nil),
+		(*AuthDomainEvent_SessionCreated)(nil),
+		(*AuthDomainEvent_SessionRefreshed)(nil),
+		(*AuthDomainEvent_SessionRevoked)(nil),
+		(*AuthDomainEvent_MfaEnrolled)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_durudex_v1_auth_domain_event_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_durudex_v1_auth_domain_event_proto_goTypes,
+		DependencyIndexes: file_durudex_v1_auth_domain_event_proto_depIdxs,
+		MessageInfos:      file_durudex_v1_auth_domain_event_proto_msgTypes,
+	}.Build()
+	File_durudex_v1_auth_domain_event_proto = out.File
+	file_durudex_v1_auth_domain_event_proto_rawDesc = nil
+	file_durudex_v1_auth_domain_event_proto_goTypes = nil
+	file_durudex_v1_auth_domain_event_proto_depIdxs = nil
+}
@@ -24,6 +24,10 @@ type UserAuthServiceClient interface {
 	UserSignIn(ctx context.Context, in *UserSignInRequest, opts ...grpc.CallOption) (*UserSignInResponse, error)
 	// Refresh user authentication token.
 	RefreshUserToken(ctx context.Context, in *RefreshUserTokenRequest, opts ...grpc.CallOption) (*RefreshUserTokenResponse, error)
+	// Re-authenticating the user session.
+	UserStepUp(ctx context.Context, in *UserStepUpRequest, opts ...grpc.CallOption) (*UserStepUpResponse, error)
+	// User Sign Out, deleting the refresh token session.
+	UserSignOut(ctx context.Context, in *UserSignOutRequest, opts ...grpc.CallOption) (*UserSignOutResponse, error)
 }
 
 type userAuthServiceClient struct {
@@ -61,6 +65,24 @@ func (c *userAuthServiceClient) RefreshUserToken(ctx context.Context, in *Refres
 	return out, nil
 }
 
+func (c *userAuthServiceClient) UserStepUp(ctx context.Context, in *UserStepUpRequest, opts ...grpc.CallOption) (*UserStepUpResponse, error) {
+	out := new(UserStepUpResponse)
+	err := c.cc.Invoke(ctx, "/durudex.v1.UserAuthService/UserStepUp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAuthServiceClient) UserSignOut(ctx context.Context, in *UserSignOutRequest, opts ...grpc.CallOption) (*UserSignOutResponse, error) {
+	out := new(UserSignOutResponse)
+	err := c.cc.Invoke(ctx, "/durudex.v1.UserAuthService/UserSignOut", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserAuthServiceServer is the server API for UserAuthService service.
 // All implementations must embed UnimplementedUserAuthServiceServer
 // for forward compatibility
@@ -71,6 +93,10 @@ type UserAuthServiceServer interface {
 	UserSignIn(context.Context, *UserSignInRequest) (*UserSignInResponse, error)
 	// Refresh user authentication token.
 	RefreshUserToken(context.Context, *RefreshUserTokenRequest) (*RefreshUserTokenResponse, error)
+	// Re-authenticating the user session.
+	UserStepUp(context.Context, *UserStepUpRequest) (*UserStepUpResponse, error)
+	// User Sign Out, deleting the refresh token session.
+	UserSignOut(context.Context, *UserSignOutRequest) (*UserSignOutResponse, error)
 	mustEmbedUnimplementedUserAuthServiceServer()
 }
 
@@ -87,6 +113,12 @@ func (UnimplementedUserAuthServiceServer) UserSignIn(context.Context, *UserSignI
 func (UnimplementedUserAuthServiceServer) RefreshUserToken(context.Context, *RefreshUserTokenRequest) (*RefreshUserTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RefreshUserToken not implemented")
 }
+func (UnimplementedUserAuthServiceServer) UserStepUp(context.Context, *UserStepUpRequest) (*UserStepUpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserStepUp not implemented")
+}
+func (UnimplementedUserAuthServiceServer) UserSignOut(context.Context, *UserSignOutRequest) (*UserSignOutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserSignOut not implemented")
+}
 func (UnimplementedUserAuthServiceServer) mustEmbedUnimplementedUserAuthServiceServer() {}
 
 // UnsafeUserAuthServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -154,6 +186,42 @@ func _UserAuthService_RefreshUserToken_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserAuthService_UserStepUp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserStepUpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAuthServiceServer).UserStepUp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/durudex.v1.UserAuthService/UserStepUp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAuthServiceServer).UserStepUp(ctx, req.(*UserStepUpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserAuthService_UserSignOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserSignOutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAuthServiceServer).UserSignOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/durudex.v1.UserAuthService/UserSignOut",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAuthServiceServer).UserSignOut(ctx, req.(*UserSignOutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserAuthService_ServiceDesc is the grpc.ServiceDesc for UserAuthService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -173,6 +241,14 @@ var UserAuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RefreshUserToken",
 			Handler:    _UserAuthService_RefreshUserToken_Handler,
 		},
+		{
+			MethodName: "UserStepUp",
+			Handler:    _UserAuthService_UserStepUp_Handler,
+		},
+		{
+			MethodName: "UserSignOut",
+			Handler:    _UserAuthService_UserSignOut_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "durudex/v1/user_auth.proto",
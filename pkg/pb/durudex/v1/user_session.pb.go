@@ -5,7 +5,7 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.26.0
+// 	protoc-gen-go v1.28.0
 // 	protoc        (unknown)
 // source: durudex/v1/user_session.proto
 
@@ -26,6 +26,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// User session state.
+type UserSessionState int32
+
+const (
+	// Any session state.
+	UserSessionState_USER_SESSION_STATE_UNSPECIFIED UserSessionState = 0
+	// Not expired session.
+	UserSessionState_USER_SESSION_STATE_ACTIVE UserSessionState = 1
+	// Expired session.
+	UserSessionState_USER_SESSION_STATE_EXPIRED UserSessionState = 2
+)
+
+// Enum value maps for UserSessionState.
+var (
+	UserSessionState_name = map[int32]string{
+		0: "USER_SESSION_STATE_UNSPECIFIED",
+		1: "USER_SESSION_STATE_ACTIVE",
+		2: "USER_SESSION_STATE_EXPIRED",
+	}
+	UserSessionState_value = map[string]int32{
+		"USER_SESSION_STATE_UNSPECIFIED": 0,
+		"USER_SESSION_STATE_ACTIVE":      1,
+		"USER_SESSION_STATE_EXPIRED":     2,
+	}
+)
+
+func (x UserSessionState) Enum() *UserSessionState {
+	p := new(UserSessionState)
+	*p = x
+	return p
+}
+
+func (x UserSessionState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserSessionState) Descriptor() protoreflect.EnumDescriptor {
+	return file_durudex_v1_user_session_proto_enumTypes[0].Descriptor()
+}
+
+func (UserSessionState) Type() protoreflect.EnumType {
+	return &file_durudex_v1_user_session_proto_enumTypes[0]
+}
+
+func (x UserSessionState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UserSessionState.Descriptor instead.
+func (UserSessionState) EnumDescriptor() ([]byte, []int) {
+	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{0}
+}
+
 // User session message.
 type UserSession struct {
 	state         protoimpl.MessageState
@@ -40,6 +93,10 @@ type UserSession struct {
 	Ip string `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
 	// Session expires in.
 	ExpiresIn *pbtype.Timestamp `protobuf:"bytes,4,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	// Session device type.
+	DeviceType string `protobuf:"bytes,5,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
+	// Session device name.
+	DeviceName string `protobuf:"bytes,6,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`
 }
 
 func (x *UserSession) Reset() {
@@ -102,6 +159,114 @@ func (x *UserSession) GetExpiresIn() *pbtype.Timestamp {
 	return nil
 }
 
+func (x *UserSession) GetDeviceType() string {
+	if x != nil {
+		return x.DeviceType
+	}
+	return ""
+}
+
+func (x *UserSession) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+// User sessions filter message.
+type UserSessionFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Session ip address or CIDR network.
+	Ip *string `protobuf:"bytes,1,opt,name=ip,proto3,oneof" json:"ip,omitempty"`
+	// Sessions created after the time.
+	CreatedAfter *pbtype.Timestamp `protobuf:"bytes,2,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	// Sessions created before the time.
+	CreatedBefore *pbtype.Timestamp `protobuf:"bytes,3,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	// Session state.
+	State UserSessionState `protobuf:"varint,4,opt,name=state,proto3,enum=durudex.v1.UserSessionState" json:"state,omitempty"`
+	// Session device type.
+	DeviceType *string `protobuf:"bytes,5,opt,name=device_type,json=deviceType,proto3,oneof" json:"device_type,omitempty"`
+	// Case-insensitive session device name part.
+	DeviceName *string `protobuf:"bytes,6,opt,name=device_name,json=deviceName,proto3,oneof" json:"device_name,omitempty"`
+}
+
+func (x *UserSessionFilter) Reset() {
+	*x = UserSessionFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_durudex_v1_user_session_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserSessionFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSessionFilter) ProtoMessage() {}
+
+func (x *UserSessionFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_durudex_v1_user_session_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSessionFilter.ProtoReflect.Descriptor instead.
+func (*UserSessionFilter) Descriptor() ([]byte, []int) {
+	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserSessionFilter) GetIp() string {
+	if x != nil && x.Ip != nil {
+		return *x.Ip
+	}
+	return ""
+}
+
+func (x *UserSessionFilter) GetCreatedAfter() *pbtype.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *UserSessionFilter) GetCreatedBefore() *pbtype.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *UserSessionFilter) GetState() UserSessionState {
+	if x != nil {
+		return x.State
+	}
+	return UserSessionState_USER_SESSION_STATE_UNSPECIFIED
+}
+
+func (x *UserSessionFilter) GetDeviceType() string {
+	if x != nil && x.DeviceType != nil {
+		return *x.DeviceType
+	}
+	return ""
+}
+
+func (x *UserSessionFilter) GetDeviceName() string {
+	if x != nil && x.DeviceName != nil {
+		return *x.DeviceName
+	}
+	return ""
+}
+
 // Getting a user session request.
 type GetUserSessionRequest struct {
 	state         protoimpl.MessageState
@@ -117,7 +282,7 @@ type GetUserSessionRequest struct {
 func (x *GetUserSessionRequest) Reset() {
 	*x = GetUserSessionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_durudex_v1_user_session_proto_msgTypes[1]
+		mi := &file_durudex_v1_user_session_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -130,7 +295,7 @@ func (x *GetUserSessionRequest) String() string {
 func (*GetUserSessionRequest) ProtoMessage() {}
 
 func (x *GetUserSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_durudex_v1_user_session_proto_msgTypes[1]
+	mi := &file_durudex_v1_user_session_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -143,7 +308,7 @@ func (x *GetUserSessionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserSessionRequest.ProtoReflect.Descriptor instead.
 func (*GetUserSessionRequest) Descriptor() ([]byte, []int) {
-	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{1}
+	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GetUserSessionRequest) GetId() []byte {
@@ -170,12 +335,16 @@ type GetUserSessionResponse struct {
 	Ip string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
 	// Session expires in.
 	ExpiresIn *pbtype.Timestamp `protobuf:"bytes,2,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	// Session device type.
+	DeviceType string `protobuf:"bytes,3,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
+	// Session device name.
+	DeviceName string `protobuf:"bytes,4,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`
 }
 
 func (x *GetUserSessionResponse) Reset() {
 	*x = GetUserSessionResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_durudex_v1_user_session_proto_msgTypes[2]
+		mi := &file_durudex_v1_user_session_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -188,7 +357,7 @@ func (x *GetUserSessionResponse) String() string {
 func (*GetUserSessionResponse) ProtoMessage() {}
 
 func (x *GetUserSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_durudex_v1_user_session_proto_msgTypes[2]
+	mi := &file_durudex_v1_user_session_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -201,7 +370,7 @@ func (x *GetUserSessionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserSessionResponse.ProtoReflect.Descriptor instead.
 func (*GetUserSessionResponse) Descriptor() ([]byte, []int) {
-	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{2}
+	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetUserSessionResponse) GetIp() string {
@@ -218,6 +387,20 @@ func (x *GetUserSessionResponse) GetExpiresIn() *pbtype.Timestamp {
 	return nil
 }
 
+func (x *GetUserSessionResponse) GetDeviceType() string {
+	if x != nil {
+		return x.DeviceType
+	}
+	return ""
+}
+
+func (x *GetUserSessionResponse) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
 // Getting a user sessions request.
 type GetUserSessionsRequest struct {
 	state         protoimpl.MessageState
@@ -228,12 +411,14 @@ type GetUserSessionsRequest struct {
 	UserId []byte `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	// Query sort options.
 	SortOptions *pbtype.SortOptions `protobuf:"bytes,2,opt,name=sort_options,json=sortOptions,proto3" json:"sort_options,omitempty"`
+	// Query filter.
+	Filter *UserSessionFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
 }
 
 func (x *GetUserSessionsRequest) Reset() {
 	*x = GetUserSessionsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_durudex_v1_user_session_proto_msgTypes[3]
+		mi := &file_durudex_v1_user_session_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -246,7 +431,7 @@ func (x *GetUserSessionsRequest) String() string {
 func (*GetUserSessionsRequest) ProtoMessage() {}
 
 func (x *GetUserSessionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_durudex_v1_user_session_proto_msgTypes[3]
+	mi := &file_durudex_v1_user_session_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -259,7 +444,7 @@ func (x *GetUserSessionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserSessionsRequest.ProtoReflect.Descriptor instead.
 func (*GetUserSessionsRequest) Descriptor() ([]byte, []int) {
-	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{3}
+	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetUserSessionsRequest) GetUserId() []byte {
@@ -276,20 +461,167 @@ func (x *GetUserSessionsRequest) GetSortOptions() *pbtype.SortOptions {
 	return nil
 }
 
+func (x *GetUserSessionsRequest) GetFilter() *UserSessionFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// User session connection edge message.
+type UserSessionEdge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Opaque edge cursor.
+	Cursor []byte `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// User session.
+	Node *UserSession `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+}
+
+func (x *UserSessionEdge) Reset() {
+	*x = UserSessionEdge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_durudex_v1_user_session_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserSessionEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSessionEdge) ProtoMessage() {}
+
+func (x *UserSessionEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_durudex_v1_user_session_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSessionEdge.ProtoReflect.Descriptor instead.
+func (*UserSessionEdge) Descriptor() ([]byte, []int) {
+	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UserSessionEdge) GetCursor() []byte {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
+}
+
+func (x *UserSessionEdge) GetNode() *UserSession {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+// Connection page info message.
+type PageInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Is there a page after the end cursor.
+	HasNextPage bool `protobuf:"varint,1,opt,name=has_next_page,json=hasNextPage,proto3" json:"has_next_page,omitempty"`
+	// Is there a page before the start cursor.
+	HasPreviousPage bool `protobuf:"varint,2,opt,name=has_previous_page,json=hasPreviousPage,proto3" json:"has_previous_page,omitempty"`
+	// Cursor of the first edge.
+	StartCursor []byte `protobuf:"bytes,3,opt,name=start_cursor,json=startCursor,proto3,oneof" json:"start_cursor,omitempty"`
+	// Cursor of the last edge.
+	EndCursor []byte `protobuf:"bytes,4,opt,name=end_cursor,json=endCursor,proto3,oneof" json:"end_cursor,omitempty"`
+}
+
+func (x *PageInfo) Reset() {
+	*x = PageInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_durudex_v1_user_session_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageInfo) ProtoMessage() {}
+
+func (x *PageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_durudex_v1_user_session_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
+func (*PageInfo) Descriptor() ([]byte, []int) {
+	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PageInfo) GetHasNextPage() bool {
+	if x != nil {
+		return x.HasNextPage
+	}
+	return false
+}
+
+func (x *PageInfo) GetHasPreviousPage() bool {
+	if x != nil {
+		return x.HasPreviousPage
+	}
+	return false
+}
+
+func (x *PageInfo) GetStartCursor() []byte {
+	if x != nil {
+		return x.StartCursor
+	}
+	return nil
+}
+
+func (x *PageInfo) GetEndCursor() []byte {
+	if x != nil {
+		return x.EndCursor
+	}
+	return nil
+}
+
 // Getting a user sessions response.
 type GetUserSessionsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// User sessions.
+	// User sessions, use edges instead.
+	//
+	// Deprecated: Do not use.
 	Sessions []*UserSession `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	// User session edges ordered by id.
+	Edges []*UserSessionEdge `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	// Connection page info.
+	PageInfo *PageInfo `protobuf:"bytes,3,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
 }
 
 func (x *GetUserSessionsResponse) Reset() {
 	*x = GetUserSessionsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_durudex_v1_user_session_proto_msgTypes[4]
+		mi := &file_durudex_v1_user_session_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -302,7 +634,7 @@ func (x *GetUserSessionsResponse) String() string {
 func (*GetUserSessionsResponse) ProtoMessage() {}
 
 func (x *GetUserSessionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_durudex_v1_user_session_proto_msgTypes[4]
+	mi := &file_durudex_v1_user_session_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -315,9 +647,10 @@ func (x *GetUserSessionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserSessionsResponse.ProtoReflect.Descriptor instead.
 func (*GetUserSessionsResponse) Descriptor() ([]byte, []int) {
-	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{4}
+	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{7}
 }
 
+// Deprecated: Do not use.
 func (x *GetUserSessionsResponse) GetSessions() []*UserSession {
 	if x != nil {
 		return x.Sessions
@@ -325,6 +658,20 @@ func (x *GetUserSessionsResponse) GetSessions() []*UserSession {
 	return nil
 }
 
+func (x *GetUserSessionsResponse) GetEdges() []*UserSessionEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *GetUserSessionsResponse) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
 // Deleting a user session request.
 type DeleteUserSessionRequest struct {
 	state         protoimpl.MessageState
@@ -340,7 +687,7 @@ type DeleteUserSessionRequest struct {
 func (x *DeleteUserSessionRequest) Reset() {
 	*x = DeleteUserSessionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_durudex_v1_user_session_proto_msgTypes[5]
+		mi := &file_durudex_v1_user_session_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -353,7 +700,7 @@ func (x *DeleteUserSessionRequest) String() string {
 func (*DeleteUserSessionRequest) ProtoMessage() {}
 
 func (x *DeleteUserSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_durudex_v1_user_session_proto_msgTypes[5]
+	mi := &file_durudex_v1_user_session_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -366,7 +713,7 @@ func (x *DeleteUserSessionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserSessionRequest.ProtoReflect.Descriptor instead.
 func (*DeleteUserSessionRequest) Descriptor() ([]byte, []int) {
-	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{5}
+	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DeleteUserSessionRequest) GetId() []byte {
@@ -393,7 +740,7 @@ type DeleteUserSessionResponse struct {
 func (x *DeleteUserSessionResponse) Reset() {
 	*x = DeleteUserSessionResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_durudex_v1_user_session_proto_msgTypes[6]
+		mi := &file_durudex_v1_user_session_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -406,7 +753,7 @@ func (x *DeleteUserSessionResponse) String() string {
 func (*DeleteUserSessionResponse) ProtoMessage() {}
 
 func (x *DeleteUserSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_durudex_v1_user_session_proto_msgTypes[6]
+	mi := &file_durudex_v1_user_session_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -419,7 +766,7 @@ func (x *DeleteUserSessionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserSessionResponse.ProtoReflect.Descriptor instead.
 func (*DeleteUserSessionResponse) Descriptor() ([]byte, []int) {
-	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{6}
+	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{9}
 }
 
 // Getting total session count request.
@@ -435,7 +782,7 @@ type GetTotalUserSessionCountRequest struct {
 func (x *GetTotalUserSessionCountRequest) Reset() {
 	*x = GetTotalUserSessionCountRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_durudex_v1_user_session_proto_msgTypes[7]
+		mi := &file_durudex_v1_user_session_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -448,7 +795,7 @@ func (x *GetTotalUserSessionCountRequest) String() string {
 func (*GetTotalUserSessionCountRequest) ProtoMessage() {}
 
 func (x *GetTotalUserSessionCountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_durudex_v1_user_session_proto_msgTypes[7]
+	mi := &file_durudex_v1_user_session_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -461,7 +808,7 @@ func (x *GetTotalUserSessionCountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTotalUserSessionCountRequest.ProtoReflect.Descriptor instead.
 func (*GetTotalUserSessionCountRequest) Descriptor() ([]byte, []int) {
-	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{7}
+	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetTotalUserSessionCountRequest) GetUserId() []byte {
@@ -484,7 +831,7 @@ type GetTotalUserSessionCountResponse struct {
 func (x *GetTotalUserSessionCountResponse) Reset() {
 	*x = GetTotalUserSessionCountResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_durudex_v1_user_session_proto_msgTypes[8]
+		mi := &file_durudex_v1_user_session_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -497,7 +844,7 @@ func (x *GetTotalUserSessionCountResponse) String() string {
 func (*GetTotalUserSessionCountResponse) ProtoMessage() {}
 
 func (x *GetTotalUserSessionCountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_durudex_v1_user_session_proto_msgTypes[8]
+	mi := &file_durudex_v1_user_session_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -510,7 +857,7 @@ func (x *GetTotalUserSessionCountResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTotalUserSessionCountResponse.ProtoReflect.Descriptor instead.
 func (*GetTotalUserSessionCountResponse) Descriptor() ([]byte, []int) {
-	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{8}
+	return file_durudex_v1_user_session_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetTotalUserSessionCountResponse) GetCount() int32 {
@@ -529,7 +876,7 @@ var file_durudex_v1_user_session_proto_rawDesc = []byte{
 	0x75, 0x64, 0x65, 0x78, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
 	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x64, 0x75, 0x72, 0x75, 0x64,
 	0x65, 0x78, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x6f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8f, 0x01, 0x0a, 0x0b, 0x55, 0x73,
+	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd1, 0x01, 0x0a, 0x0b, 0x55, 0x73,
 	0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x07, 0x75, 0x73, 0x65,
 	0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x06, 0x75, 0x73,
@@ -537,44 +884,109 @@ var file_durudex_v1_user_session_proto_rawDesc = []byte{
 	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x36, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72,
 	0x65, 0x73, 0x5f, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x75,
 	0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x49, 0x6e, 0x42,
-	0x0a, 0x0a, 0x08, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x22, 0x40, 0x0a, 0x15, 0x47,
-	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x60, 0x0a,
-	0x16, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x36, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72,
-	0x65, 0x73, 0x5f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x75,
-	0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x49, 0x6e, 0x22,
-	0x6f, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
-	0x49, 0x64, 0x12, 0x3c, 0x0a, 0x0c, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64,
-	0x65, 0x78, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x0b, 0x73, 0x6f, 0x72, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x22, 0x4e, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x73,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
-	0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
-	0x22, 0x43, 0x0a, 0x18, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07,
-	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x75,
-	0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x1b, 0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55,
-	0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x3a, 0x0a, 0x1f, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x55, 0x73,
-	0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x38,
-	0x0a, 0x20, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x32, 0xa2, 0x03, 0x0a, 0x12, 0x55, 0x73, 0x65,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x49, 0x6e, 0x12,
+	0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x22, 0xcd, 0x02,
+	0x0a, 0x11, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x13, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x02, 0x69, 0x70, 0x88, 0x01, 0x01, 0x12, 0x3c, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x3e, 0x0a, 0x0e, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e,
+	0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x24, 0x0a, 0x0b, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x01, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x88, 0x01, 0x01,
+	0x12, 0x24, 0x0a, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x42, 0x05, 0x0a, 0x03, 0x5f, 0x69, 0x70, 0x42, 0x0e, 0x0a,
+	0x0c, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x42, 0x0e, 0x0a,
+	0x0c, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x40, 0x0a,
+	0x15, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22,
+	0xa2, 0x01, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x36, 0x0a, 0x0a, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x49, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x22, 0xa6, 0x01, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x3c, 0x0a, 0x0c, 0x73, 0x6f, 0x72, 0x74,
+	0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x53, 0x6f,
+	0x72, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0b, 0x73, 0x6f, 0x72, 0x74, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x35, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78,
+	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x56, 0x0a,
+	0x0f, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x64, 0x67, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x2b, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78,
+	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0xc6, 0x01, 0x0a, 0x08, 0x50, 0x61, 0x67, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x22, 0x0a, 0x0d, 0x68, 0x61, 0x73, 0x5f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70,
+	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x68, 0x61, 0x73, 0x4e, 0x65,
+	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x68, 0x61, 0x73, 0x5f, 0x70, 0x72,
+	0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0f, 0x68, 0x61, 0x73, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x50, 0x61,
+	0x67, 0x65, 0x12, 0x26, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x65, 0x6e,
+	0x64, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x01,
+	0x52, 0x09, 0x65, 0x6e, 0x64, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x88, 0x01, 0x01, 0x42, 0x0f,
+	0x0a, 0x0d, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x42,
+	0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0xb8,
+	0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x08, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64,
+	0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x02, 0x18, 0x01, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x31, 0x0a, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x64, 0x67, 0x65, 0x52,
+	0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x69,
+	0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x75, 0x72, 0x75,
+	0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x08, 0x70, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x43, 0x0a, 0x18, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x1b,
+	0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3a, 0x0a, 0x1f, 0x47,
+	0x65, 0x74, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x38, 0x0a, 0x20, 0x47, 0x65, 0x74, 0x54, 0x6f,
+	0x74, 0x61, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x2a, 0x75, 0x0a, 0x10, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x1e, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x45,
+	0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x55, 0x53, 0x45,
+	0x52, 0x5f, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f,
+	0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x55, 0x53, 0x45, 0x52,
+	0x5f, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x45,
+	0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x02, 0x32, 0xa2, 0x03, 0x0a, 0x12, 0x55, 0x73, 0x65,
 	0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
 	0x57, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
 	0x6e, 0x12, 0x21, 0x2e, 0x64, 0x75, 0x72, 0x75, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x47,
@@ -627,38 +1039,50 @@ func file_durudex_v1_user_session_proto_rawDescGZIP() []byte {
 	return file_durudex_v1_user_session_proto_rawDescData
 }
 
-var file_durudex_v1_user_session_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_durudex_v1_user_session_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_durudex_v1_user_session_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_durudex_v1_user_session_proto_goTypes = []interface{}{
-	(*UserSession)(nil),                      // 0: durudex.v1.UserSession
-	(*GetUserSessionRequest)(nil),            // 1: durudex.v1.GetUserSessionRequest
-	(*GetUserSessionResponse)(nil),           // 2: durudex.v1.GetUserSessionResponse
-	(*GetUserSessionsRequest)(nil),           // 3: durudex.v1.GetUserSessionsRequest
-	(*GetUserSessionsResponse)(nil),          // 4: durudex.v1.GetUserSessionsResponse
-	(*DeleteUserSessionRequest)(nil),         // 5: durudex.v1.DeleteUserSessionRequest
-	(*DeleteUserSessionResponse)(nil),        // 6: durudex.v1.DeleteUserSessionResponse
-	(*GetTotalUserSessionCountRequest)(nil),  // 7: durudex.v1.GetTotalUserSessionCountRequest
-	(*GetTotalUserSessionCountResponse)(nil), // 8: durudex.v1.GetTotalUserSessionCountResponse
-	(*pbtype.Timestamp)(nil),                 // 9: durudex.type.Timestamp
-	(*pbtype.SortOptions)(nil),               // 10: durudex.type.SortOptions
+	(UserSessionState)(0),                    // 0: durudex.v1.UserSessionState
+	(*UserSession)(nil),                      // 1: durudex.v1.UserSession
+	(*UserSessionFilter)(nil),                // 2: durudex.v1.UserSessionFilter
+	(*GetUserSessionRequest)(nil),            // 3: durudex.v1.GetUserSessionRequest
+	(*GetUserSessionResponse)(nil),           // 4: durudex.v1.GetUserSessionResponse
+	(*GetUserSessionsRequest)(nil),           // 5: durudex.v1.GetUserSessionsRequest
+	(*UserSessionEdge)(nil),                  // 6: durudex.v1.UserSessionEdge
+	(*PageInfo)(nil),                         // 7: durudex.v1.PageInfo
+	(*GetUserSessionsResponse)(nil),          // 8: durudex.v1.GetUserSessionsResponse
+	(*DeleteUserSessionRequest)(nil),         // 9: durudex.v1.DeleteUserSessionRequest
+	(*DeleteUserSessionResponse)(nil),        // 10: durudex.v1.DeleteUserSessionResponse
+	(*GetTotalUserSessionCountRequest)(nil),  // 11: durudex.v1.GetTotalUserSessionCountRequest
+	(*GetTotalUserSessionCountResponse)(nil), // 12: durudex.v1.GetTotalUserSessionCountResponse
+	(*pbtype.Timestamp)(nil),                 // 13: durudex.type.Timestamp
+	(*pbtype.SortOptions)(nil),               // 14: durudex.type.SortOptions
 }
 var file_durudex_v1_user_session_proto_depIdxs = []int32{
-	9,  // 0: durudex.v1.UserSession.expires_in:type_name -> durudex.type.Timestamp
-	9,  // 1: durudex.v1.GetUserSessionResponse.expires_in:type_name -> durudex.type.Timestamp
-	10, // 2: durudex.v1.GetUserSessionsRequest.sort_options:type_name -> durudex.type.SortOptions
-	0,  // 3: durudex.v1.GetUserSessionsResponse.sessions:type_name -> durudex.v1.UserSession
-	1,  // 4: durudex.v1.UserSessionService.GetUserSession:input_type -> durudex.v1.GetUserSessionRequest
-	3,  // 5: durudex.v1.UserSessionService.GetUserSessions:input_type -> durudex.v1.GetUserSessionsRequest
-	5,  // 6: durudex.v1.UserSessionService.DeleteUserSession:input_type -> durudex.v1.DeleteUserSessionRequest
-	7,  // 7: durudex.v1.UserSessionService.GetTotalUserSessionCount:input_type -> durudex.v1.GetTotalUserSessionCountRequest
-	2,  // 8: durudex.v1.UserSessionService.GetUserSession:output_type -> durudex.v1.GetUserSessionResponse
-	4,  // 9: durudex.v1.UserSessionService.GetUserSessions:output_type -> durudex.v1.GetUserSessionsResponse
-	6,  // 10: durudex.v1.UserSessionService.DeleteUserSession:output_type -> durudex.v1.DeleteUserSessionResponse
-	8,  // 11: durudex.v1.UserSessionService.GetTotalUserSessionCount:output_type -> durudex.v1.GetTotalUserSessionCountResponse
-	8,  // [8:12] is the sub-list for method output_type
-	4,  // [4:8] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	13, // 0: durudex.v1.UserSession.expires_in:type_name -> durudex.type.Timestamp
+	13, // 1: durudex.v1.UserSessionFilter.created_after:type_name -> durudex.type.Timestamp
+	13, // 2: durudex.v1.UserSessionFilter.created_before:type_name -> durudex.type.Timestamp
+	0,  // 3: durudex.v1.UserSessionFilter.state:type_name -> durudex.v1.UserSessionState
+	13, // 4: durudex.v1.GetUserSessionResponse.expires_in:type_name -> durudex.type.Timestamp
+	14, // 5: durudex.v1.GetUserSessionsRequest.sort_options:type_name -> durudex.type.SortOptions
+	2,  // 6: durudex.v1.GetUserSessionsRequest.filter:type_name -> durudex.v1.UserSessionFilter
+	1,  // 7: durudex.v1.UserSessionEdge.node:type_name -> durudex.v1.UserSession
+	1,  // 8: durudex.v1.GetUserSessionsResponse.sessions:type_name -> durudex.v1.UserSession
+	6,  // 9: durudex.v1.GetUserSessionsResponse.edges:type_name -> durudex.v1.UserSessionEdge
+	7,  // 10: durudex.v1.GetUserSessionsResponse.page_info:type_name -> durudex.v1.PageInfo
+	3,  // 11: durudex.v1.UserSessionService.GetUserSession:input_type -> durudex.v1.GetUserSessionRequest
+	5,  // 12: durudex.v1.UserSessionService.GetUserSessions:input_type -> durudex.v1.GetUserSessionsRequest
+	9,  // 13: durudex.v1.UserSessionService.DeleteUserSession:input_type -> durudex.v1.DeleteUserSessionRequest
+	11, // 14: durudex.v1.UserSessionService.GetTotalUserSessionCount:input_type -> durudex.v1.GetTotalUserSessionCountRequest
+	4,  // 15: durudex.v1.UserSessionService.GetUserSession:output_type -> durudex.v1.GetUserSessionResponse
+	8,  // 16: durudex.v1.UserSessionService.GetUserSessions:output_type -> durudex.v1.GetUserSessionsResponse
+	10, // 17: durudex.v1.UserSessionService.DeleteUserSession:output_type -> durudex.v1.DeleteUserSessionResponse
+	12, // 18: durudex.v1.UserSessionService.GetTotalUserSessionCount:output_type -> durudex.v1.GetTotalUserSessionCountResponse
+	15, // [15:19] is the sub-list for method output_type
+	11, // [11:15] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_durudex_v1_user_session_proto_init() }
@@ -680,7 +1104,7 @@ func file_durudex_v1_user_session_proto_init() {
 			}
 		}
 		file_durudex_v1_user_session_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetUserSessionRequest); i {
+			switch v := v.(*UserSessionFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -692,7 +1116,7 @@ func file_durudex_v1_user_session_proto_init() {
 			}
 		}
 		file_durudex_v1_user_session_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetUserSessionResponse); i {
+			switch v := v.(*GetUserSessionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -704,7 +1128,7 @@ func file_durudex_v1_user_session_proto_init() {
 			}
 		}
 		file_durudex_v1_user_session_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetUserSessionsRequest); i {
+			switch v := v.(*GetUserSessionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -716,7 +1140,7 @@ func file_durudex_v1_user_session_proto_init() {
 			}
 		}
 		file_durudex_v1_user_session_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetUserSessionsResponse); i {
+			switch v := v.(*GetUserSessionsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -728,7 +1152,7 @@ func file_durudex_v1_user_session_proto_init() {
 			}
 		}
 		file_durudex_v1_user_session_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteUserSessionRequest); i {
+			switch v := v.(*UserSessionEdge); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -740,7 +1164,7 @@ func file_durudex_v1_user_session_proto_init() {
 			}
 		}
 		file_durudex_v1_user_session_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteUserSessionResponse); i {
+			switch v := v.(*PageInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -752,7 +1176,7 @@ func file_durudex_v1_user_session_proto_init() {
 			}
 		}
 		file_durudex_v1_user_session_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetTotalUserSessionCountRequest); i {
+			switch v := v.(*GetUserSessionsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -764,6 +1188,42 @@ func file_durudex_v1_user_session_proto_init() {
 			}
 		}
 		file_durudex_v1_user_session_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteUserSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_durudex_v1_user_session_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteUserSessionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_durudex_v1_user_session_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTotalUserSessionCountRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_durudex_v1_user_session_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetTotalUserSessionCountResponse); i {
 			case 0:
 				return &v.state
@@ -777,18 +1237,21 @@ func file_durudex_v1_user_session_proto_init() {
 		}
 	}
 	file_durudex_v1_user_session_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	file_durudex_v1_user_session_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	file_durudex_v1_user_session_proto_msgTypes[6].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_durudex_v1_user_session_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   9,
+			NumEnums:      1,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_durudex_v1_user_session_proto_goTypes,
 		DependencyIndexes: file_durudex_v1_user_session_proto_depIdxs,
+		EnumInfos:         file_durudex_v1_user_session_proto_enumTypes,
 		MessageInfos:      file_durudex_v1_user_session_proto_msgTypes,
 	}.Build()
 	File_durudex_v1_user_session_proto = out.File
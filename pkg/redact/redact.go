@@ -0,0 +1,103 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package redact
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Masked value of the redacted string and bytes fields.
+const Mask string = "[REDACTED]"
+
+// Default sensitive protobuf field names.
+var DefaultFields = []string{"password", "secret", "refresh", "access", "code"}
+
+// Protobuf message redactor structure.
+type Redactor struct {
+	fields map[protoreflect.Name]struct{}
+}
+
+// Creating a new protobuf message redactor.
+func New(fields ...string) *Redactor {
+	r := &Redactor{fields: make(map[protoreflect.Name]struct{}, len(fields))}
+
+	for _, field := range fields {
+		r.fields[protoreflect.Name(field)] = struct{}{}
+	}
+
+	return r
+}
+
+// Getting a copy of the message with masked sensitive fields. The original message is not modified.
+func (r *Redactor) Redact(msg proto.Message) proto.Message {
+	if msg == nil {
+		return nil
+	}
+
+	clone := proto.Clone(msg)
+	r.redact(clone.ProtoReflect())
+
+	return clone
+}
+
+// Masking sensitive fields of the message and all nested messages.
+func (r *Redactor) redact(msg protoreflect.Message) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		// Checking is field sensitive.
+		if _, ok := r.fields[fd.Name()]; ok {
+			r.mask(msg, fd)
+			return true
+		}
+
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				r.redact(list.Get(i).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				r.redact(mv.Message())
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			r.redact(v.Message())
+		}
+
+		return true
+	})
+}
+
+// Masking a sensitive field value.
+func (r *Redactor) mask(msg protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	// Lists, maps and nested messages are dropped entirely.
+	if fd.IsList() || fd.IsMap() || fd.Message() != nil {
+		msg.Clear(fd)
+		return
+	}
+
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		msg.Set(fd, protoreflect.ValueOfString(Mask))
+	case protoreflect.BytesKind:
+		msg.Set(fd, protoreflect.ValueOfBytes([]byte(Mask)))
+	default:
+		msg.Clear(fd)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package redact_test
+
+import (
+	"testing"
+
+	v1 "github.com/durudex/durudex-auth-service/pkg/pb/durudex/v1"
+	"github.com/durudex/durudex-auth-service/pkg/redact"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Testing redacting sensitive protobuf message fields.
+func TestRedactor_Redact(t *testing.T) {
+	// Testing args.
+	type args struct{ msg proto.Message }
+
+	// Creating a new redactor.
+	r := redact.New(redact.DefaultFields...)
+
+	// Tests structures.
+	tests := []struct {
+		name string
+		args args
+		want proto.Message
+	}{
+		{
+			name: "OK",
+			args: args{msg: &v1.UserSignUpRequest{
+				Username: "example",
+				Email:    "example@durudex.com",
+				Password: "qwerty",
+				Secret:   "secret",
+				Code:     123456,
+				Ip:       "0.0.0.0",
+			}},
+			want: &v1.UserSignUpRequest{
+				Username: "example",
+				Email:    "example@durudex.com",
+				Password: redact.Mask,
+				Secret:   redact.Mask,
+				Ip:       "0.0.0.0",
+			},
+		},
+		{
+			name: "Without sensitive fields",
+			args: args{msg: &v1.GetUserSessionRequest{Id: []byte("id"), UserId: []byte("user")}},
+			want: &v1.GetUserSessionRequest{Id: []byte("id"), UserId: []byte("user")},
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := proto.Clone(tt.args.msg)
+
+			// Redacting a message.
+			got := r.Redact(tt.args.msg)
+
+			// Check for similarity of a message.
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("error redacted message are not similar: %v", got)
+			}
+
+			// Check that the original message is not modified.
+			if !proto.Equal(tt.args.msg, original) {
+				t.Error("error original message is modified")
+			}
+		})
+	}
+}
@@ -20,12 +20,19 @@ package tls
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"os"
 )
 
-// Loading TLS credentials config.
-func LoadTLSConfig(caCertPath, certPath, keyPath string) (*tls.Config, error) {
+// TLS credentials material.
+type material struct {
+	cert *tls.Certificate
+	pool *x509.CertPool
+}
+
+// Loading TLS credentials material.
+func loadMaterial(caCertPath, certPath, keyPath string) (*material, error) {
 	// Load certificate on the CA who signed client's certificate.
 	pemCA, err := os.ReadFile(caCertPath)
 	if err != nil {
@@ -39,14 +46,96 @@ func LoadTLSConfig(caCertPath, certPath, keyPath string) (*tls.Config, error) {
 	}
 
 	// Load server's certificate and private key.
-	serverCert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parsing leaf certificate.
+	if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+		return nil, err
+	}
+
+	return &material{cert: &cert, pool: certPool}, nil
+}
+
+// Getting server TLS config. Certificate and client CA pool are taken from the reloader on
+// every handshake.
+func (r *Reloader) ServerConfig(p Policy) (*tls.Config, error) {
+	parsed, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	// Creating a new server config with current material.
+	config := func() *tls.Config {
+		m := r.material()
+
+		return &tls.Config{
+			Certificates: []tls.Certificate{*m.cert},
+			ClientAuth:   parsed.clientAuth,
+			ClientCAs:    m.pool,
+			MinVersion:   parsed.minVersion,
+			MaxVersion:   parsed.maxVersion,
+			CipherSuites: parsed.cipherSuites,
+			NextProtos:   []string{"h2"},
+		}
+	}
+
+	base := config()
+	base.Certificates = nil
+	base.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		return r.material().cert, nil
+	}
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		return config(), nil
+	}
+
+	return base, nil
+}
+
+// Getting client TLS config. Client certificate and root CA pool are taken from the reloader
+// on every handshake.
+func (r *Reloader) ClientConfig(p Policy) (*tls.Config, error) {
+	parsed, err := p.parse()
 	if err != nil {
 		return nil, err
 	}
 
 	return &tls.Config{
-		Certificates: []tls.Certificate{serverCert},
-		ClientAuth:   tls.RequireAndVerifyClientCert,
-		ClientCAs:    certPool,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.material().cert, nil
+		},
+		ServerName:   p.ServerName,
+		MinVersion:   parsed.minVersion,
+		MaxVersion:   parsed.maxVersion,
+		CipherSuites: parsed.cipherSuites,
+		// Default verification uses a fixed RootCAs pool, the server chain is verified in
+		// VerifyConnection against the current pool instead.
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			return r.verifyServer(cs)
+		},
 	}, nil
 }
+
+// Verifying server certificate chain and name against the current root CA pool.
+func (r *Reloader) verifyServer(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("server certificate is not presented")
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         r.material().pool,
+		DNSName:       cs.ServerName,
+		Intermediates: x509.NewCertPool(),
+	}
+
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+
+	_, err := cs.PeerCertificates[0].Verify(opts)
+
+	return err
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package tls
+
+import (
+	"crypto/tls"
+	"fmt"
+)
+
+// Client authentication modes.
+const (
+	// Client certificate is not requested.
+	ClientAuthNone string = "none"
+	// Client certificate is requested and verified when presented.
+	ClientAuthRequest string = "request"
+	// Client certificate is required and verified.
+	ClientAuthRequireAndVerify string = "require-and-verify"
+)
+
+// Default minimum TLS version.
+const defaultMinVersion uint16 = tls.VersionTLS12
+
+// TLS versions by name.
+var versions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLS connection policy. Empty values use secure defaults: TLS 1.2 minimum version, Go
+// default cipher suites and required client certificates.
+type Policy struct {
+	// Minimum and maximum TLS versions, e.g. "1.2" or "1.3".
+	MinVersion, MaxVersion string
+	// Cipher suite names, e.g. "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256". Cipher suites are
+	// not configurable for TLS 1.3.
+	CipherSuites []string
+	// Server client authentication mode: none, request or require-and-verify.
+	ClientAuth string
+	// Client server name override used for SNI and certificate verification.
+	ServerName string
+}
+
+// Parsed TLS connection policy.
+type policy struct {
+	minVersion, maxVersion uint16
+	cipherSuites           []uint16
+	clientAuth             tls.ClientAuthType
+}
+
+// Parsing TLS connection policy.
+func (p Policy) parse() (policy, error) {
+	parsed := policy{minVersion: defaultMinVersion, clientAuth: tls.RequireAndVerifyClientCert}
+
+	// Parsing TLS versions.
+	if p.MinVersion != "" {
+		v, ok := versions[p.MinVersion]
+		if !ok {
+			return policy{}, fmt.Errorf("unknown TLS min version: %s", p.MinVersion)
+		}
+
+		parsed.minVersion = v
+	}
+
+	if p.MaxVersion != "" {
+		v, ok := versions[p.MaxVersion]
+		if !ok {
+			return policy{}, fmt.Errorf("unknown TLS max version: %s", p.MaxVersion)
+		}
+
+		if v < parsed.minVersion {
+			return policy{}, fmt.Errorf("TLS max version %s is lower than min version", p.MaxVersion)
+		}
+
+		parsed.maxVersion = v
+	}
+
+	// Parsing cipher suites, insecure suites are rejected.
+	if len(p.CipherSuites) != 0 {
+		suites := make(map[string]uint16, len(tls.CipherSuites()))
+		for _, suite := range tls.CipherSuites() {
+			suites[suite.Name] = suite.ID
+		}
+
+		for _, name := range p.CipherSuites {
+			id, ok := suites[name]
+			if !ok {
+				return policy{}, fmt.Errorf("unknown or insecure cipher suite: %s", name)
+			}
+
+			parsed.cipherSuites = append(parsed.cipherSuites, id)
+		}
+	}
+
+	// Parsing client authentication mode.
+	switch p.ClientAuth {
+	case "", ClientAuthRequireAndVerify:
+		parsed.clientAuth = tls.RequireAndVerifyClientCert
+	case ClientAuthRequest:
+		parsed.clientAuth = tls.VerifyClientCertIfGiven
+	case ClientAuthNone:
+		parsed.clientAuth = tls.NoClientCert
+	default:
+		return policy{}, fmt.Errorf("unknown client auth mode: %s", p.ClientAuth)
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package tls_test
+
+import (
+	stdtls "crypto/tls"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/pkg/tls"
+)
+
+// Creating a new reloader with generated credentials.
+func newTestReloader(t *testing.T) *tls.Reloader {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeCertificate(t, dir, time.Now().Add(time.Hour))
+
+	r, err := tls.NewReloader(
+		filepath.Join(dir, "ca.pem"), filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), nil,
+	)
+	if err != nil {
+		t.Fatalf("error creating reloader: %s", err.Error())
+	}
+
+	t.Cleanup(func() { r.Close() })
+
+	return r
+}
+
+// Testing server TLS policy.
+func TestReloader_ServerConfig(t *testing.T) {
+	r := newTestReloader(t)
+
+	// Tests structures.
+	tests := []struct {
+		name    string
+		policy  tls.Policy
+		want    stdtls.ClientAuthType
+		wantErr bool
+	}{
+		{
+			name:   "Default",
+			policy: tls.Policy{},
+			want:   stdtls.RequireAndVerifyClientCert,
+		},
+		{
+			name:   "Request client certificate",
+			policy: tls.Policy{MinVersion: "1.3", ClientAuth: tls.ClientAuthRequest},
+			want:   stdtls.VerifyClientCertIfGiven,
+		},
+		{
+			name:   "No client certificate",
+			policy: tls.Policy{ClientAuth: tls.ClientAuthNone},
+			want:   stdtls.NoClientCert,
+		},
+		{
+			name:    "Unknown client auth mode",
+			policy:  tls.Policy{ClientAuth: "optional"},
+			wantErr: true,
+		},
+		{
+			name:    "Max version lower than min version",
+			policy:  tls.Policy{MinVersion: "1.3", MaxVersion: "1.2"},
+			wantErr: true,
+		},
+		{
+			name:    "Insecure cipher suite",
+			policy:  tls.Policy{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}},
+			wantErr: true,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := r.ServerConfig(tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error server config: %v", err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			handshake, err := config.GetConfigForClient(nil)
+			if err != nil {
+				t.Fatalf("error getting config for client: %s", err.Error())
+			}
+
+			// Check client authentication mode.
+			if handshake.ClientAuth != tt.want {
+				t.Errorf("error client auth: got %s, want %s", handshake.ClientAuth, tt.want)
+			}
+
+			// Check minimum version.
+			if handshake.MinVersion < stdtls.VersionTLS12 {
+				t.Errorf("error min version: got %x", handshake.MinVersion)
+			}
+		})
+	}
+}
+
+// Testing mutual TLS handshake between server and client configs.
+func TestReloader_Handshake(t *testing.T) {
+	r := newTestReloader(t)
+
+	// Tests structures.
+	tests := []struct {
+		name       string
+		serverName string
+		wantErr    bool
+	}{
+		{name: "OK", serverName: "auth.service.durudex.local"},
+		{name: "Server name mismatch", serverName: "user.service.durudex.local", wantErr: true},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverConfig, err := r.ServerConfig(tls.Policy{MinVersion: "1.2"})
+			if err != nil {
+				t.Fatalf("error server config: %s", err.Error())
+			}
+
+			clientConfig, err := r.ClientConfig(tls.Policy{MinVersion: "1.2", ServerName: tt.serverName})
+			if err != nil {
+				t.Fatalf("error client config: %s", err.Error())
+			}
+
+			serverConn, clientConn := net.Pipe()
+			defer serverConn.Close()
+			defer clientConn.Close()
+
+			serverErr := make(chan error, 1)
+
+			go func() {
+				serverErr <- stdtls.Server(serverConn, serverConfig).Handshake()
+				serverConn.Close()
+			}()
+
+			err = stdtls.Client(clientConn, clientConfig).Handshake()
+			clientConn.Close()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error client handshake: %v", err)
+			}
+
+			if err := <-serverErr; !tt.wantErr && err != nil {
+				t.Errorf("error server handshake: %s", err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package tls
+
+import (
+	"crypto/x509"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+)
+
+// Delay between a file change and reload, multiple writes are reloaded once.
+const reloadDelay time.Duration = time.Millisecond * 100
+
+// Function called with the leaf certificate after every successful load.
+type ReloadFunc func(leaf *x509.Certificate)
+
+// TLS credentials reloader. Watches CA, certificate and key files and atomically replaces
+// loaded material. When reload fails the previous material keeps being used.
+type Reloader struct {
+	caCertPath, certPath, keyPath string
+	onReload                      ReloadFunc
+	current                       atomic.Value
+	watcher                       *fsnotify.Watcher
+	done                          chan struct{}
+}
+
+// Creating a new TLS credentials reloader. Files are loaded once before watching.
+func NewReloader(caCertPath, certPath, keyPath string, onReload ReloadFunc) (*Reloader, error) {
+	r := &Reloader{
+		caCertPath: caCertPath,
+		certPath:   certPath,
+		keyPath:    keyPath,
+		onReload:   onReload,
+		done:       make(chan struct{}),
+	}
+
+	// Loading initial material.
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	// Watching files directories, mounted secrets are replaced by swapping symlinks.
+	dirs := make(map[string]struct{}, 3)
+	for _, path := range []string{caCertPath, certPath, keyPath} {
+		dirs[filepath.Dir(path)] = struct{}{}
+	}
+
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return nil, err
+		}
+	}
+
+	r.watcher = watcher
+
+	go r.watch()
+
+	return r, nil
+}
+
+// Reloading TLS credentials material.
+func (r *Reloader) Reload() error {
+	m, err := loadMaterial(r.caCertPath, r.certPath, r.keyPath)
+	if err != nil {
+		return err
+	}
+
+	r.current.Store(m)
+
+	if r.onReload != nil {
+		r.onReload(m.cert.Leaf)
+	}
+
+	return nil
+}
+
+// Getting certificate expiration time.
+func (r *Reloader) NotAfter() time.Time {
+	return r.material().cert.Leaf.NotAfter
+}
+
+// Stopping watching files.
+func (r *Reloader) Close() error {
+	close(r.done)
+	return r.watcher.Close()
+}
+
+// Getting current TLS credentials material.
+func (r *Reloader) material() *material {
+	return r.current.Load().(*material)
+}
+
+// Watching file events.
+func (r *Reloader) watch() {
+	timer := time.NewTimer(reloadDelay)
+	timer.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			timer.Stop()
+			return
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+
+			if r.changed(event.Name) {
+				timer.Reset(reloadDelay)
+			}
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+
+			log.Error().Err(err).Msg("error watching TLS credentials files")
+		case <-timer.C:
+			if err := r.Reload(); err != nil {
+				log.Error().Err(err).Str("cert", r.certPath).Msg("failed to reload TLS credentials, previous credentials are used")
+				continue
+			}
+
+			log.Info().Str("cert", r.certPath).Time("not_after", r.NotAfter()).Msg("TLS credentials reloaded")
+		}
+	}
+}
+
+// Checking is the changed file affects watched credentials.
+func (r *Reloader) changed(name string) bool {
+	name = filepath.Clean(name)
+
+	// Kubernetes secret volume data directory swap.
+	if strings.HasPrefix(filepath.Base(name), "..") {
+		return true
+	}
+
+	for _, path := range []string{r.caCertPath, r.certPath, r.keyPath} {
+		if name == filepath.Clean(path) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package tls_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/pkg/tls"
+)
+
+// Writing a new self-signed certificate with expiration time to the files.
+func writeCertificate(t *testing.T, dir string, notAfter time.Time) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating key: %s", err.Error())
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(notAfter.Unix()),
+		Subject:               pkix.Name{CommonName: "auth.service.durudex.local"},
+		DNSNames:              []string{"auth.service.durudex.local"},
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error creating certificate: %s", err.Error())
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("error marshaling key: %s", err.Error())
+	}
+
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+	for name, data := range map[string][]byte{"ca.pem": certPem, "cert.pem": certPem, "key.pem": keyPem} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatalf("error writing file: %s", err.Error())
+		}
+	}
+}
+
+// Waiting for the reloader certificate expiration time.
+func waitNotAfter(r *tls.Reloader, want time.Time) bool {
+	deadline := time.Now().Add(time.Second * 5)
+
+	for time.Now().Before(deadline) {
+		if r.NotAfter().Equal(want) {
+			return true
+		}
+
+		time.Sleep(time.Millisecond * 20)
+	}
+
+	return false
+}
+
+// Testing TLS credentials hot reload.
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+
+	first := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	writeCertificate(t, dir, first)
+
+	var observed time.Time
+
+	// Creating a new reloader.
+	r, err := tls.NewReloader(
+		filepath.Join(dir, "ca.pem"), filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"),
+		func(leaf *x509.Certificate) { observed = leaf.NotAfter },
+	)
+	if err != nil {
+		t.Fatalf("error creating reloader: %s", err.Error())
+	}
+	defer r.Close()
+
+	// Check initial certificate.
+	if !r.NotAfter().Equal(first) || !observed.Equal(first) {
+		t.Fatalf("error initial certificate expiry: got %s, want %s", r.NotAfter(), first)
+	}
+
+	// Rotating certificate files.
+	second := first.Add(time.Hour)
+	writeCertificate(t, dir, second)
+
+	if !waitNotAfter(r, second) {
+		t.Fatalf("error reloaded certificate expiry: got %s, want %s", r.NotAfter(), second)
+	}
+
+	// Check handshake config uses reloaded certificate.
+	server, err := r.ServerConfig(tls.Policy{})
+	if err != nil {
+		t.Fatalf("error getting server config: %s", err.Error())
+	}
+
+	config, err := server.GetConfigForClient(nil)
+	if err != nil {
+		t.Fatalf("error getting config for client: %s", err.Error())
+	}
+
+	if !config.Certificates[0].Leaf.NotAfter.Equal(second) {
+		t.Errorf("error handshake certificate expiry: got %s, want %s", config.Certificates[0].Leaf.NotAfter, second)
+	}
+
+	// Writing invalid certificate, previous credentials must be used.
+	if err := os.WriteFile(filepath.Join(dir, "cert.pem"), []byte("invalid"), 0o600); err != nil {
+		t.Fatalf("error writing file: %s", err.Error())
+	}
+
+	time.Sleep(time.Millisecond * 300)
+
+	if !r.NotAfter().Equal(second) {
+		t.Errorf("error certificate after failed reload: got %s, want %s", r.NotAfter(), second)
+	}
+}
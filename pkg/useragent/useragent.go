@@ -0,0 +1,182 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package useragent
+
+import "strings"
+
+// Client device types.
+const (
+	DeviceUnknown string = "unknown"
+	DeviceDesktop string = "desktop"
+	DeviceMobile  string = "mobile"
+	DeviceTablet  string = "tablet"
+	DeviceBot     string = "bot"
+)
+
+// Maximum device name length in bytes.
+const MaxNameLength = 255
+
+// Device types.
+var DeviceTypes = []string{DeviceUnknown, DeviceDesktop, DeviceMobile, DeviceTablet, DeviceBot}
+
+// Client device structure.
+type Device struct {
+	// Device type.
+	Type string
+	// Human readable device name, e.g. "Chrome on Android".
+	Name string
+}
+
+// Rule matching the user agent token.
+type rule struct {
+	token string
+	name  string
+}
+
+var (
+	// Bot user agent tokens.
+	bots = []string{"bot", "crawler", "spider", "curl/", "wget/", "python-requests"}
+
+	// Operating system rules, more specific tokens go first.
+	systems = []rule{
+		{token: "iPhone", name: "iPhone"},
+		{token: "iPad", name: "iPad"},
+		{token: "Android", name: "Android"},
+		{token: "CrOS", name: "ChromeOS"},
+		{token: "Windows", name: "Windows"},
+		{token: "Macintosh", name: "macOS"},
+		{token: "Linux", name: "Linux"},
+	}
+
+	// Browser rules, more specific tokens go first.
+	browsers = []rule{
+		{token: "Edg/", name: "Edge"},
+		{token: "OPR/", name: "Opera"},
+		{token: "SamsungBrowser/", name: "Samsung Internet"},
+		{token: "FxiOS/", name: "Firefox"},
+		{token: "Firefox/", name: "Firefox"},
+		{token: "CriOS/", name: "Chrome"},
+		{token: "Chrome/", name: "Chrome"},
+		{token: "Safari/", name: "Safari"},
+	}
+)
+
+// Parsing the client device from the user agent.
+func Parse(ua string) Device {
+	if strings.TrimSpace(ua) == "" {
+		return Device{Type: DeviceUnknown}
+	}
+
+	lower := strings.ToLower(ua)
+
+	for _, token := range bots {
+		if strings.Contains(lower, token) {
+			return Device{Type: DeviceBot, Name: botName(ua, token)}
+		}
+	}
+
+	system := match(ua, systems)
+	browser := match(ua, browsers)
+
+	device := Device{Type: deviceType(ua, system)}
+
+	switch {
+	case browser != "" && system != "":
+		device.Name = browser + " on " + system
+	case browser != "":
+		device.Name = browser
+	case system != "":
+		device.Name = system
+	default:
+		device.Name = product(ua)
+	}
+
+	return device
+}
+
+// Getting the first matched rule name.
+func match(ua string, rules []rule) string {
+	for _, r := range rules {
+		if strings.Contains(ua, r.token) {
+			return r.name
+		}
+	}
+
+	return ""
+}
+
+// Getting the device type by operating system.
+func deviceType(ua, system string) string {
+	switch system {
+	case "iPhone":
+		return DeviceMobile
+	case "iPad":
+		return DeviceTablet
+	case "Android":
+		// Android tablets do not send the mobile token.
+		if strings.Contains(ua, "Mobile") {
+			return DeviceMobile
+		}
+
+		return DeviceTablet
+	case "Windows", "macOS", "Linux", "ChromeOS":
+		return DeviceDesktop
+	default:
+		return DeviceUnknown
+	}
+}
+
+// Getting the user agent product name without version, e.g. "grpc-go".
+func product(ua string) string {
+	name := strings.Fields(ua)[0]
+
+	if i := strings.IndexByte(name, '/'); i != -1 {
+		name = name[:i]
+	}
+
+	// Truncating the name on the rune boundary.
+	if len(name) > MaxNameLength {
+		name = strings.ToValidUTF8(name[:MaxNameLength], "")
+	}
+
+	return name
+}
+
+// Getting the bot product name containing the token, e.g. "Googlebot".
+func botName(ua, token string) string {
+	words := strings.FieldsFunc(ua, func(r rune) bool { return strings.ContainsRune(" ;()+", r) })
+
+	for _, word := range words {
+		if strings.Contains(strings.ToLower(word), token) {
+			return product(word)
+		}
+	}
+
+	return product(ua)
+}
+
+// Checking is the device type known.
+func IsDeviceType(deviceType string) bool {
+	for _, t := range DeviceTypes {
+		if t == deviceType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package useragent_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/durudex/durudex-auth-service/pkg/useragent"
+)
+
+// Testing parsing the client device from the user agent.
+func TestParse(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name string
+		ua   string
+		want useragent.Device
+	}{
+		{
+			name: "Android phone",
+			ua:   "Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 Mobile Safari/537.36",
+			want: useragent.Device{Type: useragent.DeviceMobile, Name: "Chrome on Android"},
+		},
+		{
+			name: "Android tablet",
+			ua:   "Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 Safari/537.36",
+			want: useragent.Device{Type: useragent.DeviceTablet, Name: "Chrome on Android"},
+		},
+		{
+			name: "iPhone",
+			ua:   "Mozilla/5.0 (iPhone; CPU iPhone OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.6 Mobile/15E148 Safari/604.1",
+			want: useragent.Device{Type: useragent.DeviceMobile, Name: "Safari on iPhone"},
+		},
+		{
+			name: "Windows Edge",
+			ua:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 Safari/537.36 Edg/116.0.1938.69",
+			want: useragent.Device{Type: useragent.DeviceDesktop, Name: "Edge on Windows"},
+		},
+		{
+			name: "macOS Firefox",
+			ua:   "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:109.0) Gecko/20100101 Firefox/117.0",
+			want: useragent.Device{Type: useragent.DeviceDesktop, Name: "Firefox on macOS"},
+		},
+		{
+			name: "Bot",
+			ua:   "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			want: useragent.Device{Type: useragent.DeviceBot, Name: "Googlebot"},
+		},
+		{
+			name: "Client library",
+			ua:   "grpc-go/1.45.0",
+			want: useragent.Device{Type: useragent.DeviceUnknown, Name: "grpc-go"},
+		},
+		{
+			name: "Long product",
+			ua:   strings.Repeat("a", 300) + "/1.0",
+			want: useragent.Device{Type: useragent.DeviceUnknown, Name: strings.Repeat("a", useragent.MaxNameLength)},
+		},
+		{
+			name: "Empty",
+			want: useragent.Device{Type: useragent.DeviceUnknown},
+		},
+		{
+			name: "Blank",
+			ua:   "  ",
+			want: useragent.Device{Type: useragent.DeviceUnknown},
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := useragent.Parse(tt.ua); got != tt.want {
+				t.Errorf("error device: got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package webhook
+
+import "time"
+
+// Webhook request body of the auth domain event.
+type Payload struct {
+	// Event id, ids are ordered by the occurrence time.
+	Id string `json:"id"`
+	// Event type, e.g. "session_created".
+	Type string `json:"type"`
+	// Event schema version.
+	Version int32 `json:"version"`
+	// Event occurred at.
+	OccurredAt time.Time `json:"occurred_at"`
+	// Event data, fields are set by the event type.
+	Data PayloadData `json:"data"`
+}
+
+// Webhook auth domain event data.
+type PayloadData struct {
+	UserId     string `json:"user_id,omitempty"`
+	SessionId  string `json:"session_id,omitempty"`
+	Ip         string `json:"ip,omitempty"`
+	UserAgent  string `json:"user_agent,omitempty"`
+	DeviceType string `json:"device_type,omitempty"`
+	DeviceName string `json:"device_name,omitempty"`
+	Method     string `json:"method,omitempty"`
+}
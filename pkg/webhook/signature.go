@@ -0,0 +1,106 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Webhook request headers.
+const (
+	// Delivery signature header, e.g. "t=1657152000,v1=5257a869...".
+	SignatureHeader string = "X-Durudex-Signature"
+	// Auth domain event type header.
+	EventHeader string = "X-Durudex-Event"
+	// Delivery id header, retried deliveries have the same id.
+	DeliveryHeader string = "X-Durudex-Delivery"
+)
+
+// Default maximum age of the signed delivery accepted by the receiver.
+const DefaultTolerance time.Duration = time.Minute * 5
+
+// Signature verification errors.
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrExpiredSignature = errors.New("webhook signature timestamp is outside the tolerance")
+)
+
+// Signing the request body with the timestamp. Signature is the hex HMAC-SHA256 of the
+// "<unix timestamp>.<body>" string, the header value is "t=<unix timestamp>,v1=<signature>".
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+
+	return "t=" + unix + ",v1=" + signature(secret, unix, body)
+}
+
+// Verifying the signature header of the request body. Signatures older or newer than the
+// tolerance are rejected to prevent replay.
+func Verify(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var unix string
+	var signatures []string
+
+	// Parsing signature header parts.
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return ErrInvalidSignature
+		}
+
+		switch key {
+		case "t":
+			unix = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	timestamp, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	// Checking signature timestamp age.
+	if age := now.Sub(time.Unix(timestamp, 0)); age > tolerance || age < -tolerance {
+		return ErrExpiredSignature
+	}
+
+	expected := signature(secret, unix, body)
+
+	for _, s := range signatures {
+		if hmac.Equal([]byte(s), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
+
+// Getting the hex HMAC-SHA256 signature of the timestamp and body.
+func signature(secret, unix string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix + "."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
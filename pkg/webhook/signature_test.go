@@ -0,0 +1,90 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package webhook_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/durudex/durudex-auth-service/pkg/webhook"
+)
+
+// Testing verifying a webhook delivery signature.
+func Test_Verify(t *testing.T) {
+	now := time.Now()
+	body := []byte(`{"type":"session_created"}`)
+
+	// Testing args.
+	type args struct {
+		secret string
+		header string
+		body   []byte
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "OK",
+			args: args{secret: "secret", header: webhook.Sign("secret", now, body), body: body},
+		},
+		{
+			name: "Multiple Signatures",
+			args: args{
+				secret: "secret",
+				header: webhook.Sign("secret", now, body) + ",v1=00",
+				body:   body,
+			},
+		},
+		{
+			name:    "Invalid Secret",
+			args:    args{secret: "invalid", header: webhook.Sign("secret", now, body), body: body},
+			wantErr: webhook.ErrInvalidSignature,
+		},
+		{
+			name:    "Modified Body",
+			args:    args{secret: "secret", header: webhook.Sign("secret", now, body), body: []byte("{}")},
+			wantErr: webhook.ErrInvalidSignature,
+		},
+		{
+			name:    "Replayed",
+			args:    args{secret: "secret", header: webhook.Sign("secret", now.Add(-time.Hour), body), body: body},
+			wantErr: webhook.ErrExpiredSignature,
+		},
+		{
+			name:    "Malformed Header",
+			args:    args{secret: "secret", header: "invalid", body: body},
+			wantErr: webhook.ErrInvalidSignature,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Verifying webhook signature.
+			err := webhook.Verify(tt.args.secret, tt.args.header, tt.args.body, now, webhook.DefaultTolerance)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error verifying signature: got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
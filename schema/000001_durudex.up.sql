@@ -21,5 +21,5 @@ CREATE TABLE IF NOT EXISTS user_session (
   payload    CHAR(64)  NOT NULL,
   ip         INET      NOT NULL,
   expires_in TIMESTAMP NOT NULL,
-  CONSTRAINT user_session_pkey PRIMARY KEY (user_id DESC, id DESC)
+  CONSTRAINT user_session_pkey PRIMARY KEY (user_id, id)
 );
@@ -0,0 +1,30 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+CREATE TABLE IF NOT EXISTS email_outbox (
+  id         CHAR(27)    NOT NULL,
+  kind       VARCHAR(32) NOT NULL,
+  payload    JSONB       NOT NULL,
+  attempts   INTEGER     NOT NULL DEFAULT 0,
+  next_at    TIMESTAMP   NOT NULL DEFAULT now(),
+  last_error TEXT,
+  dead_at    TIMESTAMP,
+  created_at TIMESTAMP   NOT NULL DEFAULT now(),
+  CONSTRAINT email_outbox_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS email_outbox_pending_idx ON email_outbox (next_at) WHERE dead_at IS NULL;
@@ -0,0 +1,33 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+CREATE TABLE IF NOT EXISTS sign_up_saga (
+  id         CHAR(27)     NOT NULL,
+  step       VARCHAR(32)  NOT NULL,
+  user_id    CHAR(27),
+  email      VARCHAR(255) NOT NULL,
+  username   VARCHAR(40)  NOT NULL,
+  ip         INET         NOT NULL,
+  attempts   INTEGER      NOT NULL DEFAULT 0,
+  last_error TEXT,
+  created_at TIMESTAMP    NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP    NOT NULL DEFAULT now(),
+  CONSTRAINT sign_up_saga_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS sign_up_saga_pending_idx ON sign_up_saga (updated_at)
+  WHERE step NOT IN ('completed', 'compensated', 'failed');
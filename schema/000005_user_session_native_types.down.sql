@@ -0,0 +1,53 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+-- Encoding 20 KSUID bytes into a base62 string.
+CREATE OR REPLACE FUNCTION ksuid_encode_base62(b BYTEA) RETURNS TEXT AS $$
+DECLARE
+  alphabet CONSTANT TEXT := '0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz';
+  n NUMERIC := 0;
+  s TEXT := '';
+BEGIN
+  FOR i IN 0..octet_length(b) - 1 LOOP
+    n := n * 256 + get_byte(b, i);
+  END LOOP;
+
+  FOR i IN 1..27 LOOP
+    s := substr(alphabet, mod(n, 62)::INTEGER + 1, 1) || s;
+    n := div(n, 62);
+  END LOOP;
+
+  RETURN s;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE STRICT;
+
+DROP INDEX IF EXISTS user_session_expires_in_idx;
+
+ALTER TABLE user_session
+  DROP CONSTRAINT user_session_pkey,
+  DROP CONSTRAINT user_session_id_length,
+  DROP CONSTRAINT user_session_payload_length;
+
+ALTER TABLE user_session
+  ALTER COLUMN id         TYPE CHAR(27)  USING ksuid_encode_base62(id),
+  ALTER COLUMN user_id    TYPE CHAR(27)  USING ksuid_encode_base62(user_id),
+  ALTER COLUMN payload    TYPE CHAR(64)  USING encode(payload, 'hex'),
+  ALTER COLUMN expires_in TYPE TIMESTAMP USING expires_in AT TIME ZONE 'UTC';
+
+ALTER TABLE user_session ADD CONSTRAINT user_session_pkey PRIMARY KEY (user_id, id);
+
+DROP FUNCTION ksuid_encode_base62(BYTEA);
@@ -0,0 +1,53 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+-- Decoding a base62 string encoded KSUID into 20 bytes.
+CREATE OR REPLACE FUNCTION ksuid_decode_base62(s TEXT) RETURNS BYTEA AS $$
+DECLARE
+  alphabet CONSTANT TEXT := '0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz';
+  n NUMERIC := 0;
+  b BYTEA := '\x0000000000000000000000000000000000000000';
+BEGIN
+  FOR i IN 1..length(s) LOOP
+    n := n * 62 + strpos(alphabet, substr(s, i, 1)) - 1;
+  END LOOP;
+
+  FOR i IN REVERSE 19..0 LOOP
+    b := set_byte(b, i, mod(n, 256)::INTEGER);
+    n := div(n, 256);
+  END LOOP;
+
+  RETURN b;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE STRICT;
+
+ALTER TABLE user_session DROP CONSTRAINT user_session_pkey;
+
+ALTER TABLE user_session
+  ALTER COLUMN id         TYPE BYTEA       USING ksuid_decode_base62(id),
+  ALTER COLUMN user_id    TYPE BYTEA       USING ksuid_decode_base62(user_id),
+  ALTER COLUMN payload    TYPE BYTEA       USING decode(payload, 'hex'),
+  ALTER COLUMN expires_in TYPE TIMESTAMPTZ USING expires_in AT TIME ZONE 'UTC';
+
+ALTER TABLE user_session
+  ADD CONSTRAINT user_session_pkey PRIMARY KEY (user_id, id),
+  ADD CONSTRAINT user_session_id_length CHECK (octet_length(id) = 20 AND octet_length(user_id) = 20),
+  ADD CONSTRAINT user_session_payload_length CHECK (octet_length(payload) = 32);
+
+CREATE INDEX IF NOT EXISTS user_session_expires_in_idx ON user_session (expires_in);
+
+DROP FUNCTION ksuid_decode_base62(TEXT);
@@ -0,0 +1,27 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE user_session
+  ADD COLUMN device_type VARCHAR(16)  NOT NULL DEFAULT 'unknown',
+  ADD COLUMN device_name VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS user_session_user_expires_in_idx ON user_session (user_id, expires_in);
+CREATE INDEX IF NOT EXISTS user_session_user_device_type_idx ON user_session (user_id, device_type);
+CREATE INDEX IF NOT EXISTS user_session_ip_idx ON user_session USING gist (ip inet_ops);
+CREATE INDEX IF NOT EXISTS user_session_device_name_idx ON user_session USING gin (device_name gin_trgm_ops);
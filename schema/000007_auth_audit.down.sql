@@ -0,0 +1,23 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+DROP FUNCTION IF EXISTS auth_audit_drop_partitions(TIMESTAMPTZ);
+DROP FUNCTION IF EXISTS auth_audit_create_partitions(TIMESTAMPTZ, TIMESTAMPTZ);
+
+DROP TABLE IF EXISTS auth_audit;
+
+DROP FUNCTION IF EXISTS auth_audit_reject_update();
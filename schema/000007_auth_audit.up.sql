@@ -0,0 +1,94 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+CREATE TABLE IF NOT EXISTS auth_audit (
+  id         BYTEA        NOT NULL,
+  kind       VARCHAR(32)  NOT NULL,
+  actor      VARCHAR(255) NOT NULL,
+  subject    VARCHAR(255) NOT NULL,
+  session_id BYTEA,
+  ip         INET,
+  user_agent VARCHAR(512) NOT NULL DEFAULT '',
+  outcome    VARCHAR(16)  NOT NULL,
+  reason     TEXT         NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ  NOT NULL,
+  CONSTRAINT auth_audit_pkey PRIMARY KEY (created_at, id),
+  CONSTRAINT auth_audit_id_length CHECK (octet_length(id) = 20 AND octet_length(session_id) = 20)
+) PARTITION BY RANGE (created_at);
+
+-- Events without a monthly partition are kept in the default partition.
+CREATE TABLE IF NOT EXISTS auth_audit_default PARTITION OF auth_audit DEFAULT;
+
+CREATE INDEX IF NOT EXISTS auth_audit_id_idx ON auth_audit (id);
+CREATE INDEX IF NOT EXISTS auth_audit_subject_idx ON auth_audit (subject, id);
+
+-- Rejecting changes of the recorded events.
+CREATE OR REPLACE FUNCTION auth_audit_reject_update() RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'auth audit events are append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER auth_audit_reject_update BEFORE UPDATE ON auth_audit
+  FOR EACH ROW EXECUTE FUNCTION auth_audit_reject_update();
+
+-- Creating missing monthly partitions for events created from the start until the end time.
+CREATE OR REPLACE FUNCTION auth_audit_create_partitions(start_at TIMESTAMPTZ, end_at TIMESTAMPTZ)
+RETURNS INTEGER AS $$
+DECLARE
+  period  TIMESTAMP := date_trunc('month', start_at AT TIME ZONE 'UTC');
+  part    TEXT;
+  created INTEGER := 0;
+BEGIN
+  WHILE period AT TIME ZONE 'UTC' < end_at LOOP
+    part := 'auth_audit_p' || to_char(period, 'YYYYMM');
+
+    IF to_regclass(part) IS NULL THEN
+      EXECUTE format('CREATE TABLE %I PARTITION OF auth_audit FOR VALUES FROM (%L) TO (%L)',
+        part, period AT TIME ZONE 'UTC', (period + INTERVAL '1 month') AT TIME ZONE 'UTC');
+      created := created + 1;
+    END IF;
+
+    period := period + INTERVAL '1 month';
+  END LOOP;
+
+  RETURN created;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Dropping monthly partitions with events created before the time and deleting such events
+-- from the default partition.
+CREATE OR REPLACE FUNCTION auth_audit_drop_partitions(end_at TIMESTAMPTZ) RETURNS INTEGER AS $$
+DECLARE
+  part    RECORD;
+  dropped INTEGER := 0;
+BEGIN
+  FOR part IN
+    SELECT c.relname FROM pg_inherits i JOIN pg_class c ON c.oid = i.inhrelid
+    WHERE i.inhparent = 'auth_audit'::regclass AND c.relname ~ '^auth_audit_p[0-9]{6}$'
+  LOOP
+    IF (to_date(substr(part.relname, 13), 'YYYYMM') + INTERVAL '1 month') AT TIME ZONE 'UTC' <= end_at THEN
+      EXECUTE format('DROP TABLE %I', part.relname);
+      dropped := dropped + 1;
+    END IF;
+  END LOOP;
+
+  DELETE FROM auth_audit_default WHERE created_at < end_at;
+
+  RETURN dropped;
+END;
+$$ LANGUAGE plpgsql;
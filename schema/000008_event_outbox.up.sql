@@ -0,0 +1,30 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+CREATE TABLE IF NOT EXISTS event_outbox (
+  id         BYTEA       NOT NULL,
+  seq        BIGINT      GENERATED ALWAYS AS IDENTITY,
+  subject    TEXT        NOT NULL,
+  payload    BYTEA       NOT NULL,
+  attempts   INTEGER     NOT NULL DEFAULT 0,
+  next_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+  last_error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT event_outbox_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS event_outbox_next_at_idx ON event_outbox (next_at, seq);
@@ -0,0 +1,47 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+CREATE TABLE IF NOT EXISTS webhook (
+  id          BYTEA         NOT NULL,
+  url         VARCHAR(2048) NOT NULL,
+  event_types VARCHAR(32)[] NOT NULL,
+  secret      VARCHAR(256)  NOT NULL,
+  enabled     BOOLEAN       NOT NULL DEFAULT true,
+  failures    INTEGER       NOT NULL DEFAULT 0,
+  created_at  TIMESTAMPTZ   NOT NULL DEFAULT now(),
+  disabled_at TIMESTAMPTZ,
+  CONSTRAINT webhook_pkey PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+  id            BYTEA       NOT NULL,
+  webhook_id    BYTEA       NOT NULL,
+  event_id      BYTEA       NOT NULL,
+  event_type    VARCHAR(32) NOT NULL,
+  payload       BYTEA       NOT NULL,
+  status        VARCHAR(16) NOT NULL DEFAULT 'pending',
+  attempts      INTEGER     NOT NULL DEFAULT 0,
+  response_code INTEGER     NOT NULL DEFAULT 0,
+  last_error    TEXT        NOT NULL DEFAULT '',
+  next_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+  created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT webhook_delivery_pkey PRIMARY KEY (id),
+  CONSTRAINT webhook_delivery_webhook_fkey FOREIGN KEY (webhook_id) REFERENCES webhook (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_pending_idx ON webhook_delivery (next_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS webhook_delivery_webhook_idx ON webhook_delivery (webhook_id, id);
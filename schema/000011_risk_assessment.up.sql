@@ -0,0 +1,32 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+CREATE TABLE IF NOT EXISTS risk_assessment (
+  id         BYTEA        NOT NULL,
+  user_id    BYTEA        NOT NULL,
+  session_id BYTEA        NOT NULL,
+  ip         INET,
+  user_agent VARCHAR(512) NOT NULL DEFAULT '',
+  score      INTEGER      NOT NULL,
+  decision   VARCHAR(16)  NOT NULL,
+  signals    JSONB        NOT NULL DEFAULT '[]',
+  created_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+  CONSTRAINT risk_assessment_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS risk_assessment_user_idx ON risk_assessment (user_id, id);
+CREATE INDEX IF NOT EXISTS risk_assessment_decision_idx ON risk_assessment (decision, created_at);
@@ -0,0 +1,28 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+ALTER TABLE user_session
+  ADD COLUMN auth_level   SMALLINT    NOT NULL DEFAULT 1,
+  ADD COLUMN auth_methods TEXT[]      NOT NULL DEFAULT '{}',
+  ADD COLUMN auth_time    TIMESTAMPTZ;
+
+-- Existing sessions were authenticated when they were created, the KSUID timestamp is the
+-- number of seconds since the KSUID epoch.
+UPDATE user_session
+  SET auth_time = to_timestamp(('x' || encode(substring(id FROM 1 FOR 4), 'hex'))::BIT(32)::BIGINT + 1400000000);
+
+ALTER TABLE user_session ALTER COLUMN auth_time SET NOT NULL;
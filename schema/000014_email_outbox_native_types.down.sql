@@ -0,0 +1,55 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+
+-- Encoding 20 KSUID bytes into a base62 string.
+CREATE OR REPLACE FUNCTION ksuid_encode_base62(b BYTEA) RETURNS TEXT AS $$
+DECLARE
+  alphabet CONSTANT TEXT := '0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz';
+  n NUMERIC := 0;
+  s TEXT := '';
+BEGIN
+  FOR i IN 0..octet_length(b) - 1 LOOP
+    n := n * 256 + get_byte(b, i);
+  END LOOP;
+
+  FOR i IN 1..27 LOOP
+    s := substr(alphabet, mod(n, 62)::INTEGER + 1, 1) || s;
+    n := div(n, 62);
+  END LOOP;
+
+  RETURN s;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE STRICT;
+
+DROP INDEX IF EXISTS email_outbox_pending_idx;
+
+ALTER TABLE email_outbox
+  DROP CONSTRAINT email_outbox_pkey,
+  DROP CONSTRAINT email_outbox_id_length;
+
+ALTER TABLE email_outbox
+  ALTER COLUMN id         TYPE CHAR(27)  USING ksuid_encode_base62(id),
+  ALTER COLUMN next_at    TYPE TIMESTAMP USING next_at AT TIME ZONE 'UTC',
+  ALTER COLUMN dead_at    TYPE TIMESTAMP USING dead_at AT TIME ZONE 'UTC',
+  ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE email_outbox ADD CONSTRAINT email_outbox_pkey PRIMARY KEY (id);
+
+CREATE INDEX IF NOT EXISTS email_outbox_pending_idx ON email_outbox (next_at) WHERE dead_at IS NULL;
+
+DROP FUNCTION ksuid_encode_base62(BYTEA);
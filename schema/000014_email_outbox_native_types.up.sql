@@ -0,0 +1,55 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+
+-- Decoding a base62 string encoded KSUID into 20 bytes.
+CREATE OR REPLACE FUNCTION ksuid_decode_base62(s TEXT) RETURNS BYTEA AS $$
+DECLARE
+  alphabet CONSTANT TEXT := '0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz';
+  n NUMERIC := 0;
+  b BYTEA := '\x0000000000000000000000000000000000000000';
+BEGIN
+  FOR i IN 1..length(s) LOOP
+    n := n * 62 + strpos(alphabet, substr(s, i, 1)) - 1;
+  END LOOP;
+
+  FOR i IN REVERSE 19..0 LOOP
+    b := set_byte(b, i, mod(n, 256)::INTEGER);
+    n := div(n, 256);
+  END LOOP;
+
+  RETURN b;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE STRICT;
+
+DROP INDEX IF EXISTS email_outbox_pending_idx;
+
+ALTER TABLE email_outbox DROP CONSTRAINT email_outbox_pkey;
+
+ALTER TABLE email_outbox
+  ALTER COLUMN id         TYPE BYTEA       USING ksuid_decode_base62(id),
+  ALTER COLUMN next_at    TYPE TIMESTAMPTZ USING next_at AT TIME ZONE 'UTC',
+  ALTER COLUMN dead_at    TYPE TIMESTAMPTZ USING dead_at AT TIME ZONE 'UTC',
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE email_outbox
+  ADD CONSTRAINT email_outbox_pkey PRIMARY KEY (id),
+  ADD CONSTRAINT email_outbox_id_length CHECK (octet_length(id) = 20);
+
+CREATE INDEX IF NOT EXISTS email_outbox_pending_idx ON email_outbox (next_at) WHERE dead_at IS NULL;
+
+DROP FUNCTION ksuid_decode_base62(TEXT);